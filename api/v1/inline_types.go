@@ -7,6 +7,7 @@ package v1
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -72,6 +73,13 @@ type BaseAgentSpec struct {
 	// +kubebuilder:validation:Optional
 	KeysSecret string `json:"keysSecret,omitempty"`
 
+	// Name of a SecretProviderClass of the Secrets Store CSI driver in the namespace of the CR, as an alternative to the
+	// KeysSecret. Its objects are mounted as files into the agent and k8sensor pods and must be named like the files
+	// of the secret mounts, e.g. INSTANA_AGENT_KEY, INSTANA_DOWNLOAD_KEY, INSTANA_AGENT_PROXY_USER,
+	// INSTANA_AGENT_PROXY_PASSWORD and HTTPS_PROXY. Requires useSecretMounts and a single backend.
+	// +kubebuilder:validation:Optional
+	SecretProviderClass string `json:"secretProviderClass,omitempty"`
+
 	// ListenAddress is the IP addresses the Agent HTTP server will listen on. Normally this will just be localhost (`127.0.0.1`),
 	// the pod public IP and any container runtime bridge interfaces. Set `listenAddress: *` for making the Agent listen on all
 	// network interfaces.
@@ -128,6 +136,10 @@ type BaseAgentSpec struct {
 	// proxyPassword sets the INSTANA_AGENT_PROXY_PASSWORD environment variable.
 	// +kubebuilder:validation:Optional
 	ProxyPassword string `json:"proxyPassword,omitempty"`
+	// proxyPasswordSecretRef references the proxy password in a Secret in the namespace of the CR instead of setting
+	// proxyPassword. Requires useSecretMounts.
+	// +kubebuilder:validation:Optional
+	ProxyPasswordSecretRef *corev1.SecretKeySelector `json:"proxyPasswordSecretRef,omitempty"`
 	// proxyUseDNS sets the INSTANA_AGENT_PROXY_USE_DNS environment variable.
 	// +kubebuilder:validation:Optional
 	ProxyUseDNS bool `json:"proxyUseDNS,omitempty"`
@@ -173,6 +185,10 @@ type BaseAgentSpec struct {
 	// Sets the AGENT_RELEASE_REPOSITORY_MIRROR_PASSWORD environment variable
 	// +kubebuilder:validation:Optional
 	MirrorReleaseRepoPassword string `json:"agentReleaseRepoMirrorPassword,omitempty"`
+	// References the AGENT_RELEASE_REPOSITORY_MIRROR_PASSWORD in a Secret in the namespace of the CR instead of setting
+	// agentReleaseRepoMirrorPassword. Requires useSecretMounts.
+	// +kubebuilder:validation:Optional
+	MirrorReleaseRepoPasswordSecretRef *corev1.SecretKeySelector `json:"agentReleaseRepoMirrorPasswordSecretRef,omitempty"`
 	// Sets the INSTANA_SHARED_REPOSITORY_MIRROR_URL environment variable
 	// +kubebuilder:validation:Optional
 	MirrorSharedRepoUrl string `json:"instanaSharedRepoMirrorUrl,omitempty"`
@@ -182,6 +198,10 @@ type BaseAgentSpec struct {
 	// Sets the INSTANA_SHARED_REPOSITORY_MIRROR_PASSWORD environment variable
 	// +kubebuilder:validation:Optional
 	MirrorSharedRepoPassword string `json:"instanaSharedRepoMirrorPassword,omitempty"`
+	// References the INSTANA_SHARED_REPOSITORY_MIRROR_PASSWORD in a Secret in the namespace of the CR instead of
+	// setting instanaSharedRepoMirrorPassword. Requires useSecretMounts.
+	// +kubebuilder:validation:Optional
+	MirrorSharedRepoPasswordSecretRef *corev1.SecretKeySelector `json:"instanaSharedRepoMirrorPasswordSecretRef,omitempty"`
 }
 
 type ResourceRequirements corev1.ResourceRequirements
@@ -255,6 +275,36 @@ type TlsSpec struct {
 	// key (together with certificate) is the alternative to an existing Secret. Must be base64 encoded.
 	// +kubebuilder:validation:Optional
 	Key []byte `json:"key,omitempty"`
+	// certManager lets cert-manager issue the certificate into the Secret named by secretName, or "<name>-tls" if
+	// empty. The agents are restarted whenever the certificate is renewed.
+	// +kubebuilder:validation:Optional
+	CertManager *CertManagerSpec `json:"certManager,omitempty"`
+	// autoGenerate makes the operator generate a CA and a certificate signed by it into the Secret named by secretName,
+	// or "<name>-tls" if empty, and rotate them before they expire. The CA is published in the ConfigMap
+	// "<name>-ca" for clients of the agent.
+	// +kubebuilder:validation:Optional
+	AutoGenerate bool `json:"autoGenerate,omitempty"`
+}
+
+type CertManagerSpec struct {
+	// issuerRef references the cert-manager Issuer or ClusterIssuer issuing the certificate.
+	// +kubebuilder:validation:Required
+	IssuerRef CertManagerIssuerRef `json:"issuerRef"`
+	// dnsNames are issued in addition to the DNS names of the agent Service and headless Service.
+	// +kubebuilder:validation:Optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+}
+
+type CertManagerIssuerRef struct {
+	// name of the issuer.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// kind of the issuer, Issuer if empty.
+	// +kubebuilder:validation:Optional
+	Kind string `json:"kind,omitempty"`
+	// group of the issuer, cert-manager.io if empty.
+	// +kubebuilder:validation:Optional
+	Group string `json:"group,omitempty"`
 }
 
 type ImageSpec struct {
@@ -484,3 +534,77 @@ type Zone struct {
 	// +kubebuilder:validation:Optional
 	Mode AgentMode `json:"mode,omitempty"`
 }
+
+// FieldOwnershipSpec lets other controllers own fields of the resources managed by the operator
+type FieldOwnershipSpec struct {
+	// Fields that are left out when applying the resources of a component, so that the operator neither sets them nor
+	// takes back ownership of them, e.g. `spec.replicas` of the k8sensor Deployment scaled by a HorizontalPodAutoscaler.
+	// +kubebuilder:validation:Optional
+	IgnoredFields []IgnoredField `json:"ignoredFields,omitempty"`
+
+	// Field managers whose changes to the resources managed by the operator do not trigger a reconcile, e.g. the
+	// manager of a HorizontalPodAutoscaler or Reloader.
+	// +kubebuilder:validation:Optional
+	FieldManagers []string `json:"fieldManagers,omitempty"`
+}
+
+// IgnoredField identifies a field of the resources of a component that the operator does not apply
+type IgnoredField struct {
+	// Component whose resources the field is left out of, either instana-agent or k8sensor for an InstanaAgent and
+	// instana-agent-remote for an InstanaAgentRemote.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=instana-agent;k8sensor;instana-agent-remote
+	Component string `json:"component"`
+
+	// Kind of the resources the field is left out of, e.g. Deployment. Applies to all resources of the component if
+	// unset.
+	// +kubebuilder:validation:Optional
+	Kind string `json:"kind,omitempty"`
+
+	// Path of the field, separated by dots. Keys containing dots are enclosed in brackets, e.g.
+	// `spec.template.metadata.annotations[reloader.stakater.com/last-reloaded-from]`. Paths into lists apply to all
+	// of their items, e.g. `spec.template.spec.containers.resources`.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+}
+
+// Segments splits the path of the ignored field into the keys of the nested fields it addresses
+func (in *IgnoredField) Segments() ([]string, error) {
+	segments := make([]string, 0, strings.Count(in.Path, ".")+1)
+
+	for rest := in.Path; ; {
+		var segment string
+		switch {
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("missing closing bracket in path %q", in.Path)
+			}
+			segment, rest = rest[1:end], rest[end+1:]
+			if rest != "" && !strings.HasPrefix(rest, ".") && !strings.HasPrefix(rest, "[") {
+				return nil, fmt.Errorf("missing separator after bracket in path %q", in.Path)
+			}
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			segment, rest = rest[:end], rest[end:]
+		}
+
+		if segment == "" {
+			return nil, fmt.Errorf("empty key in path %q", in.Path)
+		}
+		segments = append(segments, segment)
+
+		switch {
+		case rest == "":
+			return segments, nil
+		case rest == ".":
+			return nil, fmt.Errorf("empty key in path %q", in.Path)
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+		}
+	}
+}
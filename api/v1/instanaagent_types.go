@@ -87,6 +87,10 @@ type InstanaAgentSpec struct {
 
 	// +kubebuilder:validation:Optional
 	ServiceMesh ServiceMeshSpec `json:"serviceMesh,omitempty"`
+
+	// Lets other controllers own selected fields of the resources managed by the operator.
+	// +kubebuilder:validation:Optional
+	Ownership FieldOwnershipSpec `json:"ownership,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -97,6 +101,182 @@ type ResourceInfo struct {
 	UID  string `json:"uid"`
 }
 
+// ResourceInventoryEntry identifies an object the operator applied for a CR along with a hash of the applied content
+type ResourceInventoryEntry struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// UID of the applied object, known once it has been created
+	// +kubebuilder:validation:Optional
+	UID string `json:"uid,omitempty"`
+	// Hash is the sha256 of the applied object, leaving out the data of Secrets
+	// +kubebuilder:validation:Optional
+	Hash string `json:"hash,omitempty"`
+}
+
+// ZoneStatus reports the agents of a zone as counted by the status of its DaemonSet
+type ZoneStatus struct {
+	// Name of the zone
+	Name string `json:"name"`
+	// Mode the agents of the zone run in
+	// +kubebuilder:validation:Optional
+	Mode AgentMode `json:"mode,omitempty"`
+	// DaemonSet running the agents of the zone
+	DaemonSet ResourceInfo `json:"daemonSet"`
+	// Number of nodes that should run an agent of the zone
+	Desired int32 `json:"desired"`
+	// Number of nodes running a ready agent of the zone
+	Ready int32 `json:"ready"`
+	// Number of nodes running an agent of the zone with the current pod template
+	Updated int32 `json:"updated"`
+	// Number of nodes running an available agent of the zone
+	Available int32 `json:"available"`
+}
+
+// K8sSensorStatus reports the k8sensor Deployment of a backend as counted by its status
+type K8sSensorStatus struct {
+	// Endpoint of the backend the k8sensor reports to, as host:port
+	Endpoint string `json:"endpoint"`
+	// Deployment running the k8sensor of the backend
+	Deployment ResourceInfo `json:"deployment"`
+	// Number of desired k8sensor replicas
+	Desired int32 `json:"desired"`
+	// Number of ready k8sensor replicas
+	Ready int32 `json:"ready"`
+	// Number of k8sensor replicas with the current pod template
+	Updated int32 `json:"updated"`
+	// Number of available k8sensor replicas
+	Available int32 `json:"available"`
+}
+
+// NodeCoverageReason explains why a node has no ready agent
+// +kubebuilder:validation:Enum=UntoleratedTaint;NodeSelectorMismatch;InsufficientResources;Unschedulable;CrashLoopBackOff;NotReady;NoAgentPod
+//
+//nolint:lll
+type NodeCoverageReason string
+
+const (
+	// NodeCoverageReasonUntoleratedTaint the node has a taint the agent pods do not tolerate
+	NodeCoverageReasonUntoleratedTaint NodeCoverageReason = "UntoleratedTaint"
+	// NodeCoverageReasonNodeSelectorMismatch the node does not match the node selector or affinity of any agent
+	// DaemonSet, e.g. because it is not part of any zone
+	NodeCoverageReasonNodeSelectorMismatch NodeCoverageReason = "NodeSelectorMismatch"
+	// NodeCoverageReasonInsufficientResources the agent pod of the node is pending because the node lacks resources
+	NodeCoverageReasonInsufficientResources NodeCoverageReason = "InsufficientResources"
+	// NodeCoverageReasonUnschedulable the agent pod of the node is pending for another reason reported by the scheduler
+	NodeCoverageReasonUnschedulable NodeCoverageReason = "Unschedulable"
+	// NodeCoverageReasonCrashLoopBackOff the agent container of the node keeps crashing
+	NodeCoverageReasonCrashLoopBackOff NodeCoverageReason = "CrashLoopBackOff"
+	// NodeCoverageReasonNotReady the agent pod of the node is not ready yet
+	NodeCoverageReasonNotReady NodeCoverageReason = "NotReady"
+	// NodeCoverageReasonNoAgentPod the node is targeted by an agent DaemonSet, but no agent pod has been created for it
+	NodeCoverageReasonNoAgentPod NodeCoverageReason = "NoAgentPod"
+)
+
+// UncoveredNode is a node without a ready agent
+type UncoveredNode struct {
+	// Name of the node
+	Name   string             `json:"name"`
+	Reason NodeCoverageReason `json:"reason"`
+	// Message details the reason, e.g. the untolerated taint or the message of the scheduler
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// NodeCoverageStatus compares the nodes of the cluster with the agent pods running on them
+type NodeCoverageStatus struct {
+	// Number of nodes in the cluster, including cordoned nodes
+	Nodes int32 `json:"nodes"`
+	// Number of nodes running a ready agent
+	Covered int32 `json:"covered"`
+	// Uncovered lists the nodes without a ready agent, limited to the first 50 nodes by name
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	Uncovered []UncoveredNode `json:"uncovered,omitempty"`
+}
+
+// ETCDMonitoringSource is where the ETCD targets monitored by the k8sensor come from
+// +kubebuilder:validation:Enum=Spec;Discovered;OpenShift
+type ETCDMonitoringSource string
+
+const (
+	// ETCDMonitoringSourceSpec the targets are configured in spec.k8s_sensor.etcd.targets
+	ETCDMonitoringSourceSpec ETCDMonitoringSource = "Spec"
+	// ETCDMonitoringSourceDiscovered the targets are discovered from the ETCD Service in kube-system
+	ETCDMonitoringSourceDiscovered ETCDMonitoringSource = "Discovered"
+	// ETCDMonitoringSourceOpenShift the ETCD CA bundle and client certificate are copied from openshift-etcd
+	ETCDMonitoringSourceOpenShift ETCDMonitoringSource = "OpenShift"
+)
+
+// ETCDMonitoringStatus reports how the k8sensor monitors ETCD, or why it does not
+type ETCDMonitoringStatus struct {
+	// Source of the ETCD targets, empty while ETCD monitoring is disabled
+	// +kubebuilder:validation:Optional
+	Source ETCDMonitoringSource `json:"source,omitempty"`
+	// Targets are the ETCD metrics endpoints monitored by the k8sensor
+	// +kubebuilder:validation:Optional
+	Targets []string `json:"targets,omitempty"`
+	// CAFound tells whether a CA to verify the ETCD endpoints was found
+	CAFound bool `json:"caFound"`
+	// DisabledReason explains why ETCD monitoring is disabled
+	// +kubebuilder:validation:Optional
+	DisabledReason string `json:"disabledReason,omitempty"`
+}
+
+// TLSStatus reports when the CA and the certificate generated for the agent TLS listener expire. Both are rotated
+// well before.
+type TLSStatus struct {
+	// CANotAfter is when the generated CA expires
+	CANotAfter metav1.Time `json:"caNotAfter"`
+	// CertificateNotAfter is when the generated certificate of the agent expires
+	CertificateNotAfter metav1.Time `json:"certificateNotAfter"`
+}
+
+// ReconcilePausedAnnotation makes the operator skip applying the spec of an InstanaAgent or InstanaAgentRemote and
+// cleaning up its dependents, e.g. to keep manual changes to them in place, while its status is still updated
+const ReconcilePausedAnnotation = "instana.io/reconcile-paused"
+
+// PreviewAnnotation makes the operator dry-run the spec of the InstanaAgent against the cluster and report the
+// resulting changes in the status, instead of applying them
+const PreviewAnnotation = "instana.io/preview"
+
+// PreviewAction describes what applying the spec of a CR would do to an object
+// +kubebuilder:validation:Enum=Create;Update;Delete
+type PreviewAction string
+
+const (
+	PreviewActionCreate PreviewAction = "Create"
+	PreviewActionUpdate PreviewAction = "Update"
+	PreviewActionDelete PreviewAction = "Delete"
+)
+
+// PreviewEntry describes how an object would change if the spec of the CR was applied
+type PreviewEntry struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// +kubebuilder:validation:Optional
+	Namespace string        `json:"namespace,omitempty"`
+	Name      string        `json:"name"`
+	Action    PreviewAction `json:"action"`
+	// ChangedFields lists the paths of the fields that would be updated, e.g. spec.template.spec.containers
+	// +kubebuilder:validation:Optional
+	ChangedFields []string `json:"changedFields,omitempty"`
+}
+
+// PreviewStatus summarizes the server-side dry-run of the spec of a CR, which is performed instead of the actual apply
+// while the CR is annotated with instana.io/preview: "true"
+type PreviewStatus struct {
+	// ObservedGeneration is the generation of the CR that was dry-run
+	ObservedGeneration int64       `json:"observedGeneration"`
+	Time               metav1.Time `json:"time"`
+	// Objects lists the objects that would be created, updated or deleted, unchanged objects are left out
+	// +kubebuilder:validation:Optional
+	Objects []PreviewEntry `json:"objects,omitempty"`
+}
+
 // AgentOperatorState type representing the running state of the Agent Operator itself.
 type AgentOperatorState string
 
@@ -126,6 +306,39 @@ type DeprecatedInstanaAgentStatus struct {
 	LeadingAgentPod map[string]ResourceInfo `json:"leadingAgentPod,omitempty"`
 }
 
+// ReconcileState is the outcome of the most recent reconcile of an agent CR
+// +kubebuilder:validation:Enum=Succeeded;Failed;Paused;Preview
+type ReconcileState string
+
+const (
+	// ReconcileStateSucceeded the spec was applied without issue
+	ReconcileStateSucceeded ReconcileState = "Succeeded"
+	// ReconcileStateFailed applying the spec failed, see the ReconcileSucceeded condition
+	ReconcileStateFailed ReconcileState = "Failed"
+	// ReconcileStatePaused the spec was not applied because reconciliation is paused
+	ReconcileStatePaused ReconcileState = "Paused"
+	// ReconcileStatePreview the spec was only previewed, see status.preview
+	ReconcileStatePreview ReconcileState = "Preview"
+)
+
+// ReconcileAttempt records a reconcile of an agent CR
+type ReconcileAttempt struct {
+	// Time the reconcile started
+	Time metav1.Time `json:"time"`
+	// Generation of the CR that was reconciled
+	Generation int64 `json:"generation"`
+	// Duration of the reconcile
+	Duration metav1.Duration `json:"duration"`
+	// Result of the reconcile
+	Result ReconcileState `json:"result"`
+	// Object the API server rejected when the reconcile failed, as kind/name
+	// +kubebuilder:validation:Optional
+	Object string `json:"object,omitempty"`
+	// Error the reconcile failed with, truncated to 1024 characters
+	// +kubebuilder:validation:Optional
+	Error string `json:"error,omitempty"`
+}
+
 // +kubebuilder:validation:Type=string
 // +kubebuilder:validation:Pattern=`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`
 
@@ -145,6 +358,50 @@ type InstanaAgentStatus struct {
 	// +kubebuilder:validation:Minimum=0
 	ObservedGeneration *int64           `json:"observedGeneration,omitempty"`
 	OperatorVersion    *SemanticVersion `json:"operatorVersion,omitempty"`
+	// Inventory lists the objects applied by the operator for this CR, objects of earlier generations that are no
+	// longer part of it are removed by the operator
+	// +kubebuilder:validation:Optional
+	Inventory []ResourceInventoryEntry `json:"inventory,omitempty"`
+	// Preview holds the changes the current spec would apply, while the CR is annotated with instana.io/preview
+	// +kubebuilder:validation:Optional
+	Preview *PreviewStatus `json:"preview,omitempty"`
+	// Zones reports the agent DaemonSet of every zone configured in spec.zones
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	Zones []ZoneStatus `json:"zones,omitempty"`
+	// NodeCoverage reports the nodes that do not run a ready agent and why
+	// +kubebuilder:validation:Optional
+	NodeCoverage *NodeCoverageStatus `json:"nodeCoverage,omitempty"`
+	// K8sSensors reports the k8sensor Deployment of every backend
+	// +kubebuilder:validation:Optional
+	K8sSensors []K8sSensorStatus `json:"k8sSensors,omitempty"`
+	// ETCDMonitoring reports how the k8sensor monitors ETCD
+	// +kubebuilder:validation:Optional
+	ETCDMonitoring *ETCDMonitoringStatus `json:"etcdMonitoring,omitempty"`
+	// Agents summarizes the agent DaemonSets of all zones as ready/desired agents
+	// +kubebuilder:validation:Optional
+	Agents string `json:"agents,omitempty"`
+	// K8sSensor summarizes the k8sensor Deployments of all backends as ready/desired replicas
+	// +kubebuilder:validation:Optional
+	K8sSensor string `json:"k8sSensor,omitempty"`
+	// ReconcileState is the outcome of the most recent reconcile
+	// +kubebuilder:validation:Optional
+	ReconcileState ReconcileState `json:"reconcileState,omitempty"`
+	// Cluster is the name of the cluster the agents report, or of their zone if no cluster name is set
+	// +kubebuilder:validation:Optional
+	Cluster string `json:"cluster,omitempty"`
+	// AgentImage is the image run by the agent DaemonSets
+	// +kubebuilder:validation:Optional
+	AgentImage string `json:"agentImage,omitempty"`
+	// ReconcileHistory lists the last reconciles of the CR, oldest first. Consecutive reconciles of the same generation
+	// with the same result and error are recorded once.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	ReconcileHistory []ReconcileAttempt `json:"reconcileHistory,omitempty"`
+	// TLS reports the certificates generated by the operator while spec.agent.tls.autoGenerate is set
+	// +kubebuilder:validation:Optional
+	TLS *TLSStatus `json:"tls,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -152,6 +409,13 @@ type InstanaAgentStatus struct {
 // +kubebuilder:subresource:status
 //nolint:lll
 // +kubebuilder:resource:path=agents,singular=agent,shortName=ia;instanaagent;instanaagents,scope=Namespaced,categories=monitoring;openshift-optional
+// +kubebuilder:printcolumn:name="Agents",type=string,JSONPath=`.status.agents`,description="Ready/desired agents"
+// +kubebuilder:printcolumn:name="K8sensor",type=string,JSONPath=`.status.k8sSensor`,description="Ready/desired k8sensor replicas"
+// +kubebuilder:printcolumn:name="Reconcile",type=string,JSONPath=`.status.reconcileState`
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.status.cluster`
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.status.agentImage`,priority=1
+// +kubebuilder:printcolumn:name="Operator",type=string,JSONPath=`.status.operatorVersion`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:storageversion
 // +operator-sdk:csv:customresourcedefinitions:displayName="Instana Agent", resources={{DaemonSet,v1,instana-agent},{Pod,v1,instana-agent},{Secret,v1,instana-agent}}
 
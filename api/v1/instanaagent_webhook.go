@@ -0,0 +1,107 @@
+/*
+(c) Copyright IBM Corp. 2025
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupInstanaAgentWebhookWithManager registers the defaulting and validating webhooks for InstanaAgent with the manager
+func SetupInstanaAgentWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &InstanaAgent{}).
+		WithDefaulter(&instanaAgentDefaulter{}).
+		WithValidator(NewInstanaAgentValidator()).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-instana-io-v1-instanaagent,mutating=true,failurePolicy=fail,sideEffects=None,groups=instana.io,resources=agents,verbs=create;update,versions=v1,name=minstanaagent.instana.io,admissionReviewVersions=v1
+
+// instanaAgentDefaulter persists the InstanaAgent defaults on the stored CR so they are visible to users and drift tooling
+type instanaAgentDefaulter struct{}
+
+var _ admission.Defaulter[*InstanaAgent] = &instanaAgentDefaulter{}
+
+func (d *instanaAgentDefaulter) Default(_ context.Context, agent *InstanaAgent) error {
+	agent.Default()
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-instana-io-v1-instanaagent,mutating=false,failurePolicy=fail,sideEffects=None,groups=instana.io,resources=agents,verbs=create;update,versions=v1,name=vinstanaagent.instana.io,admissionReviewVersions=v1
+
+// instanaAgentValidator rejects InstanaAgent specs the builders would silently skip
+type instanaAgentValidator struct{}
+
+var _ admission.Validator[*InstanaAgent] = &instanaAgentValidator{}
+
+// NewInstanaAgentValidator returns the validator of InstanaAgents, which the webhooks of the other versions delegate
+// to after converting to the hub version
+func NewInstanaAgentValidator() admission.Validator[*InstanaAgent] {
+	return &instanaAgentValidator{}
+}
+
+func (v *instanaAgentValidator) ValidateCreate(_ context.Context, agent *InstanaAgent) (admission.Warnings, error) {
+	return nil, agent.validate()
+}
+
+func (v *instanaAgentValidator) ValidateUpdate(
+	_ context.Context,
+	oldAgent *InstanaAgent,
+	agent *InstanaAgent,
+) (admission.Warnings, error) {
+	if skipUpdateValidation(agent, equality.Semantic.DeepEqual(oldAgent.Spec, agent.Spec)) {
+		return nil, nil
+	}
+	if len(oldAgent.Spec.Validate()) > 0 {
+		return errorsAsWarnings(agent.Spec.Validate()), nil
+	}
+	return nil, agent.validate()
+}
+
+func (v *instanaAgentValidator) ValidateDelete(_ context.Context, _ *InstanaAgent) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// skipUpdateValidation admits updates of objects being deleted and updates that leave the spec unchanged, e.g. the
+// finalizers added and removed by the operator, even if the spec is invalid under rules introduced after it was stored
+func skipUpdateValidation(obj metav1.Object, specUnchanged bool) bool {
+	return obj.GetDeletionTimestamp() != nil || specUnchanged
+}
+
+// errorsAsWarnings reports the errors of a spec that was already invalid before the update as warnings, so that it can
+// be fixed one step at a time
+func errorsAsWarnings(errs field.ErrorList) admission.Warnings {
+	warnings := make(admission.Warnings, 0, len(errs))
+	for _, err := range errs {
+		warnings = append(warnings, err.Error())
+	}
+	return warnings
+}
+
+func (in *InstanaAgent) validate() error {
+	allErrs := in.Spec.Validate()
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("InstanaAgent").GroupKind(), in.Name, allErrs)
+}
@@ -0,0 +1,107 @@
+/*
+(c) Copyright IBM Corp. 2025
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInstanaAgentValidator(t *testing.T) {
+	validator := &instanaAgentValidator{}
+	ctx := context.Background()
+
+	valid := &InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent"},
+		Spec: InstanaAgentSpec{
+			Agent:   BaseAgentSpec{Key: "key"},
+			Cluster: Name{Name: "cluster"},
+		},
+	}
+	invalid := &InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent"},
+	}
+
+	warnings, err := validator.ValidateCreate(ctx, valid)
+	require.Empty(t, warnings)
+	require.NoError(t, err)
+
+	warnings, err = validator.ValidateUpdate(ctx, valid, valid)
+	require.Empty(t, warnings)
+	require.NoError(t, err)
+
+	_, err = validator.ValidateCreate(ctx, invalid)
+	require.True(t, apierrors.IsInvalid(err))
+
+	_, err = validator.ValidateUpdate(ctx, valid, invalid)
+	require.True(t, apierrors.IsInvalid(err))
+
+	statusErr := &apierrors.StatusError{}
+	require.ErrorAs(t, err, &statusErr)
+	require.Len(t, statusErr.ErrStatus.Details.Causes, 2)
+
+	warnings, err = validator.ValidateDelete(ctx, invalid)
+	require.Empty(t, warnings)
+	require.NoError(t, err)
+}
+
+func TestInstanaAgentValidatorAdmitsUpdatesOfInvalidAgents(t *testing.T) {
+	validator := &instanaAgentValidator{}
+	ctx := context.Background()
+
+	invalid := &InstanaAgent{ObjectMeta: metav1.ObjectMeta{Name: "instana-agent"}}
+
+	// the finalizers of the operator can be added and removed on agents stored before the rules were introduced
+	withFinalizer := invalid.DeepCopy()
+	withFinalizer.Finalizers = []string{"test.instana.io/finalizer"}
+	warnings, err := validator.ValidateUpdate(ctx, invalid, withFinalizer)
+	require.Empty(t, warnings)
+	require.NoError(t, err)
+
+	deleting := invalid.DeepCopy()
+	deleting.DeletionTimestamp = &metav1.Time{}
+	deleting.Spec.Agent.EndpointHost = "ingress-red-saas.instana.io"
+	warnings, err = validator.ValidateUpdate(ctx, invalid, deleting)
+	require.Empty(t, warnings)
+	require.NoError(t, err)
+
+	// agents that were already invalid can be fixed one step at a time
+	partiallyFixed := invalid.DeepCopy()
+	partiallyFixed.Spec.Agent.Key = "key"
+	warnings, err = validator.ValidateUpdate(ctx, invalid, partiallyFixed)
+	require.Len(t, warnings, 1)
+	require.NoError(t, err)
+}
+
+func TestInstanaAgentDefaulter(t *testing.T) {
+	agent := &InstanaAgent{
+		Spec: InstanaAgentSpec{
+			Agent: BaseAgentSpec{EndpointHost: "custom.instana.io"},
+		},
+	}
+	expected := agent.DeepCopy()
+	expected.Default()
+
+	require.NoError(t, (&instanaAgentDefaulter{}).Default(context.Background(), agent))
+	require.Equal(t, expected, agent)
+	require.Equal(t, "custom.instana.io", agent.Spec.Agent.EndpointHost)
+	require.Equal(t, "443", agent.Spec.Agent.EndpointPort)
+}
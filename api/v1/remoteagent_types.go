@@ -51,6 +51,10 @@ type InstanaAgentRemoteSpec struct {
 
 	// +kubebuilder:validation:Optional
 	Hostname *Name `json:"hostname,omitempty"`
+
+	// Lets other controllers own selected fields of the resources managed by the operator.
+	// +kubebuilder:validation:Optional
+	Ownership FieldOwnershipSpec `json:"ownership,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -64,6 +68,22 @@ const (
 	RemoteOperatorStateFailed   InstanaAgentRemoteOperatorState = "Failed"
 )
 
+// RemoteAgentDeploymentStatus reports the agent Deployment of a backend as counted by its status
+type RemoteAgentDeploymentStatus struct {
+	// Endpoint of the backend the agent reports to, as host:port
+	Endpoint string `json:"endpoint"`
+	// Deployment running the agent of the backend
+	Deployment ResourceInfo `json:"deployment"`
+	// Number of desired agent replicas
+	Desired int32 `json:"desired"`
+	// Number of ready agent replicas
+	Ready int32 `json:"ready"`
+	// Number of agent replicas with the current pod template
+	Updated int32 `json:"updated"`
+	// Number of available agent replicas
+	Available int32 `json:"available"`
+}
+
 // +k8s:openapi-gen=true
 
 type InstanaAgentRemoteStatus struct {
@@ -71,7 +91,18 @@ type InstanaAgentRemoteStatus struct {
 	Conditions         []metav1.Condition `json:"conditions,omitempty"`
 	ObservedGeneration *int64             `json:"observedGeneration,omitempty"`
 	OperatorVersion    *SemanticVersion   `json:"operatorVersion,omitempty"`
-	Deployment         ResourceInfo       `json:"deployment,omitempty"`
+	// Deployment is only reported when a single backend is configured, see Deployments
+	Deployment ResourceInfo `json:"deployment,omitempty"`
+	// Deployments reports the agent Deployment of every backend
+	// +kubebuilder:validation:Optional
+	Deployments []RemoteAgentDeploymentStatus `json:"deployments,omitempty"`
+	// AvailableBackends counts the backends with an available agent Deployment, as available/configured
+	// +kubebuilder:validation:Optional
+	AvailableBackends string `json:"availableBackends,omitempty"`
+	// Inventory lists the objects applied by the operator for this CR, objects of earlier generations that are no
+	// longer part of it are removed by the operator
+	// +kubebuilder:validation:Optional
+	Inventory []ResourceInventoryEntry `json:"inventory,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -79,6 +110,12 @@ type InstanaAgentRemoteStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=agentsremote,singular=agentremote,shortName=ar,scope=Namespaced,categories=monitoring;openshift-optional
 // +kubebuilder:storageversion
+//nolint:lll
+// +kubebuilder:printcolumn:name="Backends",type=string,JSONPath=`.status.availableBackends`,description="Backends with an available agent Deployment"
+// +kubebuilder:printcolumn:name="Available",type=string,JSONPath=`.status.conditions[?(@.type=="AgentAvailable")].status`
+// +kubebuilder:printcolumn:name="Reconciled",type=string,JSONPath=`.status.conditions[?(@.type=="ReconcileSucceeded")].status`
+// +kubebuilder:printcolumn:name="Zone",type=string,JSONPath=`.spec.zone.name`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +operator-sdk:csv:customresourcedefinitions:displayName="Remote Instana Agent",resources={{Deployment,apps/v1,InstanaAgentRemote},{Pod,v1,InstanaAgentRemote},{Secret,v1,InstanaAgentRemote}}
 
 type InstanaAgentRemote struct {
@@ -0,0 +1,95 @@
+/*
+(c) Copyright IBM Corp. 2025
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupInstanaAgentRemoteWebhookWithManager registers the defaulting and validating webhooks for InstanaAgentRemote with the manager
+func SetupInstanaAgentRemoteWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &InstanaAgentRemote{}).
+		WithDefaulter(&instanaAgentRemoteDefaulter{}).
+		WithValidator(NewInstanaAgentRemoteValidator()).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-instana-io-v1-instanaagentremote,mutating=true,failurePolicy=fail,sideEffects=None,groups=instana.io,resources=agentsremote,verbs=create;update,versions=v1,name=minstanaagentremote.instana.io,admissionReviewVersions=v1
+
+// instanaAgentRemoteDefaulter persists the InstanaAgentRemote defaults on the stored CR so they are visible to users and drift tooling
+type instanaAgentRemoteDefaulter struct{}
+
+var _ admission.Defaulter[*InstanaAgentRemote] = &instanaAgentRemoteDefaulter{}
+
+func (d *instanaAgentRemoteDefaulter) Default(_ context.Context, agent *InstanaAgentRemote) error {
+	agent.Default()
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-instana-io-v1-instanaagentremote,mutating=false,failurePolicy=fail,sideEffects=None,groups=instana.io,resources=agentsremote,verbs=create;update,versions=v1,name=vinstanaagentremote.instana.io,admissionReviewVersions=v1
+
+// instanaAgentRemoteValidator rejects InstanaAgentRemote specs the builders would silently skip
+type instanaAgentRemoteValidator struct{}
+
+var _ admission.Validator[*InstanaAgentRemote] = &instanaAgentRemoteValidator{}
+
+// NewInstanaAgentRemoteValidator returns the validator of InstanaAgentRemotes, which the webhooks of the other versions
+// delegate to after converting to the hub version
+func NewInstanaAgentRemoteValidator() admission.Validator[*InstanaAgentRemote] {
+	return &instanaAgentRemoteValidator{}
+}
+
+func (v *instanaAgentRemoteValidator) ValidateCreate(
+	_ context.Context,
+	agent *InstanaAgentRemote,
+) (admission.Warnings, error) {
+	return nil, agent.validate()
+}
+
+func (v *instanaAgentRemoteValidator) ValidateUpdate(
+	_ context.Context,
+	oldAgent *InstanaAgentRemote,
+	agent *InstanaAgentRemote,
+) (admission.Warnings, error) {
+	if skipUpdateValidation(agent, equality.Semantic.DeepEqual(oldAgent.Spec, agent.Spec)) {
+		return nil, nil
+	}
+	if len(oldAgent.Spec.Validate()) > 0 {
+		return errorsAsWarnings(agent.Spec.Validate()), nil
+	}
+	return nil, agent.validate()
+}
+
+func (v *instanaAgentRemoteValidator) ValidateDelete(
+	_ context.Context,
+	_ *InstanaAgentRemote,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (in *InstanaAgentRemote) validate() error {
+	allErrs := in.Spec.Validate()
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("InstanaAgentRemote").GroupKind(), in.Name, allErrs)
+}
@@ -0,0 +1,94 @@
+/*
+(c) Copyright IBM Corp. 2025
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInstanaAgentRemoteValidator(t *testing.T) {
+	validator := &instanaAgentRemoteValidator{}
+	ctx := context.Background()
+
+	valid := &InstanaAgentRemote{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote-agent"},
+		Spec: InstanaAgentRemoteSpec{
+			Agent: BaseAgentSpec{KeysSecret: "keys"},
+			Zone:  Name{Name: "zone"},
+		},
+	}
+	invalid := &InstanaAgentRemote{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote-agent"},
+	}
+
+	_, err := validator.ValidateCreate(ctx, valid)
+	require.NoError(t, err)
+
+	_, err = validator.ValidateUpdate(ctx, invalid, valid)
+	require.NoError(t, err)
+
+	_, err = validator.ValidateCreate(ctx, invalid)
+	require.True(t, apierrors.IsInvalid(err))
+
+	_, err = validator.ValidateUpdate(ctx, valid, invalid)
+	require.True(t, apierrors.IsInvalid(err))
+
+	_, err = validator.ValidateDelete(ctx, invalid)
+	require.NoError(t, err)
+}
+
+func TestInstanaAgentRemoteValidatorAdmitsUpdatesOfInvalidAgents(t *testing.T) {
+	validator := &instanaAgentRemoteValidator{}
+	ctx := context.Background()
+
+	invalid := &InstanaAgentRemote{ObjectMeta: metav1.ObjectMeta{Name: "remote-agent"}}
+
+	// the finalizers of the operator can be added and removed on agents stored before the rules were introduced
+	withFinalizer := invalid.DeepCopy()
+	withFinalizer.Finalizers = []string{"test.instana.io/finalizer"}
+	warnings, err := validator.ValidateUpdate(ctx, invalid, withFinalizer)
+	require.Empty(t, warnings)
+	require.NoError(t, err)
+
+	deleting := invalid.DeepCopy()
+	deleting.DeletionTimestamp = &metav1.Time{}
+	deleting.Spec.Agent.EndpointHost = "ingress-red-saas.instana.io"
+	warnings, err = validator.ValidateUpdate(ctx, invalid, deleting)
+	require.Empty(t, warnings)
+	require.NoError(t, err)
+
+	// agents that were already invalid can be fixed one step at a time
+	partiallyFixed := invalid.DeepCopy()
+	partiallyFixed.Spec.Agent.KeysSecret = "keys"
+	warnings, err = validator.ValidateUpdate(ctx, invalid, partiallyFixed)
+	require.NotEmpty(t, warnings)
+	require.NoError(t, err)
+}
+
+func TestInstanaAgentRemoteDefaulter(t *testing.T) {
+	agent := &InstanaAgentRemote{}
+	expected := agent.DeepCopy()
+	expected.Default()
+
+	require.NoError(t, (&instanaAgentRemoteDefaulter{}).Default(context.Background(), agent))
+	require.Equal(t, expected, agent)
+}
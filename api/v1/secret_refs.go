@@ -0,0 +1,113 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// credentialRef is a credential of the agent spec that is either set inline or referenced in a Secret
+type credentialRef struct {
+	field    string
+	refField string
+	value    *string
+	ref      *corev1.SecretKeySelector
+}
+
+func (in *BaseAgentSpec) credentialRefs() []credentialRef {
+	return []credentialRef{
+		{
+			field:    "proxyPassword",
+			refField: "proxyPasswordSecretRef",
+			value:    &in.ProxyPassword,
+			ref:      in.ProxyPasswordSecretRef,
+		},
+		{
+			field:    "agentReleaseRepoMirrorPassword",
+			refField: "agentReleaseRepoMirrorPasswordSecretRef",
+			value:    &in.MirrorReleaseRepoPassword,
+			ref:      in.MirrorReleaseRepoPasswordSecretRef,
+		},
+		{
+			field:    "instanaSharedRepoMirrorPassword",
+			refField: "instanaSharedRepoMirrorPasswordSecretRef",
+			value:    &in.MirrorSharedRepoPassword,
+			ref:      in.MirrorSharedRepoPasswordSecretRef,
+		},
+	}
+}
+
+// ReferencesSecret reports whether the agent spec reads the keys or any credential from the Secret with the given name
+func (in *BaseAgentSpec) ReferencesSecret(name string) bool {
+	if in.KeysSecret == name {
+		return true
+	}
+	for _, credential := range in.credentialRefs() {
+		if credential.ref != nil && credential.ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveSecretRefs sets the credentials referenced in Secrets on the agent spec, so that they are rendered into the
+// mounted secret files the same way as credentials set inline. The spec must therefore never be written back to the
+// cluster afterwards. getSecret looks up a Secret by name in the namespace of the CR. References that cannot be
+// resolved are returned as errors, unless they are optional.
+func (in *BaseAgentSpec) ResolveSecretRefs(getSecret func(name string) (*corev1.Secret, error)) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, credential := range in.credentialRefs() {
+		if credential.ref == nil {
+			continue
+		}
+		refPath := field.NewPath("spec", "agent", credential.refField)
+		optional := credential.ref.Optional != nil && *credential.ref.Optional
+
+		secret, err := getSecret(credential.ref.Name)
+		switch {
+		case k8serrors.IsNotFound(err):
+			if !optional {
+				allErrs = append(allErrs, field.NotFound(refPath.Child("name"), credential.ref.Name))
+			}
+			continue
+		case err != nil:
+			allErrs = append(allErrs, field.InternalError(refPath, err))
+			continue
+		}
+
+		value, ok := secret.Data[credential.ref.Key]
+		if !ok {
+			if !optional {
+				allErrs = append(
+					allErrs,
+					field.Invalid(
+						refPath.Child("key"),
+						credential.ref.Key,
+						"secret "+credential.ref.Name+" does not contain the key",
+					),
+				)
+			}
+			continue
+		}
+		*credential.value = string(value)
+	}
+
+	return allErrs
+}
@@ -0,0 +1,120 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/instana/instana-agent-operator/pkg/pointer"
+)
+
+func credentialSecretRef(name string, key string) *corev1.SecretKeySelector {
+	return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+}
+
+func TestResolveSecretRefs(t *testing.T) {
+	secrets := map[string]*corev1.Secret{
+		"proxy": {
+			ObjectMeta: metav1.ObjectMeta{Name: "proxy"},
+			Data:       map[string][]byte{"password": []byte("proxy-password")},
+		},
+		"mirror": {
+			ObjectMeta: metav1.ObjectMeta{Name: "mirror"},
+			Data:       map[string][]byte{"release": []byte("release-password")},
+		},
+	}
+	getSecret := func(name string) (*corev1.Secret, error) {
+		if name == "unavailable" {
+			return nil, errors.New("connection refused")
+		}
+		if secret, ok := secrets[name]; ok {
+			return secret, nil
+		}
+		return nil, k8serrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, name)
+	}
+
+	t.Run("resolves_all_references", func(t *testing.T) {
+		agent := BaseAgentSpec{
+			ProxyPasswordSecretRef:             credentialSecretRef("proxy", "password"),
+			MirrorReleaseRepoPasswordSecretRef: credentialSecretRef("mirror", "release"),
+		}
+
+		require.Empty(t, agent.ResolveSecretRefs(getSecret))
+		require.Equal(t, "proxy-password", agent.ProxyPassword)
+		require.Equal(t, "release-password", agent.MirrorReleaseRepoPassword)
+		require.Empty(t, agent.MirrorSharedRepoPassword)
+	})
+
+	t.Run("reports_unresolvable_references", func(t *testing.T) {
+		agentPath := field.NewPath("spec", "agent")
+		agent := BaseAgentSpec{
+			ProxyPasswordSecretRef:             credentialSecretRef("missing", "password"),
+			MirrorReleaseRepoPasswordSecretRef: credentialSecretRef("mirror", "shared"),
+			MirrorSharedRepoPasswordSecretRef:  credentialSecretRef("unavailable", "shared"),
+		}
+
+		require.Equal(
+			t,
+			field.ErrorList{
+				field.NotFound(agentPath.Child("proxyPasswordSecretRef", "name"), "missing"),
+				field.Invalid(
+					agentPath.Child("agentReleaseRepoMirrorPasswordSecretRef", "key"),
+					"shared",
+					"secret mirror does not contain the key",
+				),
+				field.InternalError(
+					agentPath.Child("instanaSharedRepoMirrorPasswordSecretRef"),
+					errors.New("connection refused"),
+				),
+			},
+			agent.ResolveSecretRefs(getSecret),
+		)
+		require.Empty(t, agent.ProxyPassword)
+	})
+
+	t.Run("skips_optional_references", func(t *testing.T) {
+		agent := BaseAgentSpec{
+			ProxyPasswordSecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+				Key:                  "password",
+				Optional:             pointer.To(true),
+			},
+		}
+
+		require.Empty(t, agent.ResolveSecretRefs(getSecret))
+		require.Empty(t, agent.ProxyPassword)
+	})
+}
+
+func TestReferencesSecret(t *testing.T) {
+	agent := BaseAgentSpec{
+		KeysSecret:                        "keys",
+		MirrorSharedRepoPasswordSecretRef: credentialSecretRef("mirror", "shared"),
+	}
+
+	require.True(t, agent.ReferencesSecret("keys"))
+	require.True(t, agent.ReferencesSecret("mirror"))
+	require.False(t, agent.ReferencesSecret("proxy"))
+}
@@ -0,0 +1,414 @@
+/*
+(c) Copyright IBM Corp. 2025
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// PollRateRegex defines the validation pattern for k8sensor pollrate values (seconds only)
+const PollRateRegex = `^[0-9]+s$`
+
+var pollRateRegexp = regexp.MustCompile(PollRateRegex)
+
+// Validate returns all settings of the InstanaAgent spec that the builders would skip or render into a broken
+// deployment. It is shared by the validating webhook and the reconciler.
+func (in *InstanaAgentSpec) Validate() field.ErrorList {
+	specPath := field.NewPath("spec")
+
+	allErrs := validateAgentKeys(&in.Agent, specPath.Child("agent"))
+	allErrs = append(allErrs, validateAgentEndpointPorts(&in.Agent, specPath.Child("agent"))...)
+	allErrs = append(allErrs, validateSecretRefs(&in.Agent, in.UseSecretMounts, specPath.Child("agent"))...)
+	allErrs = append(allErrs, validateSecretProviderClass(&in.Agent, in.UseSecretMounts, specPath.Child("agent"))...)
+	allErrs = append(allErrs, validateTLS(&in.Agent.TlsSpec, specPath.Child("agent", "tls"))...)
+	allErrs = append(allErrs, validateClusterAndZones(in.Cluster, in.Zone, in.Zones, specPath)...)
+	allErrs = append(allErrs, validatePollRate(in.K8sSensor.PollRate, specPath.Child("k8s_sensor", "pollrate"))...)
+	allErrs = append(
+		allErrs,
+		ValidateFieldOwnership(in.Ownership, []string{"instana-agent", "k8sensor"}, specPath.Child("ownership"))...,
+	)
+
+	return allErrs
+}
+
+// Validate returns all settings of the InstanaAgentRemote spec that the builders would skip or render into a broken
+// deployment. It is shared by the validating webhook and the reconciler.
+func (in *InstanaAgentRemoteSpec) Validate() field.ErrorList {
+	specPath := field.NewPath("spec")
+
+	allErrs := validateAgentKeys(&in.Agent, specPath.Child("agent"))
+	allErrs = append(allErrs, validateAgentEndpointPorts(&in.Agent, specPath.Child("agent"))...)
+	allErrs = append(allErrs, validateSecretRefs(&in.Agent, in.UseSecretMounts, specPath.Child("agent"))...)
+	allErrs = append(allErrs, validateSecretProviderClass(&in.Agent, in.UseSecretMounts, specPath.Child("agent"))...)
+
+	if in.Agent.TlsSpec.CertManager != nil {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("agent", "tls", "certManager"), "is not supported for InstanaAgentRemote"),
+		)
+	}
+	if in.Agent.TlsSpec.AutoGenerate {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(specPath.Child("agent", "tls", "autoGenerate"), "is not supported for InstanaAgentRemote"),
+		)
+	}
+
+	if in.Zone.Name == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("zone", "name"), "zone.name must be specified"))
+	}
+
+	allErrs = append(
+		allErrs,
+		ValidateFieldOwnership(in.Ownership, []string{"instana-agent-remote"}, specPath.Child("ownership"))...,
+	)
+
+	return allErrs
+}
+
+// ValidateKeysSecret checks that the external keys secret referenced by agent.keysSecret exists and provides the
+// "key" entry as well as a "key-N" entry for every additional backend. The k8sensor deployments read these entries
+// directly from the secret, regardless of useSecretMounts, so inline backend keys cannot stand in for them.
+func (in *BaseAgentSpec) ValidateKeysSecret(keysSecret *corev1.Secret) field.ErrorList {
+	if in.KeysSecret == "" {
+		return nil
+	}
+
+	keysSecretPath := field.NewPath("spec", "agent", "keysSecret")
+	if keysSecret == nil || keysSecret.Name == "" {
+		return field.ErrorList{field.NotFound(keysSecretPath, in.KeysSecret)}
+	}
+
+	var allErrs field.ErrorList
+
+	requiredKeys := make([]string, 0, len(in.AdditionalBackends)+1)
+	requiredKeys = append(requiredKeys, "key")
+	for i := range in.AdditionalBackends {
+		requiredKeys = append(requiredKeys, "key-"+strconv.Itoa(i+1))
+	}
+
+	for _, key := range requiredKeys {
+		if len(keysSecret.Data[key]) == 0 {
+			allErrs = append(
+				allErrs,
+				field.Invalid(keysSecretPath, in.KeysSecret, "secret does not contain a value for "+key),
+			)
+		}
+	}
+
+	return allErrs
+}
+
+// validateClusterAndZones checks that the agents can be named, either through a cluster or zone name, and that all
+// configured zones are named uniquely
+func validateClusterAndZones(cluster Name, zone Name, zones []Zone, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	clusterNamePath := specPath.Child("cluster", "name")
+	if len(zones) == 0 {
+		if cluster.Name == "" && zone.Name == "" {
+			allErrs = append(
+				allErrs,
+				field.Required(clusterNamePath, "either cluster.name or zone.name must be specified"),
+			)
+		}
+		return allErrs
+	}
+
+	if cluster.Name == "" {
+		allErrs = append(
+			allErrs,
+			field.Required(clusterNamePath, "cluster.name must be specified when zones are configured"),
+		)
+	}
+
+	zoneNames := make(map[string]struct{}, len(zones))
+	for i, zone := range zones {
+		zoneNamePath := specPath.Child("zones").Index(i).Child("name")
+		if zone.Name.Name == "" {
+			allErrs = append(allErrs, field.Required(zoneNamePath, "zone name must be specified"))
+			continue
+		}
+		if _, ok := zoneNames[zone.Name.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(zoneNamePath, zone.Name.Name))
+			continue
+		}
+		zoneNames[zone.Name.Name] = struct{}{}
+	}
+
+	return allErrs
+}
+
+// validatePollRate checks that a k8sensor pollrate is given in seconds
+func validatePollRate(pollRate string, pollRatePath *field.Path) field.ErrorList {
+	if pollRate == "" || pollRateRegexp.MatchString(pollRate) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(pollRatePath, pollRate, "pollrate must be given in seconds, e.g. 10s")}
+}
+
+// validateEndpointPort checks that an endpoint port, which the CRD models as a string, is a valid port number
+func validateEndpointPort(endpointPort string, endpointPortPath *field.Path) field.ErrorList {
+	if endpointPort == "" {
+		return nil
+	}
+	port, err := strconv.Atoi(endpointPort)
+	if err != nil || len(validation.IsValidPortNum(port)) > 0 {
+		return field.ErrorList{
+			field.Invalid(endpointPortPath, endpointPort, "endpoint port must be a number between 1 and 65535"),
+		}
+	}
+	return nil
+}
+
+// operatorOwnedFields are the fields the operator relies on to identify and clean up its resources, they cannot be
+// ignored
+var operatorOwnedFields = [][]string{
+	{"apiVersion"},
+	{"kind"},
+	{"metadata", "name"},
+	{"metadata", "namespace"},
+	{"metadata", "labels"},
+	{"metadata", "ownerReferences"},
+	{"spec", "selector"},
+}
+
+// ValidateFieldOwnership checks that ignored fields belong to a component of the CR and do not overlap with a field
+// the operator relies on to identify its resources
+func ValidateFieldOwnership(
+	ownership FieldOwnershipSpec,
+	components []string,
+	ownershipPath *field.Path,
+) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, ignoredField := range ownership.IgnoredFields {
+		ignoredFieldPath := ownershipPath.Child("ignoredFields").Index(i)
+
+		if !slices.Contains(components, ignoredField.Component) {
+			allErrs = append(
+				allErrs,
+				field.NotSupported(ignoredFieldPath.Child("component"), ignoredField.Component, components),
+			)
+		}
+
+		segments, err := ignoredField.Segments()
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(ignoredFieldPath.Child("path"), ignoredField.Path, err.Error()))
+			continue
+		}
+		for _, owned := range operatorOwnedFields {
+			if hasPrefix(segments, owned) || hasPrefix(owned, segments) {
+				allErrs = append(
+					allErrs,
+					field.Forbidden(
+						ignoredFieldPath.Child("path"),
+						"overlaps with "+strings.Join(owned, ".")+" which identifies the resources of the operator",
+					),
+				)
+				break
+			}
+		}
+	}
+
+	for i, manager := range ownership.FieldManagers {
+		if manager == "" {
+			allErrs = append(allErrs, field.Required(ownershipPath.Child("fieldManagers").Index(i), ""))
+		}
+	}
+
+	return allErrs
+}
+
+func hasPrefix(segments []string, prefix []string) bool {
+	return len(segments) >= len(prefix) && slices.Equal(segments[:len(prefix)], prefix)
+}
+
+// validateAgentKeys checks that the agent key and the keys of all additional backends are resolvable
+func validateAgentKeys(agent *BaseAgentSpec, agentPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if agent.Key == "" && agent.KeysSecret == "" && agent.SecretProviderClass == "" {
+		allErrs = append(
+			allErrs,
+			field.Required(agentPath.Child("key"), "either agent.key or agent.keysSecret must be specified"),
+		)
+	}
+
+	for i, backend := range agent.AdditionalBackends {
+		backendPath := agentPath.Child("additionalBackends").Index(i)
+		if backend.EndpointHost == "" {
+			allErrs = append(allErrs, field.Required(backendPath.Child("endpointHost"), "endpoint host must be specified"))
+		}
+		if backend.Key == "" && agent.KeysSecret == "" && agent.SecretProviderClass == "" {
+			allErrs = append(
+				allErrs,
+				field.Required(backendPath.Child("key"), "key must be specified when agent.keysSecret is not used"),
+			)
+		}
+	}
+
+	return allErrs
+}
+
+// validateAgentEndpointPorts checks the endpoint ports of the primary and all additional backends
+func validateAgentEndpointPorts(agent *BaseAgentSpec, agentPath *field.Path) field.ErrorList {
+	allErrs := validateEndpointPort(agent.EndpointPort, agentPath.Child("endpointPort"))
+
+	for i, backend := range agent.AdditionalBackends {
+		allErrs = append(
+			allErrs,
+			validateEndpointPort(
+				backend.EndpointPort,
+				agentPath.Child("additionalBackends").Index(i).Child("endpointPort"),
+			)...,
+		)
+	}
+
+	return allErrs
+}
+
+// validateSecretRefs checks that credentials are either set inline or referenced in a Secret, and that referenced
+// credentials are mounted as files, since they are not rendered into environment variables
+func validateSecretRefs(agent *BaseAgentSpec, useSecretMounts *bool, agentPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, credential := range agent.credentialRefs() {
+		if credential.ref == nil {
+			continue
+		}
+		refPath := agentPath.Child(credential.refField)
+
+		if *credential.value != "" {
+			allErrs = append(allErrs, field.Forbidden(refPath, "cannot be combined with agent."+credential.field))
+		}
+		if credential.ref.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), "secret name must be specified"))
+		}
+		if credential.ref.Key == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("key"), "secret key must be specified"))
+		}
+		if useSecretMounts != nil && !*useSecretMounts {
+			allErrs = append(allErrs, field.Forbidden(refPath, "requires useSecretMounts to be enabled"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateSecretProviderClass checks that the keys and credentials of an agent using a SecretProviderClass are not
+// taken from anywhere else, since the keys secret rendering them is not created in that case. The entrypoint of the
+// agent reads the mounted files for the primary backend only, so additional backends are not supported.
+func validateSecretProviderClass(agent *BaseAgentSpec, useSecretMounts *bool, agentPath *field.Path) field.ErrorList {
+	if agent.SecretProviderClass == "" {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	secretProviderClassPath := agentPath.Child("secretProviderClass")
+	if useSecretMounts != nil && !*useSecretMounts {
+		allErrs = append(allErrs, field.Forbidden(secretProviderClassPath, "requires useSecretMounts to be enabled"))
+	}
+	if len(agent.AdditionalBackends) > 0 {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(agentPath.Child("additionalBackends"), "cannot be combined with agent.secretProviderClass"),
+		)
+	}
+
+	type providedField struct {
+		field string
+		set   bool
+	}
+	providedFields := []providedField{
+		{"key", agent.Key != ""},
+		{"downloadKey", agent.DownloadKey != ""},
+		{"keysSecret", agent.KeysSecret != ""},
+		{"proxyUser", agent.ProxyUser != ""},
+		{"agentReleaseRepoMirrorUsername", agent.MirrorReleaseRepoUsername != ""},
+		{"instanaSharedRepoMirrorUsername", agent.MirrorSharedRepoUsername != ""},
+	}
+	for _, credential := range agent.credentialRefs() {
+		providedFields = append(
+			providedFields,
+			providedField{credential.field, *credential.value != ""},
+			providedField{credential.refField, credential.ref != nil},
+		)
+	}
+	for _, provided := range providedFields {
+		if provided.set {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(agentPath.Child(provided.field), "cannot be combined with agent.secretProviderClass"),
+			)
+		}
+	}
+
+	return allErrs
+}
+
+// validateTLS checks that a certificate issued by cert-manager or generated by the operator is not mixed up with one
+// provided in the CR or with each other, since all of them would be written to the same Secret
+func validateTLS(tls *TlsSpec, tlsPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if tls.CertManager != nil {
+		if len(tls.Certificate) > 0 {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(tlsPath.Child("certificate"), "cannot be combined with tls.certManager"),
+			)
+		}
+		if len(tls.Key) > 0 {
+			allErrs = append(allErrs, field.Forbidden(tlsPath.Child("key"), "cannot be combined with tls.certManager"))
+		}
+		if tls.CertManager.IssuerRef.Name == "" {
+			allErrs = append(
+				allErrs,
+				field.Required(tlsPath.Child("certManager", "issuerRef", "name"), "issuer name must be specified"),
+			)
+		}
+	}
+
+	if tls.AutoGenerate {
+		if len(tls.Certificate) > 0 {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(tlsPath.Child("certificate"), "cannot be combined with tls.autoGenerate"),
+			)
+		}
+		if len(tls.Key) > 0 {
+			allErrs = append(allErrs, field.Forbidden(tlsPath.Child("key"), "cannot be combined with tls.autoGenerate"))
+		}
+		if tls.CertManager != nil {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(tlsPath.Child("certManager"), "cannot be combined with tls.autoGenerate"),
+			)
+		}
+	}
+
+	return allErrs
+}
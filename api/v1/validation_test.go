@@ -0,0 +1,574 @@
+/*
+(c) Copyright IBM Corp. 2025
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/instana/instana-agent-operator/pkg/pointer"
+)
+
+func TestValidateInstanaAgentSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     InstanaAgentSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "valid_with_key_and_cluster_name",
+			spec: InstanaAgentSpec{
+				Agent:   BaseAgentSpec{Key: "key"},
+				Cluster: Name{Name: "cluster"},
+			},
+		},
+		{
+			name: "valid_with_keys_secret_and_zone_name",
+			spec: InstanaAgentSpec{
+				Agent: BaseAgentSpec{KeysSecret: "keys"},
+				Zone:  Name{Name: "zone"},
+			},
+		},
+		{
+			name: "missing_key_and_keys_secret",
+			spec: InstanaAgentSpec{
+				Cluster: Name{Name: "cluster"},
+			},
+			expected: field.ErrorList{
+				field.Required(
+					field.NewPath("spec", "agent", "key"),
+					"either agent.key or agent.keysSecret must be specified",
+				),
+			},
+		},
+		{
+			name: "missing_cluster_and_zone_name",
+			spec: InstanaAgentSpec{
+				Agent: BaseAgentSpec{Key: "key"},
+			},
+			expected: field.ErrorList{
+				field.Required(
+					field.NewPath("spec", "cluster", "name"),
+					"either cluster.name or zone.name must be specified",
+				),
+			},
+		},
+		{
+			name: "zones_without_cluster_name",
+			spec: InstanaAgentSpec{
+				Agent: BaseAgentSpec{Key: "key"},
+				Zones: []Zone{{Name: Name{Name: "zone-a"}}},
+			},
+			expected: field.ErrorList{
+				field.Required(
+					field.NewPath("spec", "cluster", "name"),
+					"cluster.name must be specified when zones are configured",
+				),
+			},
+		},
+		{
+			name: "zones_with_missing_and_duplicate_names",
+			spec: InstanaAgentSpec{
+				Agent:   BaseAgentSpec{Key: "key"},
+				Cluster: Name{Name: "cluster"},
+				Zones: []Zone{
+					{Name: Name{Name: "zone-a"}},
+					{},
+					{Name: Name{Name: "zone-a"}},
+				},
+			},
+			expected: field.ErrorList{
+				field.Required(field.NewPath("spec", "zones").Index(1).Child("name"), "zone name must be specified"),
+				field.Duplicate(field.NewPath("spec", "zones").Index(2).Child("name"), "zone-a"),
+			},
+		},
+		{
+			name: "malformed_pollrate",
+			spec: InstanaAgentSpec{
+				Agent:     BaseAgentSpec{Key: "key"},
+				Cluster:   Name{Name: "cluster"},
+				K8sSensor: K8sSpec{PollRate: "10m"},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec", "k8s_sensor", "pollrate"),
+					"10m",
+					"pollrate must be given in seconds, e.g. 10s",
+				),
+			},
+		},
+		{
+			name: "valid_pollrate",
+			spec: InstanaAgentSpec{
+				Agent:     BaseAgentSpec{Key: "key"},
+				Cluster:   Name{Name: "cluster"},
+				K8sSensor: K8sSpec{PollRate: "30s"},
+			},
+		},
+		{
+			name: "additional_backend_missing_host_and_key",
+			spec: InstanaAgentSpec{
+				Agent: BaseAgentSpec{
+					Key:                "key",
+					AdditionalBackends: []BackendSpec{{EndpointPort: "443"}},
+				},
+				Cluster: Name{Name: "cluster"},
+			},
+			expected: field.ErrorList{
+				field.Required(
+					field.NewPath("spec", "agent", "additionalBackends").Index(0).Child("endpointHost"),
+					"endpoint host must be specified",
+				),
+				field.Required(
+					field.NewPath("spec", "agent", "additionalBackends").Index(0).Child("key"),
+					"key must be specified when agent.keysSecret is not used",
+				),
+			},
+		},
+		{
+			name: "additional_backend_key_from_keys_secret",
+			spec: InstanaAgentSpec{
+				Agent: BaseAgentSpec{
+					KeysSecret:         "keys",
+					AdditionalBackends: []BackendSpec{{EndpointHost: "host", EndpointPort: "443"}},
+				},
+				Cluster: Name{Name: "cluster"},
+			},
+		},
+		{
+			name: "non_numeric_endpoint_ports",
+			spec: InstanaAgentSpec{
+				Agent: BaseAgentSpec{
+					Key:          "key",
+					EndpointPort: "https",
+					AdditionalBackends: []BackendSpec{
+						{EndpointHost: "host", EndpointPort: "443", Key: "key"},
+						{EndpointHost: "host", EndpointPort: "70000", Key: "key"},
+					},
+				},
+				Cluster: Name{Name: "cluster"},
+			},
+			expected: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec", "agent", "endpointPort"),
+					"https",
+					"endpoint port must be a number between 1 and 65535",
+				),
+				field.Invalid(
+					field.NewPath("spec", "agent", "additionalBackends").Index(1).Child("endpointPort"),
+					"70000",
+					"endpoint port must be a number between 1 and 65535",
+				),
+			},
+		},
+		{
+			name: "ignored_fields_of_other_components_or_owned_by_the_operator",
+			spec: InstanaAgentSpec{
+				Agent:   BaseAgentSpec{Key: "key"},
+				Cluster: Name{Name: "cluster"},
+				Ownership: FieldOwnershipSpec{
+					IgnoredFields: []IgnoredField{
+						{Component: "k8sensor", Kind: "Deployment", Path: "spec.replicas"},
+						{Component: "instana-agent-remote", Path: "spec.replicas"},
+						{Component: "instana-agent", Path: "metadata.labels[app]"},
+						{Component: "instana-agent", Path: "spec"},
+						{Component: "instana-agent", Path: "spec..template"},
+					},
+					FieldManagers: []string{"vpa-recommender", ""},
+				},
+			},
+			expected: field.ErrorList{
+				field.NotSupported(
+					field.NewPath("spec", "ownership", "ignoredFields").Index(1).Child("component"),
+					"instana-agent-remote",
+					[]string{"instana-agent", "k8sensor"},
+				),
+				field.Forbidden(
+					field.NewPath("spec", "ownership", "ignoredFields").Index(2).Child("path"),
+					"overlaps with metadata.labels which identifies the resources of the operator",
+				),
+				field.Forbidden(
+					field.NewPath("spec", "ownership", "ignoredFields").Index(3).Child("path"),
+					"overlaps with spec.selector which identifies the resources of the operator",
+				),
+				field.Invalid(
+					field.NewPath("spec", "ownership", "ignoredFields").Index(4).Child("path"),
+					"spec..template",
+					`empty key in path "spec..template"`,
+				),
+				field.Required(field.NewPath("spec", "ownership", "fieldManagers").Index(1), ""),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.spec.Validate()
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestValidateInstanaAgentRemoteSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     InstanaAgentRemoteSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "valid",
+			spec: InstanaAgentRemoteSpec{
+				Agent: BaseAgentSpec{Key: "key"},
+				Zone:  Name{Name: "zone"},
+			},
+		},
+		{
+			name: "missing_key_and_zone",
+			spec: InstanaAgentRemoteSpec{},
+			expected: field.ErrorList{
+				field.Required(
+					field.NewPath("spec", "agent", "key"),
+					"either agent.key or agent.keysSecret must be specified",
+				),
+				field.Required(field.NewPath("spec", "zone", "name"), "zone.name must be specified"),
+			},
+		},
+		{
+			name: "ignored_field_of_agent_component",
+			spec: InstanaAgentRemoteSpec{
+				Agent: BaseAgentSpec{Key: "key"},
+				Zone:  Name{Name: "zone"},
+				Ownership: FieldOwnershipSpec{
+					IgnoredFields: []IgnoredField{{Component: "instana-agent", Path: "spec.replicas"}},
+				},
+			},
+			expected: field.ErrorList{
+				field.NotSupported(
+					field.NewPath("spec", "ownership", "ignoredFields").Index(0).Child("component"),
+					"instana-agent",
+					[]string{"instana-agent-remote"},
+				),
+			},
+		},
+		{
+			name: "cert_manager_tls",
+			spec: InstanaAgentRemoteSpec{
+				Agent: BaseAgentSpec{
+					Key:     "key",
+					TlsSpec: TlsSpec{CertManager: &CertManagerSpec{IssuerRef: CertManagerIssuerRef{Name: "issuer"}}},
+				},
+				Zone: Name{Name: "zone"},
+			},
+			expected: field.ErrorList{
+				field.Forbidden(
+					field.NewPath("spec", "agent", "tls", "certManager"),
+					"is not supported for InstanaAgentRemote",
+				),
+			},
+		},
+		{
+			name: "auto_generate_tls",
+			spec: InstanaAgentRemoteSpec{
+				Agent: BaseAgentSpec{Key: "key", TlsSpec: TlsSpec{AutoGenerate: true}},
+				Zone:  Name{Name: "zone"},
+			},
+			expected: field.ErrorList{
+				field.Forbidden(
+					field.NewPath("spec", "agent", "tls", "autoGenerate"),
+					"is not supported for InstanaAgentRemote",
+				),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.spec.Validate()
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestValidateKeysSecret(t *testing.T) {
+	keysSecretPath := field.NewPath("spec", "agent", "keysSecret")
+
+	tests := []struct {
+		name       string
+		agent      BaseAgentSpec
+		keysSecret *corev1.Secret
+		expected   field.ErrorList
+	}{
+		{
+			name:  "no_keys_secret_configured",
+			agent: BaseAgentSpec{Key: "key"},
+		},
+		{
+			name:       "keys_secret_not_found",
+			agent:      BaseAgentSpec{KeysSecret: "keys"},
+			keysSecret: &corev1.Secret{},
+			expected:   field.ErrorList{field.NotFound(keysSecretPath, "keys")},
+		},
+		{
+			name: "keys_secret_with_all_backend_keys",
+			agent: BaseAgentSpec{
+				KeysSecret:         "keys",
+				AdditionalBackends: []BackendSpec{{EndpointHost: "host-1"}, {EndpointHost: "host-2"}},
+			},
+			keysSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "keys"},
+				Data: map[string][]byte{
+					"key":   []byte("key"),
+					"key-1": []byte("key-1"),
+					"key-2": []byte("key-2"),
+				},
+			},
+		},
+		{
+			name: "keys_secret_missing_additional_backend_key",
+			agent: BaseAgentSpec{
+				KeysSecret:         "keys",
+				AdditionalBackends: []BackendSpec{{EndpointHost: "host-1", Key: "inline"}, {EndpointHost: "host-2"}},
+			},
+			keysSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "keys"},
+				Data: map[string][]byte{
+					"key":   []byte("key"),
+					"key-2": []byte("key-2"),
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(keysSecretPath, "keys", "secret does not contain a value for key-1"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.agent.ValidateKeysSecret(tt.keysSecret)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestIgnoredFieldSegments(t *testing.T) {
+	for path, expected := range map[string][]string{
+		"spec.replicas": {"spec", "replicas"},
+		"spec.template.metadata.annotations[reloader.stakater.com/last-reloaded-from]": {
+			"spec", "template", "metadata", "annotations", "reloader.stakater.com/last-reloaded-from",
+		},
+		"metadata.annotations[a.b][c].d": {"metadata", "annotations", "a.b", "c", "d"},
+		"[spec].replicas":                {"spec", "replicas"},
+	} {
+		ignoredField := IgnoredField{Path: path}
+		segments, err := ignoredField.Segments()
+		require.NoError(t, err, path)
+		require.Equal(t, expected, segments, path)
+	}
+
+	for _, path := range []string{"", "spec.", ".spec", "spec..replicas", "spec[replicas", "spec[]", "spec[a]b"} {
+		ignoredField := IgnoredField{Path: path}
+		_, err := ignoredField.Segments()
+		require.Error(t, err, path)
+	}
+}
+
+func TestValidateSecretRefs(t *testing.T) {
+	agentPath := field.NewPath("spec", "agent")
+	proxyPasswordRef := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "proxy"},
+		Key:                  "password",
+	}
+
+	tests := []struct {
+		name            string
+		agent           BaseAgentSpec
+		useSecretMounts *bool
+		expected        field.ErrorList
+	}{
+		{
+			name:  "inline_credential",
+			agent: BaseAgentSpec{ProxyPassword: "password"},
+		},
+		{
+			name:  "referenced_credential",
+			agent: BaseAgentSpec{ProxyPasswordSecretRef: proxyPasswordRef},
+		},
+		{
+			name:  "inline_and_referenced_credential",
+			agent: BaseAgentSpec{ProxyPassword: "password", ProxyPasswordSecretRef: proxyPasswordRef},
+			expected: field.ErrorList{
+				field.Forbidden(agentPath.Child("proxyPasswordSecretRef"), "cannot be combined with agent.proxyPassword"),
+			},
+		},
+		{
+			name:  "incomplete_reference",
+			agent: BaseAgentSpec{MirrorSharedRepoPasswordSecretRef: &corev1.SecretKeySelector{}},
+			expected: field.ErrorList{
+				field.Required(
+					agentPath.Child("instanaSharedRepoMirrorPasswordSecretRef", "name"),
+					"secret name must be specified",
+				),
+				field.Required(
+					agentPath.Child("instanaSharedRepoMirrorPasswordSecretRef", "key"),
+					"secret key must be specified",
+				),
+			},
+		},
+		{
+			name:            "reference_without_secret_mounts",
+			agent:           BaseAgentSpec{ProxyPasswordSecretRef: proxyPasswordRef},
+			useSecretMounts: pointer.To(false),
+			expected: field.ErrorList{
+				field.Forbidden(agentPath.Child("proxyPasswordSecretRef"), "requires useSecretMounts to be enabled"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, validateSecretRefs(&tt.agent, tt.useSecretMounts, agentPath))
+		})
+	}
+}
+
+func TestValidateSecretProviderClass(t *testing.T) {
+	agentPath := field.NewPath("spec", "agent")
+
+	tests := []struct {
+		name            string
+		agent           BaseAgentSpec
+		useSecretMounts *bool
+		expected        field.ErrorList
+	}{
+		{
+			name:  "no_secret_provider_class",
+			agent: BaseAgentSpec{Key: "key", ProxyUser: "user"},
+		},
+		{
+			name:  "secret_provider_class",
+			agent: BaseAgentSpec{SecretProviderClass: "instana-vault", ProxyHost: "proxy"},
+		},
+		{
+			name: "secret_provider_class_with_keys_and_credentials",
+			agent: BaseAgentSpec{
+				SecretProviderClass: "instana-vault",
+				Key:                 "key",
+				KeysSecret:          "keys",
+				ProxyPassword:       "password",
+				MirrorSharedRepoPasswordSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "mirror"},
+					Key:                  "password",
+				},
+			},
+			expected: field.ErrorList{
+				field.Forbidden(agentPath.Child("key"), "cannot be combined with agent.secretProviderClass"),
+				field.Forbidden(agentPath.Child("keysSecret"), "cannot be combined with agent.secretProviderClass"),
+				field.Forbidden(agentPath.Child("proxyPassword"), "cannot be combined with agent.secretProviderClass"),
+				field.Forbidden(
+					agentPath.Child("instanaSharedRepoMirrorPasswordSecretRef"),
+					"cannot be combined with agent.secretProviderClass",
+				),
+			},
+		},
+		{
+			name: "secret_provider_class_with_additional_backends_without_secret_mounts",
+			agent: BaseAgentSpec{
+				SecretProviderClass: "instana-vault",
+				AdditionalBackends:  []BackendSpec{{EndpointHost: "host-1"}},
+			},
+			useSecretMounts: pointer.To(false),
+			expected: field.ErrorList{
+				field.Forbidden(agentPath.Child("secretProviderClass"), "requires useSecretMounts to be enabled"),
+				field.Forbidden(
+					agentPath.Child("additionalBackends"),
+					"cannot be combined with agent.secretProviderClass",
+				),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, validateSecretProviderClass(&tt.agent, tt.useSecretMounts, agentPath))
+		})
+	}
+}
+
+func TestValidateTLS(t *testing.T) {
+	tlsPath := field.NewPath("spec", "agent", "tls")
+
+	tests := []struct {
+		name     string
+		tls      TlsSpec
+		expected field.ErrorList
+	}{
+		{
+			name: "certificate_and_key",
+			tls:  TlsSpec{Certificate: []byte("cert"), Key: []byte("key")},
+		},
+		{
+			name: "cert_manager_with_secret_name",
+			tls: TlsSpec{
+				SecretName:  "tls",
+				CertManager: &CertManagerSpec{IssuerRef: CertManagerIssuerRef{Name: "issuer"}},
+			},
+		},
+		{
+			name: "cert_manager_with_certificate_and_key_without_issuer",
+			tls: TlsSpec{
+				Certificate: []byte("cert"),
+				Key:         []byte("key"),
+				CertManager: &CertManagerSpec{},
+			},
+			expected: field.ErrorList{
+				field.Forbidden(tlsPath.Child("certificate"), "cannot be combined with tls.certManager"),
+				field.Forbidden(tlsPath.Child("key"), "cannot be combined with tls.certManager"),
+				field.Required(tlsPath.Child("certManager", "issuerRef", "name"), "issuer name must be specified"),
+			},
+		},
+		{
+			name: "auto_generate_with_secret_name",
+			tls:  TlsSpec{SecretName: "tls", AutoGenerate: true},
+		},
+		{
+			name: "auto_generate_with_certificate_key_and_cert_manager",
+			tls: TlsSpec{
+				Certificate:  []byte("cert"),
+				Key:          []byte("key"),
+				CertManager:  &CertManagerSpec{IssuerRef: CertManagerIssuerRef{Name: "issuer"}},
+				AutoGenerate: true,
+			},
+			expected: field.ErrorList{
+				field.Forbidden(tlsPath.Child("certificate"), "cannot be combined with tls.certManager"),
+				field.Forbidden(tlsPath.Child("key"), "cannot be combined with tls.certManager"),
+				field.Forbidden(tlsPath.Child("certificate"), "cannot be combined with tls.autoGenerate"),
+				field.Forbidden(tlsPath.Child("key"), "cannot be combined with tls.autoGenerate"),
+				field.Forbidden(tlsPath.Child("certManager"), "cannot be combined with tls.autoGenerate"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, validateTLS(&tt.tls, tlsPath))
+		})
+	}
+}
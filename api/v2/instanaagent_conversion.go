@@ -0,0 +1,471 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/pointer"
+)
+
+const (
+	// keysSecretAgentKey is the key of the primary backend in the keys secret, additional backends use "key-<n>"
+	keysSecretAgentKey = "key"
+	// keysSecretDownloadKey is the key of the download key in the keys secret
+	keysSecretDownloadKey = "downloadKey"
+	// conditionTypeReconcileSucceeded mirrors the condition maintained by the agent status manager
+	conditionTypeReconcileSucceeded = "ReconcileSucceeded"
+	// V1FieldsAnnotation keeps the v1 spec fields v2 has no equivalent for on v2 objects, so that they are restored
+	// when the object is converted back to v1
+	V1FieldsAnnotation = "instana.io/v1-fields"
+)
+
+// v1Fields are the deprecated v1 spec fields dropped from v2, as stored in the V1FieldsAnnotation
+type v1Fields struct {
+	PodSecurityPolicy  *instanav1.PodSecurityPolicySpec `json:"podSecurityPolicy,omitempty"`
+	Kubernetes         *instanav1.KubernetesSpec        `json:"kubernetes,omitempty"`
+	PinnedChartVersion string                           `json:"pinnedChartVersion,omitempty"`
+}
+
+func v1FieldsOf(spec *instanav1.InstanaAgentSpec) v1Fields {
+	var res v1Fields
+	if !reflect.ValueOf(spec.PodSecurityPolicySpec).IsZero() {
+		res.PodSecurityPolicy = &spec.PodSecurityPolicySpec
+	}
+	if !reflect.ValueOf(spec.KubernetesSpec).IsZero() {
+		res.Kubernetes = &spec.KubernetesSpec
+	}
+	res.PinnedChartVersion = spec.PinnedChartVersion
+	return res
+}
+
+func (in *v1Fields) applyTo(spec *instanav1.InstanaAgentSpec) {
+	if in.PodSecurityPolicy != nil {
+		spec.PodSecurityPolicySpec = *in.PodSecurityPolicy
+	}
+	if in.Kubernetes != nil {
+		spec.KubernetesSpec = *in.Kubernetes
+	}
+	spec.PinnedChartVersion = in.PinnedChartVersion
+}
+
+// setV1Fields stores the v1 fields in the annotations of a v2 object, or removes the annotation if there are none
+func setV1Fields(meta *metav1.ObjectMeta, fields v1Fields) error {
+	if reflect.ValueOf(fields).IsZero() {
+		removeAnnotation(meta, V1FieldsAnnotation)
+		return nil
+	}
+
+	value, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = make(map[string]string, 1)
+	}
+	meta.Annotations[V1FieldsAnnotation] = string(value)
+	return nil
+}
+
+// popV1Fields reads the v1 fields stored in the annotations of a v2 object and removes the annotation
+func popV1Fields(meta *metav1.ObjectMeta) (v1Fields, error) {
+	var res v1Fields
+
+	value, ok := meta.Annotations[V1FieldsAnnotation]
+	if !ok {
+		return res, nil
+	}
+	removeAnnotation(meta, V1FieldsAnnotation)
+
+	if err := json.Unmarshal([]byte(value), &res); err != nil {
+		return res, fmt.Errorf("invalid %s annotation: %w", V1FieldsAnnotation, err)
+	}
+	return res, nil
+}
+
+func removeAnnotation(meta *metav1.ObjectMeta, key string) {
+	delete(meta.Annotations, key)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+}
+
+var _ conversion.Convertible = &InstanaAgent{}
+
+// ConvertTo converts this InstanaAgent to the hub version (v1)
+func (src *InstanaAgent) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*instanav1.InstanaAgent)
+	in := src.DeepCopy()
+
+	keysSecret, errs := in.Spec.Agent.keysSecretName(field.NewPath("spec", "agent"))
+	if len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+
+	fields, err := popV1Fields(&in.ObjectMeta)
+	if err != nil {
+		return err
+	}
+
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = instanav1.InstanaAgentSpec{
+		UseSecretMounts:    in.Spec.UseSecretMounts,
+		Agent:              in.Spec.Agent.toV1(keysSecret),
+		Cluster:            in.Spec.Cluster,
+		Zone:               in.Spec.Zone,
+		OpenShift:          in.Spec.OpenShift,
+		Rbac:               in.Spec.Rbac,
+		Service:            in.Spec.Service,
+		OpenTelemetry:      in.Spec.OpenTelemetry.toV1(),
+		Prometheus:         in.Spec.Prometheus,
+		ServiceAccountSpec: in.Spec.ServiceAccount,
+		K8sSensor: instanav1.K8sSpec{
+			DeploymentSpec:      in.Spec.K8sSensor.Deployment,
+			ImageSpec:           in.Spec.K8sSensor.Image,
+			PodDisruptionBudget: in.Spec.K8sSensor.PodDisruptionBudget,
+			ETCD:                in.Spec.K8sSensor.ETCD,
+			RestClient:          in.Spec.K8sSensor.RestClient,
+			PollRate:            in.Spec.K8sSensor.PollRate,
+			FeatureFlags:        in.Spec.K8sSensor.FeatureFlags,
+		},
+		Zones:       in.Spec.Zones,
+		ServiceMesh: in.Spec.ServiceMesh,
+		Ownership:   in.Spec.Ownership,
+	}
+	fields.applyTo(&dst.Spec)
+	dst.Status = in.Status.toV1()
+
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1) to this version. Deprecated v1 fields are dropped from the spec and
+// kept in the V1FieldsAnnotation.
+func (dst *InstanaAgent) ConvertFrom(srcRaw conversion.Hub) error {
+	in := srcRaw.(*instanav1.InstanaAgent).DeepCopy()
+
+	dst.ObjectMeta = in.ObjectMeta
+	if err := setV1Fields(&dst.ObjectMeta, v1FieldsOf(&in.Spec)); err != nil {
+		return err
+	}
+	dst.Spec = InstanaAgentSpec{
+		UseSecretMounts: in.Spec.UseSecretMounts,
+		Agent:           agentFromV1(&in.Spec.Agent),
+		Cluster:         in.Spec.Cluster,
+		Zone:            in.Spec.Zone,
+		OpenShift:       in.Spec.OpenShift,
+		Rbac:            in.Spec.Rbac,
+		Service:         in.Spec.Service,
+		OpenTelemetry:   openTelemetryFromV1(&in.Spec.OpenTelemetry),
+		Prometheus:      in.Spec.Prometheus,
+		ServiceAccount:  in.Spec.ServiceAccountSpec,
+		K8sSensor: K8sSensorSpec{
+			Deployment:          in.Spec.K8sSensor.DeploymentSpec,
+			Image:               in.Spec.K8sSensor.ImageSpec,
+			PodDisruptionBudget: in.Spec.K8sSensor.PodDisruptionBudget,
+			ETCD:                in.Spec.K8sSensor.ETCD,
+			RestClient:          in.Spec.K8sSensor.RestClient,
+			PollRate:            in.Spec.K8sSensor.PollRate,
+			FeatureFlags:        in.Spec.K8sSensor.FeatureFlags,
+		},
+		Zones:       in.Spec.Zones,
+		ServiceMesh: in.Spec.ServiceMesh,
+		Ownership:   in.Spec.Ownership,
+	}
+	dst.Status = InstanaAgentStatus{
+		ConfigSecret:        in.Status.ConfigSecret,
+		NamespacesConfigMap: in.Status.NamespacesConfigMap,
+		Conditions:          in.Status.Conditions,
+		ObservedGeneration:  in.Status.ObservedGeneration,
+		OperatorVersion:     in.Status.OperatorVersion,
+		Inventory:           in.Status.Inventory,
+		Preview:             in.Status.Preview,
+		Zones:               in.Status.Zones,
+		NodeCoverage:        in.Status.NodeCoverage,
+		K8sSensors:          in.Status.K8sSensors,
+		ETCDMonitoring:      in.Status.ETCDMonitoring,
+		Agents:              in.Status.Agents,
+		K8sSensor:           in.Status.K8sSensor,
+		ReconcileState:      in.Status.ReconcileState,
+		Cluster:             in.Status.Cluster,
+		AgentImage:          in.Status.AgentImage,
+		ReconcileHistory:    in.Status.ReconcileHistory,
+		TLS:                 in.Status.TLS,
+	}
+
+	return nil
+}
+
+// backendKeyName returns the key in the keys secret holding the agent key of the backend at the given index
+func backendKeyName(i int) string {
+	if i == 0 {
+		return keysSecretAgentKey
+	}
+	return keysSecretAgentKey + "-" + strconv.Itoa(i)
+}
+
+// keysSecretName returns the name of the Secret all secret references point to. v1 only supports a single keys secret
+// using well-known keys, so any other layout cannot be represented and is reported as an error.
+func (in *AgentSpec) keysSecretName(agentPath *field.Path) (string, field.ErrorList) {
+	var (
+		name    string
+		allErrs field.ErrorList
+	)
+
+	check := func(ref *corev1.SecretKeySelector, expectedKey string, refPath *field.Path) {
+		if ref == nil {
+			return
+		}
+		switch {
+		case name == "":
+			name = ref.Name
+		case ref.Name != name:
+			allErrs = append(
+				allErrs,
+				field.Invalid(refPath.Child("name"), ref.Name, "all secret references must use the same Secret"),
+			)
+		}
+		if ref.Key != expectedKey {
+			allErrs = append(allErrs, field.NotSupported(refPath.Child("key"), ref.Key, []string{expectedKey}))
+		}
+	}
+
+	for i := range in.Backends {
+		check(in.Backends[i].KeySecretRef, backendKeyName(i), agentPath.Child("backends").Index(i).Child("keySecretRef"))
+	}
+	check(in.DownloadKeySecretRef, keysSecretDownloadKey, agentPath.Child("downloadKeySecretRef"))
+
+	return name, allErrs
+}
+
+func (in *AgentSpec) toV1(keysSecret string) instanav1.BaseAgentSpec {
+	res := instanav1.BaseAgentSpec{
+		Mode:                               in.Mode,
+		DownloadKey:                        in.DownloadKey,
+		KeysSecret:                         keysSecret,
+		SecretProviderClass:                in.SecretProviderClass,
+		ListenAddress:                      in.ListenAddress,
+		MinReadySeconds:                    in.MinReadySeconds,
+		TlsSpec:                            in.TLS,
+		ExtendedImageSpec:                  in.Image,
+		UpdateStrategy:                     in.UpdateStrategy,
+		Pod:                                in.Pod,
+		ProxyHost:                          in.Proxy.Host,
+		ProxyPort:                          in.Proxy.Port,
+		ProxyProtocol:                      in.Proxy.Protocol,
+		ProxyUser:                          in.Proxy.User,
+		ProxyPassword:                      in.Proxy.Password,
+		ProxyPasswordSecretRef:             in.Proxy.PasswordSecretRef,
+		ProxyUseDNS:                        in.Proxy.UseDNS,
+		Env:                                in.Env,
+		ConfigurationYaml:                  in.ConfigurationYaml,
+		RedactKubernetesSecrets:            in.RedactKubernetesSecrets,
+		Host:                               in.Host,
+		ServiceMesh:                        in.ServiceMesh,
+		MvnRepoUrl:                         in.Repositories.MvnRepoUrl,
+		MvnRepoFeaturesPath:                in.Repositories.MvnRepoFeaturesPath,
+		MvnRepoSharedPath:                  in.Repositories.MvnRepoSharedPath,
+		MirrorReleaseRepoUrl:               in.Repositories.ReleaseMirror.Url,
+		MirrorReleaseRepoUsername:          in.Repositories.ReleaseMirror.Username,
+		MirrorReleaseRepoPassword:          in.Repositories.ReleaseMirror.Password,
+		MirrorReleaseRepoPasswordSecretRef: in.Repositories.ReleaseMirror.PasswordSecretRef,
+		MirrorSharedRepoUrl:                in.Repositories.SharedMirror.Url,
+		MirrorSharedRepoUsername:           in.Repositories.SharedMirror.Username,
+		MirrorSharedRepoPassword:           in.Repositories.SharedMirror.Password,
+		MirrorSharedRepoPasswordSecretRef:  in.Repositories.SharedMirror.PasswordSecretRef,
+	}
+
+	for i, backend := range in.Backends {
+		if i == 0 {
+			res.EndpointHost = backend.EndpointHost
+			res.EndpointPort = backend.EndpointPort
+			res.Key = backend.Key
+			continue
+		}
+		res.AdditionalBackends = append(
+			res.AdditionalBackends,
+			instanav1.BackendSpec{
+				EndpointHost: backend.EndpointHost,
+				EndpointPort: backend.EndpointPort,
+				Key:          backend.Key,
+			},
+		)
+	}
+
+	return res
+}
+
+func agentFromV1(in *instanav1.BaseAgentSpec) AgentSpec {
+	keySecretRef := func(key string) *corev1.SecretKeySelector {
+		if in.KeysSecret == "" {
+			return nil
+		}
+		return &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: in.KeysSecret},
+			Key:                  key,
+		}
+	}
+
+	res := AgentSpec{
+		Mode:                    in.Mode,
+		DownloadKey:             in.DownloadKey,
+		SecretProviderClass:     in.SecretProviderClass,
+		ListenAddress:           in.ListenAddress,
+		MinReadySeconds:         in.MinReadySeconds,
+		TLS:                     in.TlsSpec,
+		Image:                   in.ExtendedImageSpec,
+		UpdateStrategy:          in.UpdateStrategy,
+		Pod:                     in.Pod,
+		Env:                     in.Env,
+		ConfigurationYaml:       in.ConfigurationYaml,
+		RedactKubernetesSecrets: in.RedactKubernetesSecrets,
+		Host:                    in.Host,
+		ServiceMesh:             in.ServiceMesh,
+		Proxy: ProxySpec{
+			Host:              in.ProxyHost,
+			Port:              in.ProxyPort,
+			Protocol:          in.ProxyProtocol,
+			User:              in.ProxyUser,
+			Password:          in.ProxyPassword,
+			PasswordSecretRef: in.ProxyPasswordSecretRef,
+			UseDNS:            in.ProxyUseDNS,
+		},
+		Repositories: RepositoriesSpec{
+			MvnRepoUrl:          in.MvnRepoUrl,
+			MvnRepoFeaturesPath: in.MvnRepoFeaturesPath,
+			MvnRepoSharedPath:   in.MvnRepoSharedPath,
+			ReleaseMirror: MirrorSpec{
+				Url:               in.MirrorReleaseRepoUrl,
+				Username:          in.MirrorReleaseRepoUsername,
+				Password:          in.MirrorReleaseRepoPassword,
+				PasswordSecretRef: in.MirrorReleaseRepoPasswordSecretRef,
+			},
+			SharedMirror: MirrorSpec{
+				Url:               in.MirrorSharedRepoUrl,
+				Username:          in.MirrorSharedRepoUsername,
+				Password:          in.MirrorSharedRepoPassword,
+				PasswordSecretRef: in.MirrorSharedRepoPasswordSecretRef,
+			},
+		},
+	}
+
+	if ref := keySecretRef(keysSecretDownloadKey); ref != nil {
+		ref.Optional = pointer.To(true)
+		res.DownloadKeySecretRef = ref
+	}
+
+	res.Backends = make([]BackendSpec, 0, len(in.AdditionalBackends)+1)
+	res.Backends = append(
+		res.Backends,
+		BackendSpec{
+			EndpointHost: in.EndpointHost,
+			EndpointPort: in.EndpointPort,
+			Key:          in.Key,
+			KeySecretRef: keySecretRef(backendKeyName(0)),
+		},
+	)
+	for i, backend := range in.AdditionalBackends {
+		res.Backends = append(
+			res.Backends,
+			BackendSpec{
+				EndpointHost: backend.EndpointHost,
+				EndpointPort: backend.EndpointPort,
+				Key:          backend.Key,
+				KeySecretRef: keySecretRef(backendKeyName(i + 1)),
+			},
+		)
+	}
+
+	return res
+}
+
+func (in *OpenTelemetry) toV1() instanav1.OpenTelemetry {
+	res := instanav1.OpenTelemetry{
+		GRPC: in.GRPC,
+		HTTP: in.HTTP,
+	}
+
+	// v1 uses a deprecated global toggle, derive it from the explicitly configured endpoints
+	if in.GRPC.Enabled != nil || in.HTTP.Enabled != nil {
+		res.Enabled.Enabled = pointer.To(
+			pointer.DerefOrDefault(in.GRPC.Enabled, true) || pointer.DerefOrDefault(in.HTTP.Enabled, true),
+		)
+	}
+
+	return res
+}
+
+func openTelemetryFromV1(in *instanav1.OpenTelemetry) OpenTelemetry {
+	res := OpenTelemetry{
+		GRPC: in.GRPC,
+		HTTP: in.HTTP,
+	}
+
+	// the deprecated global toggle disables both endpoints in v1
+	if !pointer.DerefOrDefault(in.Enabled.Enabled, true) {
+		res.GRPC.Enabled = pointer.To(false)
+		res.HTTP.Enabled = pointer.To(false)
+	}
+
+	return res
+}
+
+// toV1 converts the status and derives the deprecated v1 status fields from the ReconcileSucceeded condition
+func (in *InstanaAgentStatus) toV1() instanav1.InstanaAgentStatus {
+	res := instanav1.InstanaAgentStatus{
+		ConfigSecret:        in.ConfigSecret,
+		NamespacesConfigMap: in.NamespacesConfigMap,
+		Conditions:          in.Conditions,
+		ObservedGeneration:  in.ObservedGeneration,
+		OperatorVersion:     in.OperatorVersion,
+		Inventory:           in.Inventory,
+		Preview:             in.Preview,
+		Zones:               in.Zones,
+		NodeCoverage:        in.NodeCoverage,
+		K8sSensors:          in.K8sSensors,
+		ETCDMonitoring:      in.ETCDMonitoring,
+		Agents:              in.Agents,
+		K8sSensor:           in.K8sSensor,
+		ReconcileState:      in.ReconcileState,
+		Cluster:             in.Cluster,
+		AgentImage:          in.AgentImage,
+		ReconcileHistory:    in.ReconcileHistory,
+		TLS:                 in.TLS,
+	}
+
+	if condition := meta.FindStatusCondition(in.Conditions, conditionTypeReconcileSucceeded); condition != nil {
+		res.LastUpdate = condition.LastTransitionTime
+		switch condition.Status {
+		case metav1.ConditionTrue:
+			res.Status = instanav1.OperatorStateRunning
+		case metav1.ConditionFalse:
+			res.Status = instanav1.OperatorStateFailed
+			res.Reason = condition.Message
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,205 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/pointer"
+)
+
+func secretRef(name string, key string) *corev1.SecretKeySelector {
+	return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+}
+
+func TestConvertFromV1(t *testing.T) {
+	v1Agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", Namespace: "instana-agent"},
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{
+				KeysSecret:   "keys",
+				EndpointHost: "primary.instana.io",
+				EndpointPort: "443",
+				AdditionalBackends: []instanav1.BackendSpec{
+					{EndpointHost: "secondary.instana.io", EndpointPort: "8443"},
+				},
+				ProxyHost:              "proxy",
+				ProxyPasswordSecretRef: secretRef("proxy-credentials", "password"),
+				MirrorReleaseRepoUrl:   "https://mirror",
+				ConfigurationYaml:      "config",
+			},
+			Cluster:               instanav1.Name{Name: "cluster"},
+			PinnedChartVersion:    "1.2.3",
+			PodSecurityPolicySpec: instanav1.PodSecurityPolicySpec{Enabled: instanav1.Enabled{Enabled: pointer.To(true)}},
+			OpenTelemetry: instanav1.OpenTelemetry{
+				Enabled: instanav1.Enabled{Enabled: pointer.To(false)},
+			},
+			K8sSensor: instanav1.K8sSpec{
+				PollRate:     "30s",
+				FeatureFlags: instanav1.K8sFeatureFlagsSpec{CrdMonitoring: pointer.To(true)},
+			},
+		},
+		Status: instanav1.InstanaAgentStatus{
+			DeprecatedInstanaAgentStatus: instanav1.DeprecatedInstanaAgentStatus{
+				OldVersionsUpdated: true,
+			},
+			ObservedGeneration: pointer.To(int64(2)),
+		},
+	}
+
+	actual := &InstanaAgent{}
+	require.NoError(t, actual.ConvertFrom(v1Agent))
+
+	require.Equal(
+		t,
+		map[string]string{
+			V1FieldsAnnotation: `{"podSecurityPolicy":{"enabled":true},"pinnedChartVersion":"1.2.3"}`,
+		},
+		actual.Annotations,
+	)
+	require.Equal(t, v1Agent.Name, actual.Name)
+	require.Equal(
+		t,
+		[]BackendSpec{
+			{EndpointHost: "primary.instana.io", EndpointPort: "443", KeySecretRef: secretRef("keys", "key")},
+			{EndpointHost: "secondary.instana.io", EndpointPort: "8443", KeySecretRef: secretRef("keys", "key-1")},
+		},
+		actual.Spec.Agent.Backends,
+	)
+	require.Equal(t, "keys", actual.Spec.Agent.DownloadKeySecretRef.Name)
+	require.Equal(t, "downloadKey", actual.Spec.Agent.DownloadKeySecretRef.Key)
+	require.Equal(t, "proxy", actual.Spec.Agent.Proxy.Host)
+	require.Equal(t, secretRef("proxy-credentials", "password"), actual.Spec.Agent.Proxy.PasswordSecretRef)
+	require.Equal(t, "https://mirror", actual.Spec.Agent.Repositories.ReleaseMirror.Url)
+	require.Equal(t, "config", actual.Spec.Agent.ConfigurationYaml)
+	require.Equal(t, "30s", actual.Spec.K8sSensor.PollRate)
+	require.True(t, *actual.Spec.K8sSensor.FeatureFlags.CrdMonitoring)
+	require.False(t, *actual.Spec.OpenTelemetry.GRPC.Enabled)
+	require.False(t, *actual.Spec.OpenTelemetry.HTTP.Enabled)
+	require.Equal(t, pointer.To(int64(2)), actual.Status.ObservedGeneration)
+}
+
+func TestConvertToV1(t *testing.T) {
+	v2Agent := &InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", Namespace: "instana-agent"},
+		Spec: InstanaAgentSpec{
+			Agent: AgentSpec{
+				Backends: []BackendSpec{
+					{EndpointHost: "primary.instana.io", EndpointPort: "443", Key: "primary-key"},
+					{EndpointHost: "secondary.instana.io", EndpointPort: "8443", Key: "secondary-key"},
+				},
+			},
+			Zone: instanav1.Name{Name: "zone"},
+			OpenTelemetry: OpenTelemetry{
+				GRPC: instanav1.OpenTelemetryPortConfig{Enabled: pointer.To(false)},
+			},
+		},
+		Status: InstanaAgentStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    conditionTypeReconcileSucceeded,
+					Status:  metav1.ConditionFalse,
+					Message: "failed to apply",
+				},
+			},
+		},
+	}
+
+	actual := &instanav1.InstanaAgent{}
+	require.NoError(t, v2Agent.ConvertTo(actual))
+
+	require.Equal(t, "primary.instana.io", actual.Spec.Agent.EndpointHost)
+	require.Equal(t, "primary-key", actual.Spec.Agent.Key)
+	require.Empty(t, actual.Spec.Agent.KeysSecret)
+	require.Equal(
+		t,
+		[]instanav1.BackendSpec{{EndpointHost: "secondary.instana.io", EndpointPort: "8443", Key: "secondary-key"}},
+		actual.Spec.Agent.AdditionalBackends,
+	)
+	require.True(t, *actual.Spec.OpenTelemetry.Enabled.Enabled)
+	require.False(t, *actual.Spec.OpenTelemetry.GRPC.Enabled)
+	require.Equal(t, instanav1.OperatorStateFailed, actual.Status.Status)
+	require.Equal(t, "failed to apply", actual.Status.Reason)
+}
+
+func TestConvertToV1InvalidSecretRefs(t *testing.T) {
+	v2Agent := &InstanaAgent{
+		Spec: InstanaAgentSpec{
+			Agent: AgentSpec{
+				Backends: []BackendSpec{
+					{EndpointHost: "primary.instana.io", KeySecretRef: secretRef("keys", "key")},
+					{EndpointHost: "secondary.instana.io", KeySecretRef: secretRef("other", "key")},
+				},
+			},
+		},
+	}
+
+	require.Error(t, v2Agent.ConvertTo(&instanav1.InstanaAgent{}))
+}
+
+func TestConvertToV1InvalidV1FieldsAnnotation(t *testing.T) {
+	v2Agent := &InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{V1FieldsAnnotation: "{"}},
+		Spec: InstanaAgentSpec{
+			Agent: AgentSpec{Backends: []BackendSpec{{EndpointHost: "primary.instana.io", Key: "key"}}},
+		},
+	}
+
+	require.Error(t, v2Agent.ConvertTo(&instanav1.InstanaAgent{}))
+}
+
+func TestRoundTripV1(t *testing.T) {
+	v1Agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", Annotations: map[string]string{"team": "observability"}},
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{
+				KeysSecret:   "keys",
+				EndpointHost: "primary.instana.io",
+				EndpointPort: "443",
+				AdditionalBackends: []instanav1.BackendSpec{
+					{EndpointHost: "secondary.instana.io", EndpointPort: "443"},
+				},
+			},
+			Cluster:               instanav1.Name{Name: "cluster"},
+			PinnedChartVersion:    "1.2.3",
+			PodSecurityPolicySpec: instanav1.PodSecurityPolicySpec{Enabled: instanav1.Enabled{Enabled: pointer.To(true)}},
+			KubernetesSpec: instanav1.KubernetesSpec{
+				DeploymentSpec: instanav1.KubernetesDeploymentSpec{Replicas: 2},
+			},
+			K8sSensor: instanav1.K8sSpec{
+				FeatureFlags: instanav1.K8sFeatureFlagsSpec{CrdMonitoring: pointer.To(true)},
+			},
+			Ownership: instanav1.FieldOwnershipSpec{
+				IgnoredFields: []instanav1.IgnoredField{{Component: "k8sensor", Path: "spec.replicas"}},
+				FieldManagers: []string{"kube-controller-manager"},
+			},
+		},
+	}
+	v1Agent.Default()
+
+	v2Agent := &InstanaAgent{}
+	require.NoError(t, v2Agent.ConvertFrom(v1Agent))
+
+	actual := &instanav1.InstanaAgent{}
+	require.NoError(t, v2Agent.ConvertTo(actual))
+	require.Equal(t, v1Agent, actual)
+}
@@ -0,0 +1,368 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+)
+
+// InstanaAgentSpec defines the desired state of the Instana Agent
+type InstanaAgentSpec struct {
+	// UseSecretMounts specifies whether to mount secrets as files instead of environment variables.
+	// This is more secure as it prevents secrets from being exposed in the environment.
+	// Default is true.
+	// +kubebuilder:validation:Optional
+	UseSecretMounts *bool `json:"useSecretMounts,omitempty"`
+
+	// Agent deployment specific fields.
+	// +kubebuilder:validation:Required
+	Agent AgentSpec `json:"agent"`
+
+	// Name of the cluster, that will be assigned to this cluster in Instana. Either specifying the 'cluster.name' or 'zone.name'
+	// is mandatory.
+	// +kubebuilder:validation:Optional
+	Cluster instanav1.Name `json:"cluster,omitempty"`
+
+	// Name of the zone in which the host(s) will be displayed on the map. Optional, but then 'cluster.name' must be specified.
+	// +kubebuilder:validation:Optional
+	Zone instanav1.Name `json:"zone,omitempty"`
+
+	// Set to `True` to indicate the Operator is being deployed in a OpenShift cluster. Provides a hint so that RBAC etc is
+	// configured correctly. Will attempt to auto-detect if unset.
+	// +kubebuilder:validation:Optional
+	OpenShift *bool `json:"openshift,omitempty"`
+
+	// Specifies whether RBAC resources should be created.
+	// +kubebuilder:validation:Optional
+	Rbac instanav1.Create `json:"rbac,omitempty"`
+
+	// Specifies whether to create the instana-agent `Service` to expose within the cluster.
+	// +kubebuilder:validation:Optional
+	Service instanav1.Create `json:"service,omitempty"`
+
+	// Configures the OpenTelemetry endpoints of the Agent.
+	// +kubebuilder:validation:Optional
+	OpenTelemetry OpenTelemetry `json:"opentelemetry,omitempty"`
+
+	// Enables the Prometheus endpoint on the Agent.
+	// +kubebuilder:validation:Optional
+	Prometheus instanav1.Prometheus `json:"prometheus,omitempty"`
+
+	// Specifies whether a ServiceAccount should be created (default `true`), and possibly the name to use.
+	// +kubebuilder:validation:Optional
+	ServiceAccount instanav1.ServiceAccountSpec `json:"serviceAccount,omitempty"`
+
+	// Configures the Kubernetes Sensor deployment.
+	// +kubebuilder:validation:Optional
+	K8sSensor K8sSensorSpec `json:"k8sSensor,omitempty"`
+
+	// Zones can be used to specify agents in multiple zones split across different nodes in the cluster
+	// +kubebuilder:validation:Optional
+	Zones []instanav1.Zone `json:"zones,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	ServiceMesh instanav1.ServiceMeshSpec `json:"serviceMesh,omitempty"`
+
+	// Lets other controllers own selected fields of the resources managed by the operator.
+	// +kubebuilder:validation:Optional
+	Ownership instanav1.FieldOwnershipSpec `json:"ownership,omitempty"`
+}
+
+// AgentSpec defines the desired state info related to the running Agent
+type AgentSpec struct {
+	// Set agent mode, possible options are APM, INFRASTRUCTURE or AWS.
+	// +kubebuilder:validation:Optional
+	Mode instanav1.AgentMode `json:"mode,omitempty"`
+
+	// Backends the agent reports to. The first entry is the primary backend.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Backends []BackendSpec `json:"backends"`
+
+	// The DownloadKey, sometimes known as "sales key", that allows you to download software from Instana.
+	// +kubebuilder:validation:Optional
+	DownloadKey string `json:"downloadKey,omitempty"`
+
+	// Reference to the DownloadKey in a Secret. All secret references of an InstanaAgent must use the same Secret.
+	// +kubebuilder:validation:Optional
+	DownloadKeySecretRef *corev1.SecretKeySelector `json:"downloadKeySecretRef,omitempty"`
+
+	// Name of a SecretProviderClass of the Secrets Store CSI driver providing the keys and proxy credentials as files,
+	// as an alternative to secret references. Requires useSecretMounts and a single backend.
+	// +kubebuilder:validation:Optional
+	SecretProviderClass string `json:"secretProviderClass,omitempty"`
+
+	// ListenAddress is the IP addresses the Agent HTTP server will listen on.
+	// +kubebuilder:validation:Optional
+	ListenAddress string `json:"listenAddress,omitempty"`
+
+	// The minimum number of seconds for which a newly created Pod should be ready without any of its containers crashing, for it to be considered available
+	// +kubebuilder:validation:Optional
+	MinReadySeconds int `json:"minReadySeconds,omitempty"`
+
+	// TLS for end-to-end encryption between the Instana Agent and clients accessing the Agent.
+	// +kubebuilder:validation:Optional
+	TLS instanav1.TlsSpec `json:"tls,omitempty"`
+
+	// Override the container image used for the Instana Agent pods.
+	// +kubebuilder:validation:Optional
+	Image instanav1.ExtendedImageSpec `json:"image,omitempty"`
+
+	// Control how to update the Agent DaemonSet
+	// +kubebuilder:validation:Optional
+	UpdateStrategy appsv1.DaemonSetUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// Override Agent Pod specific settings such as annotations, labels and resources.
+	// +kubebuilder:validation:Optional
+	Pod instanav1.AgentPodSpec `json:"pod,omitempty"`
+
+	// Proxy the Agent uses to connect to the backends.
+	// +kubebuilder:validation:Optional
+	Proxy ProxySpec `json:"proxy,omitempty"`
+
+	// Additional environment variables for the Instana Agent.
+	// +kubebuilder:validation:Optional
+	Env map[string]string `json:"env,omitempty"`
+
+	// Supply Agent configuration e.g. for configuring certain Sensors.
+	// +kubebuilder:validation:Optional
+	ConfigurationYaml string `json:"configurationYaml,omitempty"`
+
+	// RedactKubernetesSecrets sets the INSTANA_KUBERNETES_REDACT_SECRETS environment variable.
+	// +kubebuilder:validation:Optional
+	RedactKubernetesSecrets string `json:"redactKubernetesSecrets,omitempty"`
+
+	// Host sets a host path to be mounted as the Agent Maven repository (mainly for debugging or development purposes)
+	// +kubebuilder:validation:Optional
+	Host instanav1.HostSpec `json:"host,omitempty"`
+
+	// ServiceMesh sets the ENABLE_AGENT_SOCKET environment variable.
+	// +kubebuilder:validation:Optional
+	ServiceMesh instanav1.ServiceMeshSpec `json:"serviceMesh,omitempty"`
+
+	// Maven and release repositories the Agent downloads from.
+	// +kubebuilder:validation:Optional
+	Repositories RepositoriesSpec `json:"repositories,omitempty"`
+}
+
+// BackendSpec is a backend the Agent reports to
+type BackendSpec struct {
+	// EndpointHost is the hostname of the Instana server.
+	// +kubebuilder:validation:Required
+	EndpointHost string `json:"endpointHost"`
+
+	// EndpointPort is the port number (as a String) of the Instana server.
+	// +kubebuilder:validation:Optional
+	EndpointPort string `json:"endpointPort,omitempty"`
+
+	// Key is the secret token which your agent uses to authenticate to this backend.
+	// +kubebuilder:validation:Optional
+	Key string `json:"key,omitempty"`
+
+	// Reference to the Key in a Secret. All secret references of an InstanaAgent must use the same Secret, the primary
+	// backend uses the key `key` and additional backends use `key-1`, `key-2`, etc.
+	// +kubebuilder:validation:Optional
+	KeySecretRef *corev1.SecretKeySelector `json:"keySecretRef,omitempty"`
+}
+
+// ProxySpec configures the proxy used by the Agent
+type ProxySpec struct {
+	// +kubebuilder:validation:Optional
+	Host string `json:"host,omitempty"`
+	// +kubebuilder:validation:Optional
+	Port string `json:"port,omitempty"`
+	// +kubebuilder:validation:Optional
+	Protocol string `json:"protocol,omitempty"`
+	// +kubebuilder:validation:Optional
+	User string `json:"user,omitempty"`
+	// +kubebuilder:validation:Optional
+	Password string `json:"password,omitempty"`
+	// Reference to the Password in a Secret in the namespace of the InstanaAgent. Requires useSecretMounts.
+	// +kubebuilder:validation:Optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// +kubebuilder:validation:Optional
+	UseDNS bool `json:"useDNS,omitempty"`
+}
+
+// RepositoriesSpec configures the Maven and release repositories used by the Agent
+type RepositoriesSpec struct {
+	// Override for the Maven repository URL.
+	// +kubebuilder:validation:Optional
+	MvnRepoUrl string `json:"mvnRepoUrl,omitempty"`
+	// +kubebuilder:validation:Optional
+	MvnRepoFeaturesPath string `json:"mvnRepoFeaturesPath,omitempty"`
+	// +kubebuilder:validation:Optional
+	MvnRepoSharedPath string `json:"mvnRepoSharedPath,omitempty"`
+	// +kubebuilder:validation:Optional
+	ReleaseMirror MirrorSpec `json:"releaseMirror,omitempty"`
+	// +kubebuilder:validation:Optional
+	SharedMirror MirrorSpec `json:"sharedMirror,omitempty"`
+}
+
+// MirrorSpec configures a repository mirror
+type MirrorSpec struct {
+	// +kubebuilder:validation:Optional
+	Url string `json:"url,omitempty"`
+	// +kubebuilder:validation:Optional
+	Username string `json:"username,omitempty"`
+	// +kubebuilder:validation:Optional
+	Password string `json:"password,omitempty"`
+	// Reference to the Password in a Secret in the namespace of the InstanaAgent. Requires useSecretMounts.
+	// +kubebuilder:validation:Optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+}
+
+// K8sSensorSpec configures the Kubernetes Sensor deployment
+type K8sSensorSpec struct {
+	// +kubebuilder:validation:Optional
+	Deployment instanav1.KubernetesDeploymentSpec `json:"deployment,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Image instanav1.ImageSpec `json:"image,omitempty"`
+
+	// Toggles the PDB for the K8s Sensor
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget instanav1.Enabled `json:"podDisruptionBudget,omitempty"`
+
+	// ETCD configuration for secure scraping
+	// +kubebuilder:validation:Optional
+	ETCD instanav1.ETCDSpec `json:"etcd,omitempty"`
+
+	// REST client configuration
+	// +kubebuilder:validation:Optional
+	RestClient instanav1.RestClientSpec `json:"restClient,omitempty"`
+
+	// PollRate controls the frequency at which k8sensor sends collected information to the backend.
+	// Example: 1s, 5s, 10s, 30s
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+s$`
+	PollRate string `json:"pollrate,omitempty"`
+
+	// Feature flags of the Kubernetes Sensor
+	// +kubebuilder:validation:Optional
+	FeatureFlags instanav1.K8sFeatureFlagsSpec `json:"featureFlags,omitempty"`
+}
+
+// OpenTelemetry configures the OpenTelemetry endpoints of the Agent
+type OpenTelemetry struct {
+	// Specify whether GRPC is enabled (default is true).
+	// +kubebuilder:validation:Optional
+	GRPC instanav1.OpenTelemetryPortConfig `json:"grpc,omitempty"`
+
+	// Specify whether HTTP is enabled (default is true).
+	// +kubebuilder:validation:Optional
+	HTTP instanav1.OpenTelemetryPortConfig `json:"http,omitempty"`
+}
+
+// InstanaAgentStatus defines the observed state of InstanaAgent
+type InstanaAgentStatus struct {
+	// +kubebuilder:validation:Optional
+	ConfigSecret instanav1.ResourceInfo `json:"configSecret,omitempty"`
+	// +kubebuilder:validation:Optional
+	NamespacesConfigMap instanav1.ResourceInfo `json:"namespacesConfigMap,omitempty"`
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	ObservedGeneration *int64                     `json:"observedGeneration,omitempty"`
+	OperatorVersion    *instanav1.SemanticVersion `json:"operatorVersion,omitempty"`
+	// Inventory lists the objects applied by the operator for this CR, objects of earlier generations that are no
+	// longer part of it are removed by the operator
+	// +kubebuilder:validation:Optional
+	Inventory []instanav1.ResourceInventoryEntry `json:"inventory,omitempty"`
+	// Preview holds the changes the current spec would apply, while the CR is annotated with instana.io/preview
+	// +kubebuilder:validation:Optional
+	Preview *instanav1.PreviewStatus `json:"preview,omitempty"`
+	// Zones reports the agent DaemonSet of every zone configured in spec.zones
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	Zones []instanav1.ZoneStatus `json:"zones,omitempty"`
+	// NodeCoverage reports the nodes that do not run a ready agent and why
+	// +kubebuilder:validation:Optional
+	NodeCoverage *instanav1.NodeCoverageStatus `json:"nodeCoverage,omitempty"`
+	// K8sSensors reports the k8sensor Deployment of every backend
+	// +kubebuilder:validation:Optional
+	K8sSensors []instanav1.K8sSensorStatus `json:"k8sSensors,omitempty"`
+	// ETCDMonitoring reports how the k8sensor monitors ETCD
+	// +kubebuilder:validation:Optional
+	ETCDMonitoring *instanav1.ETCDMonitoringStatus `json:"etcdMonitoring,omitempty"`
+	// Agents summarizes the agent DaemonSets of all zones as ready/desired agents
+	// +kubebuilder:validation:Optional
+	Agents string `json:"agents,omitempty"`
+	// K8sSensor summarizes the k8sensor Deployments of all backends as ready/desired replicas
+	// +kubebuilder:validation:Optional
+	K8sSensor string `json:"k8sSensor,omitempty"`
+	// ReconcileState is the outcome of the most recent reconcile
+	// +kubebuilder:validation:Optional
+	ReconcileState instanav1.ReconcileState `json:"reconcileState,omitempty"`
+	// Cluster is the name of the cluster the agents report, or of their zone if no cluster name is set
+	// +kubebuilder:validation:Optional
+	Cluster string `json:"cluster,omitempty"`
+	// AgentImage is the image run by the agent DaemonSets
+	// +kubebuilder:validation:Optional
+	AgentImage string `json:"agentImage,omitempty"`
+	// ReconcileHistory lists the last reconciles of the CR, oldest first. Consecutive successful reconciles of the same
+	// generation are recorded once.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	ReconcileHistory []instanav1.ReconcileAttempt `json:"reconcileHistory,omitempty"`
+	// TLS reports the certificates generated by the operator while spec.agent.tls.autoGenerate is set
+	// +kubebuilder:validation:Optional
+	TLS *instanav1.TLSStatus `json:"tls,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+//nolint:lll
+// +kubebuilder:resource:path=agents,singular=agent,shortName=ia;instanaagent;instanaagents,scope=Namespaced,categories=monitoring;openshift-optional
+// +kubebuilder:printcolumn:name="Agents",type=string,JSONPath=`.status.agents`,description="Ready/desired agents"
+// +kubebuilder:printcolumn:name="K8sensor",type=string,JSONPath=`.status.k8sSensor`,description="Ready/desired k8sensor replicas"
+// +kubebuilder:printcolumn:name="Reconcile",type=string,JSONPath=`.status.reconcileState`
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.status.cluster`
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.status.agentImage`,priority=1
+// +kubebuilder:printcolumn:name="Operator",type=string,JSONPath=`.status.operatorVersion`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:unservedversion
+
+// InstanaAgent is the Schema for the agents API
+type InstanaAgent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InstanaAgentSpec   `json:"spec,omitempty"`
+	Status InstanaAgentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InstanaAgentList contains a list of InstanaAgent
+type InstanaAgentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InstanaAgent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&InstanaAgent{}, &InstanaAgentList{})
+}
@@ -0,0 +1,148 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/pointer"
+)
+
+// invalidFields returns the fields an admission error reports as invalid
+func invalidFields(t *testing.T, err error) []string {
+	statusErr := &apierrors.StatusError{}
+	require.ErrorAs(t, err, &statusErr)
+	require.True(t, apierrors.IsInvalid(err))
+
+	fields := make([]string, 0, len(statusErr.ErrStatus.Details.Causes))
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		fields = append(fields, cause.Field)
+	}
+	return fields
+}
+
+func TestInstanaAgentValidator(t *testing.T) {
+	validator := &instanaAgentValidator{}
+	ctx := context.Background()
+
+	valid := &InstanaAgent{
+		Spec: InstanaAgentSpec{
+			Agent: AgentSpec{
+				Backends: []BackendSpec{
+					{EndpointHost: "primary", KeySecretRef: secretRef("keys", "key")},
+					{EndpointHost: "secondary", KeySecretRef: secretRef("keys", "key-1")},
+				},
+				DownloadKeySecretRef: secretRef("keys", "downloadKey"),
+			},
+			Cluster: instanav1.Name{Name: "cluster"},
+		},
+	}
+	warnings, err := validator.ValidateCreate(ctx, valid)
+	require.Empty(t, warnings)
+	require.NoError(t, err)
+
+	// the keys are provided by the SecretProviderClass
+	withSecretProviderClass := &InstanaAgent{
+		Spec: InstanaAgentSpec{
+			Agent: AgentSpec{
+				Backends:            []BackendSpec{{EndpointHost: "primary"}},
+				SecretProviderClass: "instana-vault",
+			},
+			Cluster: instanav1.Name{Name: "cluster"},
+		},
+	}
+	_, err = validator.ValidateCreate(ctx, withSecretProviderClass)
+	require.NoError(t, err)
+
+	// v2 agents are validated by the rules of v1
+	withoutSecretMounts := withSecretProviderClass.DeepCopy()
+	withoutSecretMounts.Spec.UseSecretMounts = pointer.To(false)
+	withoutSecretMounts.Spec.Agent.Proxy.PasswordSecretRef = secretRef("proxy", "password")
+	withoutSecretMounts.Spec.K8sSensor.PollRate = "1m"
+	_, err = validator.ValidateCreate(ctx, withoutSecretMounts)
+	require.Equal(
+		t,
+		[]string{
+			"spec.agent.proxyPasswordSecretRef",
+			"spec.agent.secretProviderClass",
+			"spec.agent.proxyPasswordSecretRef",
+			"spec.k8s_sensor.pollrate",
+		},
+		invalidFields(t, err),
+	)
+
+	// secret references that cannot be converted are reported on their v2 fields
+	unconvertible := valid.DeepCopy()
+	unconvertible.Spec.Agent.Backends[1].KeySecretRef = secretRef("other", "key-1")
+	_, err = validator.ValidateCreate(ctx, unconvertible)
+	require.Equal(t, []string{"spec.agent.backends[1].keySecretRef.name"}, invalidFields(t, err))
+
+	_, err = validator.ValidateCreate(ctx, &InstanaAgent{})
+	require.True(t, apierrors.IsInvalid(err))
+
+	_, err = validator.ValidateUpdate(ctx, valid, &InstanaAgent{})
+	require.True(t, apierrors.IsInvalid(err))
+
+	// updates of agents that were already invalid are admitted with warnings
+	warnings, err = validator.ValidateUpdate(ctx, unconvertible, withoutSecretMounts)
+	require.Len(t, warnings, 4)
+	require.NoError(t, err)
+
+	_, err = validator.ValidateUpdate(ctx, &InstanaAgent{}, &InstanaAgent{})
+	require.NoError(t, err)
+
+	_, err = validator.ValidateDelete(ctx, &InstanaAgent{})
+	require.NoError(t, err)
+}
+
+func TestInstanaAgentDefaulter(t *testing.T) {
+	agent := &InstanaAgent{
+		Spec: InstanaAgentSpec{
+			Agent: AgentSpec{
+				Backends: []BackendSpec{{EndpointHost: "custom.instana.io", Key: "key"}},
+			},
+		},
+	}
+
+	require.NoError(t, (&instanaAgentDefaulter{}).Default(context.Background(), agent))
+	require.Equal(t, "custom.instana.io", agent.Spec.Agent.Backends[0].EndpointHost)
+	require.Equal(t, "443", agent.Spec.Agent.Backends[0].EndpointPort)
+	require.Equal(t, "icr.io/instana/agent", agent.Spec.Agent.Image.Name)
+	require.Equal(t, "10s", agent.Spec.K8sSensor.PollRate)
+	require.True(t, *agent.Spec.OpenTelemetry.GRPC.Enabled)
+	require.True(t, *agent.Spec.UseSecretMounts)
+}
+
+func TestInstanaAgentDefaulterRejectsUnconvertibleAgent(t *testing.T) {
+	agent := &InstanaAgent{
+		Spec: InstanaAgentSpec{
+			Agent: AgentSpec{
+				Backends: []BackendSpec{
+					{EndpointHost: "primary.instana.io", KeySecretRef: secretRef("keys", "key")},
+					{EndpointHost: "secondary.instana.io", KeySecretRef: secretRef("other", "key-1")},
+				},
+			},
+		},
+	}
+
+	require.Error(t, (&instanaAgentDefaulter{}).Default(context.Background(), agent))
+}
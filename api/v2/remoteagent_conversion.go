@@ -0,0 +1,70 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+)
+
+var _ conversion.Convertible = &InstanaAgentRemote{}
+
+// ConvertTo converts this InstanaAgentRemote to the hub version (v1)
+func (src *InstanaAgentRemote) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*instanav1.InstanaAgentRemote)
+	in := src.DeepCopy()
+
+	keysSecret, errs := in.Spec.Agent.keysSecretName(field.NewPath("spec", "agent"))
+	if len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = instanav1.InstanaAgentRemoteSpec{
+		UseSecretMounts:    in.Spec.UseSecretMounts,
+		Agent:              in.Spec.Agent.toV1(keysSecret),
+		Zone:               in.Spec.Zone,
+		Rbac:               in.Spec.Rbac,
+		ServiceAccountSpec: in.Spec.ServiceAccount,
+		Hostname:           in.Spec.Hostname,
+		Ownership:          in.Spec.Ownership,
+	}
+	dst.Status = in.Status
+
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1) to this version
+func (dst *InstanaAgentRemote) ConvertFrom(srcRaw conversion.Hub) error {
+	in := srcRaw.(*instanav1.InstanaAgentRemote).DeepCopy()
+
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = InstanaAgentRemoteSpec{
+		UseSecretMounts: in.Spec.UseSecretMounts,
+		Agent:           agentFromV1(&in.Spec.Agent),
+		Zone:            in.Spec.Zone,
+		Rbac:            in.Spec.Rbac,
+		ServiceAccount:  in.Spec.ServiceAccountSpec,
+		Hostname:        in.Spec.Hostname,
+		Ownership:       in.Spec.Ownership,
+	}
+	dst.Status = in.Status
+
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+)
+
+func TestConvertRemoteToV1(t *testing.T) {
+	v2Agent := &InstanaAgentRemote{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote-agent", Namespace: "instana-agent"},
+		Spec: InstanaAgentRemoteSpec{
+			Agent: AgentSpec{
+				Backends: []BackendSpec{
+					{EndpointHost: "primary.instana.io", EndpointPort: "443", KeySecretRef: secretRef("keys", "key")},
+					{EndpointHost: "secondary.instana.io", EndpointPort: "8443", KeySecretRef: secretRef("keys", "key-1")},
+				},
+			},
+			Zone: instanav1.Name{Name: "zone"},
+		},
+	}
+
+	actual := &instanav1.InstanaAgentRemote{}
+	require.NoError(t, v2Agent.ConvertTo(actual))
+
+	require.Equal(t, "remote-agent", actual.Name)
+	require.Equal(t, "keys", actual.Spec.Agent.KeysSecret)
+	require.Equal(t, "primary.instana.io", actual.Spec.Agent.EndpointHost)
+	require.Equal(
+		t,
+		[]instanav1.BackendSpec{{EndpointHost: "secondary.instana.io", EndpointPort: "8443"}},
+		actual.Spec.Agent.AdditionalBackends,
+	)
+	require.Equal(t, "zone", actual.Spec.Zone.Name)
+}
+
+func TestConvertRemoteToV1InvalidSecretRefs(t *testing.T) {
+	v2Agent := &InstanaAgentRemote{
+		Spec: InstanaAgentRemoteSpec{
+			Agent: AgentSpec{
+				Backends: []BackendSpec{
+					{EndpointHost: "primary.instana.io", KeySecretRef: secretRef("keys", "key")},
+					{EndpointHost: "secondary.instana.io", KeySecretRef: secretRef("other", "key-1")},
+				},
+			},
+		},
+	}
+
+	require.Error(t, v2Agent.ConvertTo(&instanav1.InstanaAgentRemote{}))
+}
+
+func TestRoundTripRemoteV1(t *testing.T) {
+	v1Agent := &instanav1.InstanaAgentRemote{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote-agent", Namespace: "instana-agent"},
+		Spec: instanav1.InstanaAgentRemoteSpec{
+			Agent: instanav1.BaseAgentSpec{
+				KeysSecret:   "keys",
+				EndpointHost: "primary.instana.io",
+				EndpointPort: "443",
+				AdditionalBackends: []instanav1.BackendSpec{
+					{EndpointHost: "secondary.instana.io", EndpointPort: "443"},
+				},
+			},
+			Zone:     instanav1.Name{Name: "zone"},
+			Hostname: &instanav1.Name{Name: "remote-host"},
+			Ownership: instanav1.FieldOwnershipSpec{
+				FieldManagers: []string{"kube-controller-manager"},
+			},
+		},
+		Status: instanav1.InstanaAgentRemoteStatus{AvailableBackends: "1/2"},
+	}
+	v1Agent.Default()
+
+	v2Agent := &InstanaAgentRemote{}
+	require.NoError(t, v2Agent.ConvertFrom(v1Agent))
+
+	actual := &instanav1.InstanaAgentRemote{}
+	require.NoError(t, v2Agent.ConvertTo(actual))
+	require.Equal(t, v1Agent, actual)
+}
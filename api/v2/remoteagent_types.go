@@ -0,0 +1,88 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+)
+
+// InstanaAgentRemoteSpec defines the desired state of the Instana Agent Remote
+type InstanaAgentRemoteSpec struct {
+	// UseSecretMounts specifies whether to mount secrets as files instead of environment variables.
+	// This is more secure as it prevents secrets from being exposed in the environment.
+	// Default is true.
+	// +kubebuilder:validation:Optional
+	UseSecretMounts *bool `json:"useSecretMounts,omitempty"`
+
+	// Agent deployment specific fields.
+	// +kubebuilder:validation:Required
+	Agent AgentSpec `json:"agent"`
+
+	// Name of the zone in which the host(s) will be displayed on the map. Required as we do not set cluster name.
+	// +kubebuilder:validation:Required
+	Zone instanav1.Name `json:"zone"`
+
+	// Specifies whether RBAC resources should be created.
+	// +kubebuilder:validation:Optional
+	Rbac instanav1.Create `json:"rbac,omitempty"`
+
+	// Specifies whether a ServiceAccount should be created (default `true`).
+	// +kubebuilder:validation:Optional
+	ServiceAccount instanav1.ServiceAccountSpec `json:"serviceAccount,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Hostname *instanav1.Name `json:"hostname,omitempty"`
+
+	// Lets other controllers own selected fields of the resources managed by the operator.
+	// +kubebuilder:validation:Optional
+	Ownership instanav1.FieldOwnershipSpec `json:"ownership,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+//nolint:lll
+// +kubebuilder:resource:path=agentsremote,singular=agentremote,shortName=ar,scope=Namespaced,categories=monitoring;openshift-optional
+// +kubebuilder:printcolumn:name="Backends",type=string,JSONPath=`.status.availableBackends`,description="Backends with an available agent Deployment"
+// +kubebuilder:printcolumn:name="Available",type=string,JSONPath=`.status.conditions[?(@.type=="AgentAvailable")].status`
+// +kubebuilder:printcolumn:name="Reconciled",type=string,JSONPath=`.status.conditions[?(@.type=="ReconcileSucceeded")].status`
+// +kubebuilder:printcolumn:name="Zone",type=string,JSONPath=`.spec.zone.name`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:unservedversion
+
+// InstanaAgentRemote is the Schema for the agentsremote API
+type InstanaAgentRemote struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InstanaAgentRemoteSpec             `json:"spec,omitempty"`
+	Status instanav1.InstanaAgentRemoteStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InstanaAgentRemoteList contains a list of InstanaAgentRemote
+type InstanaAgentRemoteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InstanaAgentRemote `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&InstanaAgentRemote{}, &InstanaAgentRemoteList{})
+}
@@ -0,0 +1,116 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+)
+
+// SetupInstanaAgentRemoteWebhookWithManager registers the defaulting and validating webhooks for v2
+// InstanaAgentRemotes with the manager, the conversion webhook is registered alongside as v2 is convertible to the v1
+// hub
+func SetupInstanaAgentRemoteWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &InstanaAgentRemote{}).
+		WithDefaulter(&instanaAgentRemoteDefaulter{}).
+		WithValidator(&instanaAgentRemoteValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-instana-io-v2-instanaagentremote,mutating=true,failurePolicy=fail,sideEffects=None,groups=instana.io,resources=agentsremote,verbs=create;update,versions=v2,name=minstanaagentremote-v2.instana.io,admissionReviewVersions=v1
+
+// instanaAgentRemoteDefaulter applies the v1 defaults to v2 InstanaAgentRemotes by round-tripping them through the hub
+// version
+type instanaAgentRemoteDefaulter struct{}
+
+var _ admission.Defaulter[*InstanaAgentRemote] = &instanaAgentRemoteDefaulter{}
+
+func (d *instanaAgentRemoteDefaulter) Default(_ context.Context, agent *InstanaAgentRemote) error {
+	hub := &instanav1.InstanaAgentRemote{}
+	if err := agent.ConvertTo(hub); err != nil {
+		return err
+	}
+	hub.Default()
+	return agent.ConvertFrom(hub)
+}
+
+// +kubebuilder:webhook:path=/validate-instana-io-v2-instanaagentremote,mutating=false,failurePolicy=fail,sideEffects=None,groups=instana.io,resources=agentsremote,verbs=create;update,versions=v2,name=vinstanaagentremote-v2.instana.io,admissionReviewVersions=v1
+
+// instanaAgentRemoteValidator validates v2 InstanaAgentRemotes by the rules of the v1 hub version, so that both
+// versions are validated alike
+type instanaAgentRemoteValidator struct{}
+
+var _ admission.Validator[*InstanaAgentRemote] = &instanaAgentRemoteValidator{}
+
+func (v *instanaAgentRemoteValidator) ValidateCreate(
+	ctx context.Context,
+	agent *InstanaAgentRemote,
+) (admission.Warnings, error) {
+	hub, err := agent.hub()
+	if err != nil {
+		return nil, err
+	}
+	return instanav1.NewInstanaAgentRemoteValidator().ValidateCreate(ctx, hub)
+}
+
+func (v *instanaAgentRemoteValidator) ValidateUpdate(
+	ctx context.Context,
+	oldAgent *InstanaAgentRemote,
+	agent *InstanaAgentRemote,
+) (admission.Warnings, error) {
+	if agent.DeletionTimestamp != nil || equality.Semantic.DeepEqual(oldAgent.Spec, agent.Spec) {
+		return nil, nil
+	}
+	hub, err := agent.hub()
+	if err != nil {
+		return nil, err
+	}
+	oldHub, err := oldAgent.hub()
+	if err != nil {
+		// an agent that cannot be converted was already invalid, like the empty agent it is validated against instead
+		oldHub = &instanav1.InstanaAgentRemote{}
+	}
+	return instanav1.NewInstanaAgentRemoteValidator().ValidateUpdate(ctx, oldHub, hub)
+}
+
+func (v *instanaAgentRemoteValidator) ValidateDelete(
+	_ context.Context,
+	_ *InstanaAgentRemote,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// hub converts the agent to the v1 hub version. Secret references that cannot be converted are reported on their v2
+// fields.
+func (in *InstanaAgentRemote) hub() (*instanav1.InstanaAgentRemote, error) {
+	if _, errs := in.Spec.Agent.keysSecretName(field.NewPath("spec", "agent")); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("InstanaAgentRemote").GroupKind(), in.Name, errs)
+	}
+
+	hub := &instanav1.InstanaAgentRemote{}
+	if err := in.ConvertTo(hub); err != nil {
+		return nil, err
+	}
+	return hub, nil
+}
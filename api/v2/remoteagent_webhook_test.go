@@ -0,0 +1,79 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+)
+
+func TestInstanaAgentRemoteValidator(t *testing.T) {
+	validator := &instanaAgentRemoteValidator{}
+	ctx := context.Background()
+
+	valid := &InstanaAgentRemote{
+		Spec: InstanaAgentRemoteSpec{
+			Agent: AgentSpec{Backends: []BackendSpec{{EndpointHost: "primary", KeySecretRef: secretRef("keys", "key")}}},
+			Zone:  instanav1.Name{Name: "zone"},
+		},
+	}
+	_, err := validator.ValidateCreate(ctx, valid)
+	require.NoError(t, err)
+
+	// v2 agents are validated by the rules of v1
+	generatedTLS := valid.DeepCopy()
+	generatedTLS.Spec.Agent.TLS = instanav1.TlsSpec{AutoGenerate: true, CertManager: &instanav1.CertManagerSpec{}}
+	_, err = validator.ValidateCreate(ctx, generatedTLS)
+	require.Equal(
+		t,
+		[]string{"spec.agent.tls.certManager", "spec.agent.tls.autoGenerate"},
+		invalidFields(t, err),
+	)
+
+	_, err = validator.ValidateCreate(ctx, &InstanaAgentRemote{})
+	require.Equal(t, []string{"spec.agent.key", "spec.zone.name"}, invalidFields(t, err))
+
+	_, err = validator.ValidateUpdate(ctx, valid, &InstanaAgentRemote{})
+	require.True(t, apierrors.IsInvalid(err))
+
+	// updates of agents that were already invalid are admitted with warnings
+	warnings, err := validator.ValidateUpdate(ctx, &InstanaAgentRemote{}, generatedTLS)
+	require.Len(t, warnings, 2)
+	require.NoError(t, err)
+
+	_, err = validator.ValidateDelete(ctx, &InstanaAgentRemote{})
+	require.NoError(t, err)
+}
+
+func TestInstanaAgentRemoteDefaulter(t *testing.T) {
+	agent := &InstanaAgentRemote{
+		Spec: InstanaAgentRemoteSpec{
+			Agent: AgentSpec{Backends: []BackendSpec{{EndpointHost: "custom.instana.io", Key: "key"}}},
+		},
+	}
+
+	require.NoError(t, (&instanaAgentRemoteDefaulter{}).Default(context.Background(), agent))
+	require.Equal(t, "443", agent.Spec.Agent.Backends[0].EndpointPort)
+	require.Equal(t, "icr.io/instana/agent", agent.Spec.Agent.Image.Name)
+	require.True(t, *agent.Spec.Rbac.Create)
+	require.True(t, *agent.Spec.UseSecretMounts)
+}
@@ -18,21 +18,28 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
 
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/certgen"
 	"github.com/instana/instana-agent-operator/pkg/k8s/client"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/agent/certificate"
 	namespaces_configmap "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/agent/configmap/namespaces-configmap"
 	agentdaemonset "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/agent/daemonset"
+	generatedtls "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/agent/generated-tls"
 	headlessservice "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/agent/headless-service"
 	agentrbac "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/agent/rbac"
 	agentsecrets "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/agent/secrets"
@@ -50,18 +57,96 @@ import (
 	k8ssensorpoddisruptionbudget "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/k8s-sensor/poddisruptionbudget"
 	k8ssensorrbac "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/k8s-sensor/rbac"
 	k8ssensorserviceaccount "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/k8s-sensor/serviceaccount"
+	"github.com/instana/instana-agent-operator/pkg/k8s/operator/metrics"
 	"github.com/instana/instana-agent-operator/pkg/k8s/operator/operator_utils"
 	"github.com/instana/instana-agent-operator/pkg/k8s/operator/status"
+	"github.com/instana/instana-agent-operator/pkg/pointer"
 )
 
+// AgentBuilderOptions holds the inputs of the InstanaAgent builders that are looked up in the cluster during a
+// reconcile, so that the same resources can also be built without access to a cluster
+type AgentBuilderOptions struct {
+	IsOpenShift bool
+	// PersistHostUniqueIDEnvVar is used for the agent DaemonSet when no zones are configured
+	PersistHostUniqueIDEnvVar bool
+	// ZonePersistHostUniqueIDEnvVar holds the setting for the DaemonSet of each configured zone, in order. Zones
+	// without an entry use PersistHostUniqueIDEnvVar.
+	ZonePersistHostUniqueIDEnvVar []bool
+	KeysSecret                    *corev1.Secret
+	// TLSSecret holds the certificate of the agent if it is issued by cert-manager or generated by the operator
+	TLSSecret *corev1.Secret
+	// CredentialsVersions maps the Secrets holding the credentials referenced by the agent spec to their resourceVersion
+	CredentialsVersions map[string]string
+	// GeneratedTLS are the CA and certificate generated for the agent if tls.autoGenerate is set
+	GeneratedTLS      *certgen.Certificates
+	K8SensorBackends  []backends.K8SensorBackend
+	NamespacesDetails namespaces.NamespacesDetails
+	DeploymentContext *k8ssensordeployment.DeploymentContext
+}
+
+// NewAgentBuilders returns the builders of all resources managed for an InstanaAgent
+func NewAgentBuilders(
+	agent *instanav1.InstanaAgent,
+	statusManager status.AgentStatusManager,
+	opts AgentBuilderOptions,
+) []builder.ObjectBuilder {
+	builders := append(
+		getDaemonSetBuilders(
+			agent,
+			opts.IsOpenShift,
+			statusManager,
+			opts.PersistHostUniqueIDEnvVar,
+			opts.ZonePersistHostUniqueIDEnvVar,
+			opts.KeysSecret,
+			opts.TLSSecret,
+			opts.CredentialsVersions,
+		),
+		headlessservice.NewHeadlessServiceBuilder(agent),
+		agentsecrets.NewConfigBuilder(agent, statusManager, opts.KeysSecret, opts.K8SensorBackends),
+		agentsecrets.NewContainerBuilder(agent, opts.KeysSecret),
+		tlssecret.NewSecretBuilder(agent),
+		certificate.NewCertificateBuilder(agent),
+		generatedtls.NewSecretBuilder(agent, opts.GeneratedTLS),
+		generatedtls.NewCASecretBuilder(agent, opts.GeneratedTLS),
+		generatedtls.NewCAConfigMapBuilder(agent, opts.GeneratedTLS),
+		service.NewServiceBuilder(agent),
+		agentrbac.NewClusterRoleBuilder(agent),
+		agentrbac.NewClusterRoleBindingBuilder(agent),
+		agentserviceaccount.NewServiceAccountBuilder(agent),
+		k8ssensorpoddisruptionbudget.NewPodDisruptionBudgetBuilder(agent),
+		k8ssensorrbac.NewClusterRoleBuilder(agent),
+		k8ssensorrbac.NewClusterRoleBindingBuilder(agent),
+		k8ssensorrbac.NewRoleBuilder(agent),
+		k8ssensorrbac.NewRoleBindingBuilder(agent),
+		k8ssensorserviceaccount.NewServiceAccountBuilder(agent),
+		k8ssensorconfigmap.NewConfigMapBuilder(agent, opts.K8SensorBackends),
+		keyssecret.NewSecretBuilder(agent, opts.K8SensorBackends),
+		namespaces_configmap.NewConfigMapBuilder(agent, statusManager, opts.NamespacesDetails),
+	)
+
+	return append(
+		builders,
+		getK8sSensorDeployments(
+			agent,
+			opts.IsOpenShift,
+			statusManager,
+			opts.K8SensorBackends,
+			opts.KeysSecret,
+			opts.DeploymentContext,
+		)...,
+	)
+}
+
 func getDaemonSetBuilders(
-	ctx context.Context,
-	r *InstanaAgentReconciler,
 	agent *instanav1.InstanaAgent,
 	isOpenShift bool,
-	shouldSetPersistHostUniqueIDEnvVar bool,
 	statusManager status.AgentStatusManager,
-) ([]builder.ObjectBuilder, reconcileReturn) {
+	shouldSetPersistHostUniqueIDEnvVar bool,
+	zoneSettings []bool,
+	keysSecret *corev1.Secret,
+	tlsSecret *corev1.Secret,
+	credentialsVersions map[string]string,
+) []builder.ObjectBuilder {
 	if len(agent.Spec.Zones) == 0 {
 		return []builder.ObjectBuilder{
 			agentdaemonset.NewDaemonSetBuilder(
@@ -69,25 +154,19 @@ func getDaemonSetBuilders(
 				isOpenShift,
 				statusManager,
 				shouldSetPersistHostUniqueIDEnvVar,
+				keysSecret,
+				tlsSecret,
+				credentialsVersions,
 			),
-		}, reconcileContinue()
+		}
 	}
 
 	builders := make([]builder.ObjectBuilder, 0, len(agent.Spec.Zones))
-
-	// First, collect all zone check results to ensure consistency
-	// If any check fails, we abort before creating any builders
-	zoneSettings := make([]bool, len(agent.Spec.Zones))
 	for i, zone := range agent.Spec.Zones {
-		shouldSetForZone, shouldSetRes := r.shouldSetPersistHostUniqueIDEnvVar(ctx, agent, &zone)
-		if shouldSetRes.suppliesReconcileResult() {
-			return nil, shouldSetRes
+		shouldSetForZone := shouldSetPersistHostUniqueIDEnvVar
+		if i < len(zoneSettings) {
+			shouldSetForZone = zoneSettings[i]
 		}
-		zoneSettings[i] = shouldSetForZone
-	}
-
-	// All checks passed, now create builders with the collected settings
-	for i, zone := range agent.Spec.Zones {
 		builders = append(
 			builders,
 			agentdaemonset.NewDaemonSetBuilderWithZoneInfo(
@@ -95,12 +174,37 @@ func getDaemonSetBuilders(
 				isOpenShift,
 				statusManager,
 				&zone,
-				zoneSettings[i],
+				shouldSetForZone,
+				keysSecret,
+				tlsSecret,
+				credentialsVersions,
 			),
 		)
 	}
 
-	return builders, reconcileContinue()
+	return builders
+}
+
+// getZonePersistHostUniqueIDEnvVar collects the INSTANA_PERSIST_HOST_UNIQUE_ID setting of every zone up front, so
+// that no builders are created unless the checks of all zones succeeded
+func (r *InstanaAgentReconciler) getZonePersistHostUniqueIDEnvVar(
+	ctx context.Context,
+	agent *instanav1.InstanaAgent,
+) ([]bool, reconcileReturn) {
+	if len(agent.Spec.Zones) == 0 {
+		return nil, reconcileContinue()
+	}
+
+	zoneSettings := make([]bool, len(agent.Spec.Zones))
+	for i, zone := range agent.Spec.Zones {
+		shouldSetForZone, shouldSetRes := r.shouldSetPersistHostUniqueIDEnvVar(ctx, agent, &zone)
+		if shouldSetRes.suppliesReconcileResult() {
+			return nil, shouldSetRes
+		}
+		zoneSettings[i] = shouldSetForZone
+	}
+
+	return zoneSettings, reconcileContinue()
 }
 
 func getK8sSensorDeployments(
@@ -304,7 +408,7 @@ func copyETCDResourcesToNamespace(
 	sourceConfigMap *corev1.ConfigMap,
 	sourceSecret *corev1.Secret,
 	logger logr.Logger,
-) bool {
+) error {
 	// Copy ETCD ConfigMap to instana-agent namespace with synchronization tracking
 	targetCAConfigMap := &corev1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
@@ -321,7 +425,7 @@ func copyETCDResourcesToNamespace(
 	}
 	if _, err := c.Apply(ctx, targetCAConfigMap).Get(); err != nil {
 		logger.Error(err, "Failed to copy ETCD CA ConfigMap to instana-agent namespace")
-		return false
+		return fmt.Errorf("failed to copy the ETCD CA bundle to namespace %s: %w", agent.Namespace, err)
 	}
 	logger.Info("Successfully copied/updated ETCD CA ConfigMap",
 		"sourceResourceVersion", sourceConfigMap.ResourceVersion)
@@ -350,12 +454,12 @@ func copyETCDResourcesToNamespace(
 		// 1. OpenShiftETCDResourcesExist=false prevents mounting incomplete resources
 		// 2. The operator reconciles frequently (default: every few minutes)
 		// 3. The ConfigMap is harmless without the Secret
-		return false
+		return fmt.Errorf("failed to copy the ETCD client certificate to namespace %s: %w", agent.Namespace, err)
 	}
 	logger.Info("Successfully copied/updated ETCD client Secret",
 		"sourceResourceVersion", sourceSecret.ResourceVersion)
 
-	return true
+	return nil
 }
 
 // cleanupCopiedETCDResources removes copied ETCD resources from the target namespace
@@ -418,13 +522,16 @@ func logAndCleanupETCDErrors(
 	cleanupCopiedETCDResources(ctx, c, agent, logger)
 }
 
-// setupOpenShiftETCDMonitoring sets up ETCD monitoring for OpenShift clusters
+// setupOpenShiftETCDMonitoring sets up ETCD monitoring for OpenShift clusters and reports whether it is enabled. With
+// dryRun set, the ETCD resources are neither copied to nor cleaned up from the namespace of the agent, the deployment
+// context is returned as if they had been copied.
 func setupOpenShiftETCDMonitoring(
 	ctx context.Context,
 	c client.InstanaAgentClient,
 	agent *instanav1.InstanaAgent,
+	dryRun bool,
 	logger logr.Logger,
-) *k8ssensordeployment.DeploymentContext {
+) (*k8ssensordeployment.DeploymentContext, *instanav1.ETCDMonitoringStatus) {
 	deploymentContext := &k8ssensordeployment.DeploymentContext{}
 
 	// Fetch ETCD resources from openshift-etcd namespace
@@ -451,34 +558,140 @@ func setupOpenShiftETCDMonitoring(
 
 	// If either resource is invalid, log errors, cleanup, and return early
 	if caErr != nil || certErr != nil {
-		logAndCleanupETCDErrors(ctx, c, agent, caErr, certErr, logger)
-		return deploymentContext
+		if !dryRun {
+			logAndCleanupETCDErrors(ctx, c, agent, caErr, certErr, logger)
+		}
+		return deploymentContext, disabledETCDMonitoring(
+			fmt.Sprintf(
+				"the ETCD resources in namespace %s are unusable: %s",
+				constants.ETCDNamespace,
+				errors.Join(caErr, certErr),
+			),
+		)
 	}
 
 	// Happy path - both resources are valid
 	logger.Info("OpenShift ETCD resources found, enabling ETCD monitoring")
 
 	// Copy resources to instana-agent namespace
-	if copyETCDResourcesToNamespace(ctx, c, agent, etcdCAConfigMap, etcdClientSecret, logger) {
-		deploymentContext.OpenShiftETCDResourcesExist = true
+	if dryRun {
+		logger.V(1).Info("Skipping the copy of the OpenShift ETCD resources while previewing")
+	} else if err := copyETCDResourcesToNamespace(
+		ctx,
+		c,
+		agent,
+		etcdCAConfigMap,
+		etcdClientSecret,
+		logger,
+	); err != nil {
+		return deploymentContext, disabledETCDMonitoring(err.Error())
+	}
+	deploymentContext.OpenShiftETCDResourcesExist = true
+
+	return deploymentContext, &instanav1.ETCDMonitoringStatus{
+		Source:  instanav1.ETCDMonitoringSourceOpenShift,
+		Targets: []string{constants.GetETCDOCPMetricsURL()},
+		CAFound: true,
 	}
+}
 
-	return deploymentContext
+// disabledETCDMonitoring reports ETCD monitoring as disabled for the given reason
+func disabledETCDMonitoring(reason string) *instanav1.ETCDMonitoringStatus {
+	return &instanav1.ETCDMonitoringStatus{DisabledReason: reason}
+}
+
+// undiscoveredETCDMonitoring reports the ETCD monitoring of a vanilla cluster without discovered ETCD targets, which
+// are either configured in the spec or not monitored at all
+func undiscoveredETCDMonitoring(
+	ctx context.Context,
+	c client.InstanaAgentClient,
+	agent *instanav1.InstanaAgent,
+	discoveredETCD *DiscoveredETCDTargets,
+	logger logr.Logger,
+) *instanav1.ETCDMonitoringStatus {
+	switch {
+	case len(agent.Spec.K8sSensor.ETCD.Targets) > 0:
+		return &instanav1.ETCDMonitoringStatus{
+			Source:  instanav1.ETCDMonitoringSourceSpec,
+			Targets: agent.Spec.K8sSensor.ETCD.Targets,
+			CAFound: specETCDCAFound(ctx, c, agent, logger),
+		}
+	case discoveredETCD != nil && discoveredETCD.DisabledReason != "":
+		return disabledETCDMonitoring(discoveredETCD.DisabledReason)
+	default:
+		return disabledETCDMonitoring("no ETCD targets were discovered")
+	}
+}
+
+// specETCDCAFound tells whether the Secret configured in k8s_sensor.etcd.ca of the spec exists and holds the CA
+// certificate under the configured filename
+func specETCDCAFound(
+	ctx context.Context,
+	c client.InstanaAgentClient,
+	agent *instanav1.InstanaAgent,
+	logger logr.Logger,
+) bool {
+	caSpec := agent.Spec.K8sSensor.ETCD.CA
+	if caSpec.SecretName == "" {
+		return false
+	}
+
+	caSecret := &corev1.Secret{} // pragma: allowlist secret
+	if err := c.Get(ctx, types.NamespacedName{Namespace: agent.Namespace, Name: caSpec.SecretName}, caSecret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "Failed to get the ETCD CA Secret", "secret", caSpec.SecretName)
+		}
+		return false
+	}
+
+	filename := caSpec.Filename
+	if filename == "" {
+		filename = "ca.crt"
+	}
+	return len(caSecret.Data[filename]) > 0
 }
 
 // CreateDeploymentContext creates a deployment context for the k8s-sensor deployment.
 // It handles both OpenShift and vanilla Kubernetes cases, setting up the appropriate
-// ETCD configuration based on the environment.
+// ETCD configuration based on the environment, and reports how ETCD is monitored as a result. The number of monitored
+// ETCD targets is recorded in the metrics on every path, it is 0 while ETCD monitoring is disabled.
+// With dryRun set, nothing is written to the cluster, e.g. while previewing the resources of the agent.
 func CreateDeploymentContext(
 	ctx context.Context,
 	c client.InstanaAgentClient,
 	agent *instanav1.InstanaAgent,
 	isOpenShift bool,
+	dryRun bool,
+	logger logr.Logger,
+	discoverETCD ETCDDiscoverFunc,
+) (*k8ssensordeployment.DeploymentContext, *instanav1.ETCDMonitoringStatus, error) {
+	deploymentContext, etcdMonitoring, err := createDeploymentContext(
+		ctx,
+		c,
+		agent,
+		isOpenShift,
+		dryRun,
+		logger,
+		discoverETCD,
+	)
+	if etcdMonitoring != nil {
+		metrics.RecordETCDTargets(agent, len(etcdMonitoring.Targets))
+	}
+	return deploymentContext, etcdMonitoring, err
+}
+
+func createDeploymentContext(
+	ctx context.Context,
+	c client.InstanaAgentClient,
+	agent *instanav1.InstanaAgent,
+	isOpenShift bool,
+	dryRun bool,
 	logger logr.Logger,
 	discoverETCD ETCDDiscoverFunc,
-) (*k8ssensordeployment.DeploymentContext, error) {
+) (*k8ssensordeployment.DeploymentContext, *instanav1.ETCDMonitoringStatus, error) {
 	if isOpenShift {
-		return setupOpenShiftETCDMonitoring(ctx, c, agent, logger), nil
+		deploymentContext, etcdMonitoring := setupOpenShiftETCDMonitoring(ctx, c, agent, dryRun, logger)
+		return deploymentContext, etcdMonitoring, nil
 	}
 
 	// For vanilla Kubernetes, discover ETCD endpoints
@@ -486,11 +699,20 @@ func CreateDeploymentContext(
 	if err != nil {
 		logger.Error(err, "Failed to discover ETCD endpoints")
 		// Continue with reconciliation, don't fail the whole process
-		return nil, nil
+		return nil, disabledETCDMonitoring("ETCD discovery failed: " + err.Error()), nil
 	}
 
 	if discoveredETCD == nil || len(discoveredETCD.Targets) == 0 {
-		return nil, nil
+		return nil, undiscoveredETCDMonitoring(ctx, c, agent, discoveredETCD, logger), nil
+	}
+
+	// Use sorted targets for consistency
+	sortedTargets := getSortedTargets(discoveredETCD.Targets)
+
+	etcdMonitoring := &instanav1.ETCDMonitoringStatus{
+		Source:  instanav1.ETCDMonitoringSourceDiscovered,
+		Targets: sortedTargets,
+		CAFound: discoveredETCD.CAFound,
 	}
 
 	// Check if we need to update the Deployment with new ETCD targets
@@ -508,13 +730,10 @@ func CreateDeploymentContext(
 		needsUpdate := compareAndUpdateETCDTargets(existingDeployment, discoveredETCD.Targets, logger)
 		if !needsUpdate {
 			logger.Info("ETCD targets unchanged, skipping Deployment update")
-			return nil, nil
+			return nil, etcdMonitoring, nil
 		}
 	}
 
-	// Use sorted targets for consistency
-	sortedTargets := getSortedTargets(discoveredETCD.Targets)
-
 	logger.Info("Using discovered ETCD targets", "targets", sortedTargets)
 	deploymentContext := &k8ssensordeployment.DeploymentContext{
 		DiscoveredETCDTargets: sortedTargets,
@@ -523,7 +742,7 @@ func CreateDeploymentContext(
 		deploymentContext.ETCDCASecretName = constants.ETCDCASecretName
 	}
 
-	return deploymentContext, nil
+	return deploymentContext, etcdMonitoring, nil
 }
 
 func (r *InstanaAgentReconciler) applyResources(
@@ -534,6 +753,8 @@ func (r *InstanaAgentReconciler) applyResources(
 	operatorUtils operator_utils.OperatorUtils,
 	statusManager status.AgentStatusManager,
 	keysSecret *corev1.Secret,
+	tlsSecret *corev1.Secret,
+	credentialsVersions map[string]string,
 	k8SensorBackends []backends.K8SensorBackend,
 	namespacesDetails namespaces.NamespacesDetails,
 ) reconcileReturn {
@@ -541,11 +762,12 @@ func (r *InstanaAgentReconciler) applyResources(
 	log.V(1).Info("applying Kubernetes resources for agent")
 
 	// Create deployment context for k8s-sensor
-	deploymentContext, err := CreateDeploymentContext(
+	deploymentContext, etcdMonitoring, err := CreateDeploymentContext(
 		ctx,
 		r.client,
 		agent,
 		isOpenShift,
+		previewRequested(agent),
 		log,
 		r.DiscoverETCDEndpoints,
 	)
@@ -554,55 +776,98 @@ func (r *InstanaAgentReconciler) applyResources(
 		return reconcileFailure(err)
 	}
 
-	daemonSetBuilders, daemonSetBuildersRes := getDaemonSetBuilders(
-		ctx,
-		r,
+	zoneSettings, zoneSettingsRes := r.getZonePersistHostUniqueIDEnvVar(ctx, agent)
+	if zoneSettingsRes.suppliesReconcileResult() {
+		return zoneSettingsRes
+	}
+
+	generatedTLS, err := r.generateTLS(ctx, agent, time.Now())
+	if err != nil {
+		log.Error(err, "failed to generate the TLS certificate of the agent")
+		return reconcileFailure(err)
+	}
+	if generatedTLS != nil {
+		// the agents are rolled out again whenever the generated certificate is rotated
+		tlsSecret = &corev1.Secret{Data: generatedtls.SecretData(generatedTLS)}
+	}
+
+	builders := NewAgentBuilders(
 		agent,
-		isOpenShift,
-		shouldSetPersistHostUniqueIDEnvVar,
 		statusManager,
+		AgentBuilderOptions{
+			IsOpenShift:                   isOpenShift,
+			PersistHostUniqueIDEnvVar:     shouldSetPersistHostUniqueIDEnvVar,
+			ZonePersistHostUniqueIDEnvVar: zoneSettings,
+			KeysSecret:                    keysSecret,
+			TLSSecret:                     tlsSecret,
+			CredentialsVersions:           credentialsVersions,
+			GeneratedTLS:                  generatedTLS,
+			K8SensorBackends:              k8SensorBackends,
+			NamespacesDetails:             namespacesDetails,
+			DeploymentContext:             deploymentContext,
+		},
 	)
-	if daemonSetBuildersRes.suppliesReconcileResult() {
-		return daemonSetBuildersRes
-	}
 
-	builders := append(
-		daemonSetBuilders,
-		headlessservice.NewHeadlessServiceBuilder(agent),
-		agentsecrets.NewConfigBuilder(agent, statusManager, keysSecret, k8SensorBackends),
-		agentsecrets.NewContainerBuilder(agent, keysSecret),
-		tlssecret.NewSecretBuilder(agent),
-		service.NewServiceBuilder(agent),
-		agentrbac.NewClusterRoleBuilder(agent),
-		agentrbac.NewClusterRoleBindingBuilder(agent),
-		agentserviceaccount.NewServiceAccountBuilder(agent),
-		k8ssensorpoddisruptionbudget.NewPodDisruptionBudgetBuilder(agent),
-		k8ssensorrbac.NewClusterRoleBuilder(agent),
-		k8ssensorrbac.NewClusterRoleBindingBuilder(agent),
-		k8ssensorrbac.NewRoleBuilder(agent),
-		k8ssensorrbac.NewRoleBindingBuilder(agent),
-		k8ssensorserviceaccount.NewServiceAccountBuilder(agent),
-		k8ssensorconfigmap.NewConfigMapBuilder(agent, k8SensorBackends),
-		keyssecret.NewSecretBuilder(agent, k8SensorBackends),
-		namespaces_configmap.NewConfigMapBuilder(agent, statusManager, namespacesDetails),
-	)
+	if previewRequested(agent) {
+		return r.previewResources(ctx, agent, operatorUtils, statusManager, builders)
+	}
 
-	builders = append(
-		builders,
-		getK8sSensorDeployments(
-			agent,
-			isOpenShift,
-			statusManager,
-			k8SensorBackends,
-			keysSecret,
-			deploymentContext,
-		)...)
+	if !pointer.DerefOrDefault(agent.Spec.K8sSensor.DeploymentSpec.Enabled.Enabled, true) {
+		etcdMonitoring = disabledETCDMonitoring("the k8sensor deployment is disabled")
+	}
+	statusManager.SetETCDMonitoring(etcdMonitoring)
 
-	if err := operatorUtils.ApplyAll(builders...); err != nil {
+	err = operatorUtils.ApplyAll(builders...)
+	statusManager.SetInventory(operatorUtils.Inventory())
+	if err != nil {
 		log.Error(err, "failed to apply kubernetes resources for agent")
 		return reconcileFailure(err)
 	}
+	statusManager.SetTLS(generatedTLSStatus(generatedTLS))
 
 	log.V(1).Info("successfully applied kubernetes resources for agent")
 	return reconcileContinue()
 }
+
+// previewRequested reports whether the agent is annotated to dry-run its spec instead of applying it
+func previewRequested(agent *instanav1.InstanaAgent) bool {
+	return annotationEnabled(agent, instanav1.PreviewAnnotation)
+}
+
+// previewResources dry-runs the resources for the agent against the cluster and records the changes they would
+// cause in the status, without applying them or cleaning up dependents
+func (r *InstanaAgentReconciler) previewResources(
+	ctx context.Context,
+	agent *instanav1.InstanaAgent,
+	operatorUtils operator_utils.OperatorUtils,
+	statusManager status.AgentStatusManager,
+	builders []builder.ObjectBuilder,
+) reconcileReturn {
+	log := r.loggerFor(ctx, agent)
+
+	entries, err := operatorUtils.PreviewAll(builders...)
+	if err != nil {
+		log.Error(err, "failed to preview kubernetes resources for agent")
+		return reconcileFailure(err)
+	}
+
+	preview := &instanav1.PreviewStatus{
+		ObservedGeneration: agent.GetGeneration(),
+		Time:               metav1.Now(),
+		Objects:            entries,
+	}
+	// the time only changes with the preview, so that previews that change nothing do not change the status either
+	if previous := agent.Status.Preview; previous != nil &&
+		previous.ObservedGeneration == preview.ObservedGeneration &&
+		equality.Semantic.DeepEqual(previous.Objects, preview.Objects) {
+		preview.Time = previous.Time
+	}
+	statusManager.SetPreview(preview)
+
+	log.Info(
+		"previewed kubernetes resources for agent without applying them",
+		"annotation", instanav1.PreviewAnnotation,
+		"objects", len(entries),
+	)
+	return reconcileSuccess(ctrl.Result{})
+}
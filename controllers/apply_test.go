@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -33,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
 	"github.com/instana/instana-agent-operator/internal/mocks"
@@ -41,11 +43,14 @@ import (
 	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/constants"
 	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/namespaces"
 	"github.com/instana/instana-agent-operator/pkg/k8s/operator/operator_utils"
+	"github.com/instana/instana-agent-operator/pkg/k8s/operator/status"
 	"github.com/instana/instana-agent-operator/pkg/result"
 )
 
 type mockOperatorUtils struct {
-	applyAllCalled bool
+	applyAllCalled   bool
+	previewAllCalled bool
+	preview          []instanav1.PreviewEntry
 }
 
 func (m *mockOperatorUtils) ClusterIsOpenShift() (bool, error) {
@@ -57,12 +62,45 @@ func (m *mockOperatorUtils) ApplyAll(builders ...builder.ObjectBuilder) error {
 	return nil
 }
 
+func (m *mockOperatorUtils) PreviewAll(builders ...builder.ObjectBuilder) ([]instanav1.PreviewEntry, error) {
+	m.previewAllCalled = true
+	return m.preview, nil
+}
+
 func (m *mockOperatorUtils) DeleteAll() error {
 	return nil
 }
 
+func (m *mockOperatorUtils) Inventory() []instanav1.ResourceInventoryEntry {
+	return nil
+}
+
 var _ operator_utils.OperatorUtils = (*mockOperatorUtils)(nil)
 
+// etcdTargetsMetric returns the number of ETCD targets recorded in the metrics for the agent
+func etcdTargetsMetric(t *testing.T, agent *instanav1.InstanaAgent) float64 {
+	families, err := ctrlmetrics.Registry.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != "instana_agent_operator_etcd_targets_discovered" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string, len(metric.GetLabel()))
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["namespace"] == agent.Namespace && labels["name"] == agent.Name {
+				return metric.GetGauge().GetValue()
+			}
+		}
+	}
+
+	require.Fail(t, "no ETCD targets were recorded for the agent")
+	return 0
+}
+
 func TestCreateDeploymentContext_SimplifiedTests(t *testing.T) {
 	agent := &instanav1.InstanaAgent{
 		ObjectMeta: metav1.ObjectMeta{
@@ -117,11 +155,12 @@ func TestCreateDeploymentContext_SimplifiedTests(t *testing.T) {
 			return nil, nil
 		}
 
-		deploymentContext, err := CreateDeploymentContext(
+		deploymentContext, etcdMonitoring, err := CreateDeploymentContext(
 			ctx,
 			mockClient,
 			agent,
 			true,
+			false,
 			logger,
 			mockDiscoverETCD,
 		)
@@ -133,6 +172,16 @@ func TestCreateDeploymentContext_SimplifiedTests(t *testing.T) {
 			deploymentContext.OpenShiftETCDResourcesExist,
 			"ETCD resources should exist when Get calls succeed",
 		)
+		assert.Equal(
+			t,
+			&instanav1.ETCDMonitoringStatus{
+				Source:  instanav1.ETCDMonitoringSourceOpenShift,
+				Targets: []string{constants.GetETCDOCPMetricsURL()},
+				CAFound: true,
+			},
+			etcdMonitoring,
+		)
+		assert.Equal(t, 1.0, etcdTargetsMetric(t, agent))
 		mockClient.AssertExpectations(t)
 	})
 
@@ -144,17 +193,19 @@ func TestCreateDeploymentContext_SimplifiedTests(t *testing.T) {
 			return nil, nil
 		}
 
-		deploymentContext, err := CreateDeploymentContext(
+		deploymentContext, etcdMonitoring, err := CreateDeploymentContext(
 			ctx,
 			mockClient,
 			agent,
 			false,
+			false,
 			logger,
 			mockDiscoverETCD,
 		)
 
 		require.NoError(t, err)
 		assert.Nil(t, deploymentContext)
+		assert.Equal(t, disabledETCDMonitoring("no ETCD targets were discovered"), etcdMonitoring)
 		mockClient.AssertExpectations(t)
 	})
 
@@ -172,11 +223,12 @@ func TestCreateDeploymentContext_SimplifiedTests(t *testing.T) {
 		mockClient.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(apierrors.NewNotFound(schema.GroupResource{}, ""))
 
-		deploymentContext, err := CreateDeploymentContext(
+		deploymentContext, etcdMonitoring, err := CreateDeploymentContext(
 			ctx,
 			mockClient,
 			agent,
 			false,
+			false,
 			logger,
 			mockDiscoverETCD,
 		)
@@ -189,6 +241,15 @@ func TestCreateDeploymentContext_SimplifiedTests(t *testing.T) {
 			deploymentContext.DiscoveredETCDTargets,
 		)
 		assert.Equal(t, constants.ETCDCASecretName, deploymentContext.ETCDCASecretName)
+		assert.Equal(
+			t,
+			&instanav1.ETCDMonitoringStatus{
+				Source:  instanav1.ETCDMonitoringSourceDiscovered,
+				Targets: []string{"https://etcd-1:2379/metrics", "https://etcd-2:2379/metrics"},
+				CAFound: true,
+			},
+			etcdMonitoring,
+		)
 		mockClient.AssertExpectations(t)
 	})
 
@@ -231,17 +292,20 @@ func TestCreateDeploymentContext_SimplifiedTests(t *testing.T) {
 				*deployment = *existingDeployment
 			})
 
-		deploymentContext, err := CreateDeploymentContext(
+		deploymentContext, etcdMonitoring, err := CreateDeploymentContext(
 			ctx,
 			mockClient,
 			agent,
 			false,
+			false,
 			logger,
 			mockDiscoverETCD,
 		)
 
 		require.NoError(t, err)
 		assert.Nil(t, deploymentContext) // Should return nil when no update needed
+		// ETCD is still monitored with the unchanged targets
+		assert.Equal(t, instanav1.ETCDMonitoringSourceDiscovered, etcdMonitoring.Source)
 		mockClient.AssertExpectations(t)
 	})
 
@@ -284,11 +348,12 @@ func TestCreateDeploymentContext_SimplifiedTests(t *testing.T) {
 				*deployment = *existingDeployment
 			})
 
-		deploymentContext, err := CreateDeploymentContext(
+		deploymentContext, etcdMonitoring, err := CreateDeploymentContext(
 			ctx,
 			mockClient,
 			agent,
 			false,
+			false,
 			logger,
 			mockDiscoverETCD,
 		)
@@ -301,6 +366,7 @@ func TestCreateDeploymentContext_SimplifiedTests(t *testing.T) {
 			deploymentContext.DiscoveredETCDTargets,
 		)
 		assert.Equal(t, constants.ETCDCASecretName, deploymentContext.ETCDCASecretName)
+		assert.Equal(t, deploymentContext.DiscoveredETCDTargets, etcdMonitoring.Targets)
 		mockClient.AssertExpectations(t)
 	})
 
@@ -312,22 +378,162 @@ func TestCreateDeploymentContext_SimplifiedTests(t *testing.T) {
 			return nil, assert.AnError
 		}
 
-		deploymentContext, err := CreateDeploymentContext(
+		deploymentContext, etcdMonitoring, err := CreateDeploymentContext(
 			ctx,
 			mockClient,
 			agent,
 			false,
+			false,
 			logger,
 			mockDiscoverETCD,
 		)
 
 		require.NoError(t, err) // Function continues on error
 		assert.Nil(t, deploymentContext)
+		assert.Equal(t, disabledETCDMonitoring("ETCD discovery failed: "+assert.AnError.Error()), etcdMonitoring)
+		assert.Equal(t, 0.0, etcdTargetsMetric(t, agent))
 		mockClient.AssertExpectations(t)
 	})
+
+	t.Run("Vanilla K8s with ETCD targets in the spec reports them", func(t *testing.T) {
+		mockClient := &mocks.MockInstanaAgentClient{}
+		mockClient.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1.Secret"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				args.Get(2).(*corev1.Secret).Data = map[string][]byte{"ca.crt": []byte("test-ca-cert-data")}
+			}).
+			Return(nil)
+
+		agentWithTargets := agent.DeepCopy()
+		agentWithTargets.Spec.K8sSensor.ETCD.Targets = []string{"https://etcd:2379/metrics"}
+		agentWithTargets.Spec.K8sSensor.ETCD.CA.SecretName = "etcd-ca"
+
+		// Discovery is skipped for targets in the spec
+		mockDiscoverETCD := func(ctx context.Context, agent *instanav1.InstanaAgent) (*DiscoveredETCDTargets, error) {
+			return nil, nil
+		}
+
+		deploymentContext, etcdMonitoring, err := CreateDeploymentContext(
+			ctx,
+			mockClient,
+			agentWithTargets,
+			false,
+			false,
+			logger,
+			mockDiscoverETCD,
+		)
+
+		require.NoError(t, err)
+		assert.Nil(t, deploymentContext)
+		assert.Equal(
+			t,
+			&instanav1.ETCDMonitoringStatus{
+				Source:  instanav1.ETCDMonitoringSourceSpec,
+				Targets: []string{"https://etcd:2379/metrics"},
+				CAFound: true,
+			},
+			etcdMonitoring,
+		)
+		assert.Equal(t, 1.0, etcdTargetsMetric(t, agentWithTargets))
+	})
+
+	t.Run("Vanilla K8s with ETCD targets in the spec reports a missing CA Secret", func(t *testing.T) {
+		mockClient := &mocks.MockInstanaAgentClient{}
+		mockClient.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1.Secret"), mock.Anything).
+			Return(apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "etcd-ca"))
+
+		agentWithTargets := agent.DeepCopy()
+		agentWithTargets.Spec.K8sSensor.ETCD.Targets = []string{"https://etcd:2379/metrics"}
+		agentWithTargets.Spec.K8sSensor.ETCD.CA.SecretName = "etcd-ca"
+
+		noDiscovery := func(ctx context.Context, agent *instanav1.InstanaAgent) (*DiscoveredETCDTargets, error) {
+			return nil, nil
+		}
+
+		_, etcdMonitoring, err := CreateDeploymentContext(
+			ctx,
+			mockClient,
+			agentWithTargets,
+			false,
+			false,
+			logger,
+			noDiscovery,
+		)
+
+		require.NoError(t, err)
+		assert.False(t, etcdMonitoring.CAFound)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("OpenShift dry run neither copies nor cleans up ETCD resources", func(t *testing.T) {
+		mockClient := &mocks.MockInstanaAgentClient{}
+
+		mockClient.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1.ConfigMap"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				args.Get(2).(*corev1.ConfigMap).Data = map[string]string{"ca-bundle.crt": "test-ca-cert-data"}
+			}).
+			Return(nil)
+		mockClient.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1.Secret"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				args.Get(2).(*corev1.Secret).Data = map[string][]byte{
+					"tls.crt": []byte("test-cert-data"),
+					"tls.key": []byte("test-key-data"),
+				}
+			}).
+			Return(nil)
+
+		deploymentContext, etcdMonitoring, err := CreateDeploymentContext(ctx, mockClient, agent, true, true, logger, nil)
+
+		require.NoError(t, err)
+		assert.True(t, deploymentContext.OpenShiftETCDResourcesExist)
+		assert.Equal(t, instanav1.ETCDMonitoringSourceOpenShift, etcdMonitoring.Source)
+		mockClient.AssertNotCalled(t, "Apply", mock.Anything, mock.Anything, mock.Anything)
+
+		invalidClient := &mocks.MockInstanaAgentClient{}
+		invalidClient.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		deploymentContext, _, err = CreateDeploymentContext(ctx, invalidClient, agent, true, true, logger, nil)
+
+		require.NoError(t, err)
+		assert.False(t, deploymentContext.OpenShiftETCDResourcesExist)
+		invalidClient.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Vanilla K8s reports why discovery found no targets", func(t *testing.T) {
+		mockClient := &mocks.MockInstanaAgentClient{}
+
+		mockDiscoverETCD := func(ctx context.Context, agent *instanav1.InstanaAgent) (*DiscoveredETCDTargets, error) {
+			return &DiscoveredETCDTargets{DisabledReason: "the ETCD service etcd has no metrics port"}, nil
+		}
+
+		_, etcdMonitoring, err := CreateDeploymentContext(ctx, mockClient, agent, false, false, logger, mockDiscoverETCD)
+
+		require.NoError(t, err)
+		assert.Equal(t, disabledETCDMonitoring("the ETCD service etcd has no metrics port"), etcdMonitoring)
+	})
+
+	t.Run("OpenShift with invalid ETCD resources reports why monitoring is disabled", func(t *testing.T) {
+		mockClient := &mocks.MockInstanaAgentClient{}
+
+		mockClient.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1.ConfigMap"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				args.Get(2).(*corev1.ConfigMap).Data = map[string]string{"other": "data"}
+			}).
+			Return(nil)
+		mockClient.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1.Secret"), mock.Anything).
+			Return(nil)
+		mockClient.On("Delete", mock.Anything, mock.Anything, mock.Anything).
+			Return(apierrors.NewNotFound(schema.GroupResource{}, ""))
+
+		deploymentContext, etcdMonitoring, err := CreateDeploymentContext(ctx, mockClient, agent, true, false, logger, nil)
+
+		require.NoError(t, err)
+		assert.False(t, deploymentContext.OpenShiftETCDResourcesExist)
+		assert.Empty(t, etcdMonitoring.Source)
+		assert.Contains(t, etcdMonitoring.DisabledReason, "etcd-metrics-ca-bundle missing ca-bundle.crt key")
+	})
 }
 
-func TestGetDaemonSetBuildersReturnsFailureForZoneDaemonSetReadError(t *testing.T) {
+func TestGetZonePersistHostUniqueIDEnvVarReturnsFailureForZoneDaemonSetReadError(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, appsv1.AddToScheme(scheme))
 	require.NoError(t, corev1.AddToScheme(scheme))
@@ -355,16 +561,9 @@ func TestGetDaemonSetBuildersReturnsFailureForZoneDaemonSetReadError(t *testing.
 		},
 	}
 
-	builders, res := getDaemonSetBuilders(
-		context.Background(),
-		reconciler,
-		agent,
-		true,
-		false,
-		&mocks.MockAgentStatusManager{},
-	)
+	zoneSettings, res := reconciler.getZonePersistHostUniqueIDEnvVar(context.Background(), agent)
 
-	assert.Nil(t, builders)
+	assert.Nil(t, zoneSettings)
 	assert.True(t, res.suppliesReconcileResult())
 	_, err := res.reconcileResult()
 	assert.ErrorIs(t, err, getErr)
@@ -408,6 +607,8 @@ func TestApplyResourcesReturnsFailureAndSkipsApplyAllOnZoneDaemonSetReadError(t
 		&mocks.MockAgentStatusManager{},
 		&corev1.Secret{},
 		nil,
+		nil,
+		nil,
 		namespaces.NamespacesDetails{},
 	)
 
@@ -416,3 +617,100 @@ func TestApplyResourcesReturnsFailureAndSkipsApplyAllOnZoneDaemonSetReadError(t
 	assert.ErrorIs(t, err, getErr)
 	assert.False(t, operatorUtilsMock.applyAllCalled)
 }
+
+func TestApplyResourcesPreviewsInsteadOfApplyingWhenRequested(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	baseClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := &InstanaAgentReconciler{
+		client: instanaclient.NewInstanaAgentClient(baseClient),
+		etcdDiscoverer: &MockETCDDiscoverer{
+			ShouldSkipDiscoveryFunc: func(ctx context.Context, agent *instanav1.InstanaAgent) (bool, error) {
+				return true, nil
+			},
+		},
+	}
+
+	agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "agent",
+			Namespace:   "instana-agent",
+			Generation:  3,
+			Annotations: map[string]string{instanav1.PreviewAnnotation: "true"},
+		},
+	}
+
+	preview := []instanav1.PreviewEntry{
+		{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "agent", Action: instanav1.PreviewActionUpdate},
+	}
+	operatorUtilsMock := &mockOperatorUtils{preview: preview}
+	statusManager := &status.MockAgentStatusManager{}
+	res := reconciler.applyResources(
+		context.Background(),
+		agent,
+		false,
+		true,
+		operatorUtilsMock,
+		statusManager,
+		&corev1.Secret{},
+		nil,
+		nil,
+		nil,
+		namespaces.NamespacesDetails{},
+	)
+
+	assert.True(t, res.suppliesReconcileResult())
+	_, err := res.reconcileResult()
+	assert.NoError(t, err)
+	assert.True(t, operatorUtilsMock.previewAllCalled)
+	assert.False(t, operatorUtilsMock.applyAllCalled)
+	require.NotNil(t, statusManager.Preview)
+	assert.Equal(t, int64(3), statusManager.Preview.ObservedGeneration)
+	assert.Equal(t, preview, statusManager.Preview.Objects)
+
+	// previewing the same changes again keeps the time, so that the status is left unchanged
+	previewedAt := metav1.NewTime(time.Now().Add(-time.Hour))
+	agent.Status.Preview = &instanav1.PreviewStatus{ObservedGeneration: 3, Time: previewedAt, Objects: preview}
+	reconciler.applyResources(
+		context.Background(),
+		agent,
+		false,
+		true,
+		operatorUtilsMock,
+		statusManager,
+		&corev1.Secret{},
+		nil,
+		nil,
+		nil,
+		namespaces.NamespacesDetails{},
+	)
+	assert.Equal(t, previewedAt, statusManager.Preview.Time)
+
+	operatorUtilsMock.preview = nil
+	reconciler.applyResources(
+		context.Background(),
+		agent,
+		false,
+		true,
+		operatorUtilsMock,
+		statusManager,
+		&corev1.Secret{},
+		nil,
+		nil,
+		nil,
+		namespaces.NamespacesDetails{},
+	)
+	assert.NotEqual(t, previewedAt, statusManager.Preview.Time)
+}
+
+func TestPreviewRequested(t *testing.T) {
+	for value, expected := range map[string]bool{"true": true, "1": true, "false": false, "yes": false, "": false} {
+		agent := &instanav1.InstanaAgent{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{instanav1.PreviewAnnotation: value}},
+		}
+		assert.Equal(t, expected, previewRequested(agent), value)
+	}
+	assert.False(t, previewRequested(&instanav1.InstanaAgent{}))
+}
@@ -31,6 +31,9 @@ type DiscoveredETCDTargets struct {
 	// CAFound indicates whether the etcd-ca secret was found in the agent's namespace,
 	// which is needed for secure HTTPS connections to etcd endpoints
 	CAFound bool
+
+	// DisabledReason explains why no targets were discovered, ETCD monitoring is disabled in this case
+	DisabledReason string
 }
 
 const kubeSystemNamespace = "kube-system"
@@ -59,7 +62,9 @@ func (r *InstanaAgentReconciler) DiscoverETCDEndpoints(
 	}
 	if etcdService == nil {
 		log.Info("No ETCD service found in kube-system namespace")
-		return nil, nil
+		return &DiscoveredETCDTargets{
+			DisabledReason: "no ETCD service was found in the " + kubeSystemNamespace + " namespace",
+		}, nil
 	}
 
 	log.Info("Found etcd service", "name", etcdService.Name)
@@ -68,7 +73,9 @@ func (r *InstanaAgentReconciler) DiscoverETCDEndpoints(
 	metricsPortPtr, scheme := r.etcdDiscoverer.FindMetricsPortAndScheme(etcdService)
 	if metricsPortPtr == nil {
 		log.Info("No metrics port found in etcd service")
-		return nil, nil
+		return &DiscoveredETCDTargets{
+			DisabledReason: "the ETCD service " + etcdService.Name + " has no metrics port",
+		}, nil
 	}
 	metricsPort := *metricsPortPtr
 
@@ -84,7 +91,9 @@ func (r *InstanaAgentReconciler) DiscoverETCDEndpoints(
 	}
 	if len(targets) == 0 {
 		log.Info("No endpoints found for etcd service")
-		return nil, nil
+		return &DiscoveredETCDTargets{
+			DisabledReason: "no endpoints were found for the ETCD service " + etcdService.Name,
+		}, nil
 	}
 
 	// Step 5: Check for CA secret and return results
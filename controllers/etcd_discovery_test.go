@@ -1076,6 +1076,7 @@ func TestDiscoverETCDEndpoints(t *testing.T) {
 		caSecretExists     bool
 		expectedTargets    []string
 		expectedCAFound    bool
+		expectedReason     string
 		expectError        bool
 		expectNilResult    bool
 	}{
@@ -1094,12 +1095,13 @@ func TestDiscoverETCDEndpoints(t *testing.T) {
 			expectError:     true,
 		},
 		{
-			name:              "Should return nil when findETCDService returns nil",
+			name:              "Should return disabled reason when findETCDService returns nil",
 			shouldSkip:        false,
 			shouldSkipErr:     nil,
 			findServiceResult: nil,
 			findServiceErr:    nil,
-			expectNilResult:   true,
+			expectedReason:    "no ETCD service was found in the kube-system namespace",
+			expectNilResult:   false,
 			expectError:       false,
 		},
 		{
@@ -1112,14 +1114,15 @@ func TestDiscoverETCDEndpoints(t *testing.T) {
 			expectError:       true,
 		},
 		{
-			name:              "Should return nil when no metrics port found",
+			name:              "Should return disabled reason when no metrics port found",
 			shouldSkip:        false,
 			shouldSkipErr:     nil,
-			findServiceResult: &corev1.Service{},
+			findServiceResult: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "etcd"}},
 			findServiceErr:    nil,
 			metricsPort:       0,
 			scheme:            "",
-			expectNilResult:   true,
+			expectedReason:    "the ETCD service etcd has no metrics port",
+			expectNilResult:   false,
 			expectError:       false,
 		},
 		{
@@ -1136,16 +1139,17 @@ func TestDiscoverETCDEndpoints(t *testing.T) {
 			expectError:        true,
 		},
 		{
-			name:               "Should return nil when no targets found",
+			name:               "Should return disabled reason when no targets found",
 			shouldSkip:         false,
 			shouldSkipErr:      nil,
-			findServiceResult:  &corev1.Service{},
+			findServiceResult:  &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "etcd"}},
 			findServiceErr:     nil,
 			metricsPort:        2379,
 			scheme:             "https",
 			buildTargetsResult: []string{},
 			buildTargetsErr:    nil,
-			expectNilResult:    true,
+			expectedReason:     "no endpoints were found for the ETCD service etcd",
+			expectNilResult:    false,
 			expectError:        false,
 		},
 		{
@@ -1231,6 +1235,7 @@ func TestDiscoverETCDEndpoints(t *testing.T) {
 				assert.NotNil(t, result, "Result should not be nil")
 				assert.Equal(t, tc.expectedTargets, result.Targets, "Targets should match expected")
 				assert.Equal(t, tc.expectedCAFound, result.CAFound, "CAFound should match expected")
+				assert.Equal(t, tc.expectedReason, result.DisabledReason, "DisabledReason should match expected")
 			}
 		})
 	}
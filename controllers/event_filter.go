@@ -16,8 +16,11 @@ limitations under the License.
 package controllers
 
 import (
+	"slices"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -25,6 +28,7 @@ import (
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
 	"github.com/instana/instana-agent-operator/pkg/collections/list"
 	instanaclient "github.com/instana/instana-agent-operator/pkg/k8s/client"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
 )
 
 func wasModifiedByOther(objectNew client.Object, objectOld client.Object) bool {
@@ -44,8 +48,10 @@ func wasModifiedByOther(objectNew client.Object, objectOld client.Object) bool {
 		return true
 	}
 
+	ignoredFieldManagers := transformations.IgnoredFieldManagers(objectNew)
+
 	for _, mfe := range objectNew.GetManagedFields() {
-		if mfe.Manager == instanaclient.FieldOwnerName {
+		if mfe.Manager == instanaclient.FieldOwnerName || slices.Contains(ignoredFieldManagers, mfe.Manager) {
 			continue
 		} else if mfe.Time == nil && !list.NewDeepContainsElementChecker(objectOld.GetManagedFields()).Contains(mfe) {
 			return true
@@ -57,6 +63,11 @@ func wasModifiedByOther(objectNew client.Object, objectOld client.Object) bool {
 	return false
 }
 
+// annotationChanged reports whether the value of the annotation differs between both versions of an object
+func annotationChanged(objectNew client.Object, objectOld client.Object, annotation string) bool {
+	return objectNew.GetAnnotations()[annotation] != objectOld.GetAnnotations()[annotation]
+}
+
 // Create generic filter for all events, that removes some chattiness mainly when only the Status field has been updated.
 func filterPredicate() predicate.Predicate {
 	return predicate.Funcs{
@@ -64,6 +75,9 @@ func filterPredicate() predicate.Predicate {
 			switch createEvent.Object.(type) {
 			case *instanav1.InstanaAgent:
 				return true
+			case *corev1.Secret:
+				// A Secret referenced by the CR, created by a user after the CR
+				return metav1.GetControllerOf(createEvent.Object) == nil
 			default:
 				return false
 			}
@@ -71,7 +85,9 @@ func filterPredicate() predicate.Predicate {
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			switch e.ObjectOld.(type) {
 			case *instanav1.InstanaAgent:
-				return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+				return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
+					annotationChanged(e.ObjectNew, e.ObjectOld, instanav1.PreviewAnnotation) ||
+					annotationChanged(e.ObjectNew, e.ObjectOld, instanav1.ReconcilePausedAnnotation)
 			default:
 				return wasModifiedByOther(e.ObjectNew, e.ObjectOld)
 			}
@@ -93,6 +109,9 @@ func filterPredicateRemote() predicate.Predicate {
 			switch createEvent.Object.(type) {
 			case *instanav1.InstanaAgentRemote:
 				return true
+			case *corev1.Secret:
+				// A Secret referenced by the CR, created by a user after the CR
+				return metav1.GetControllerOf(createEvent.Object) == nil
 			default:
 				return false
 			}
@@ -100,7 +119,8 @@ func filterPredicateRemote() predicate.Predicate {
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			switch e.ObjectOld.(type) {
 			case *instanav1.InstanaAgentRemote:
-				return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+				return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
+					annotationChanged(e.ObjectNew, e.ObjectOld, instanav1.ReconcilePausedAnnotation)
 			default:
 				return wasModifiedByOther(e.ObjectNew, e.ObjectOld)
 			}
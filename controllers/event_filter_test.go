@@ -0,0 +1,58 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	instanaclient "github.com/instana/instana-agent-operator/pkg/k8s/client"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
+)
+
+func TestWasModifiedByOtherSkipsIgnoredFieldManagers(t *testing.T) {
+	appliedAt := metav1.NewTime(time.Now().Add(-time.Minute))
+	scaledAt := metav1.NewTime(time.Now())
+
+	objectOld := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: instanaclient.FieldOwnerName, Time: &appliedAt}},
+		},
+	}
+	objectNew := objectOld.DeepCopy()
+	objectNew.ManagedFields = append(
+		objectNew.ManagedFields,
+		metav1.ManagedFieldsEntry{Manager: "kube-controller-manager", Time: &scaledAt, Subresource: "scale"},
+	)
+
+	assert.True(t, wasModifiedByOther(objectNew, objectOld))
+
+	objectNew.Annotations = map[string]string{
+		transformations.IgnoredFieldManagersAnnotation: "Reloader,kube-controller-manager",
+	}
+	assert.False(t, wasModifiedByOther(objectNew, objectOld))
+
+	objectNew.ManagedFields = append(
+		objectNew.ManagedFields,
+		metav1.ManagedFieldsEntry{Manager: "kubectl-edit", Time: &scaledAt},
+	)
+	assert.True(t, wasModifiedByOther(objectNew, objectOld))
+}
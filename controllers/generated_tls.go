@@ -0,0 +1,146 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/certgen"
+	generatedtls "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/agent/generated-tls"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/helpers"
+)
+
+// generatedTLSRotationCheckInterval is how often the certificates generated for an agent are checked for rotation,
+// which is due months before they expire
+const generatedTLSRotationCheckInterval = 24 * time.Hour
+
+// generateTLS returns the CA and the certificate of the agent if spec.agent.tls.autoGenerate is set. The ones stored
+// in the cluster are kept until they are due for rotation. A rotated CA is still trusted, and still signs the
+// certificate of the agent, until the overlap elapses, so that clients can pick up the new CA in the meantime. A new
+// certificate is signed whenever the signing CA changes or the names of the agent Services change.
+func (r *InstanaAgentReconciler) generateTLS(
+	ctx context.Context,
+	agent *instanav1.InstanaAgent,
+	now time.Time,
+) (*certgen.Certificates, error) {
+	if !agent.Spec.Agent.TlsSpec.AutoGenerate {
+		return nil, nil
+	}
+
+	h := helpers.NewHelpers(agent)
+
+	ca, err := r.getGeneratedKeyPair(ctx, agent.Namespace, h.TLSCASecretName(), corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	if err != nil {
+		return nil, err
+	}
+	previousCA, err := r.getGeneratedKeyPair(
+		ctx,
+		agent.Namespace,
+		h.TLSCASecretName(),
+		generatedtls.PreviousCACertKey,
+		generatedtls.PreviousCAKeyKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if ca == nil || ca.NeedsRotation(now) {
+		previousCA = ca
+		if ca, err = certgen.GenerateCA(agent.Name+"."+agent.Namespace+" CA", now); err != nil {
+			return nil, err
+		}
+	}
+	if previousCA != nil && !now.Before(ca.OverlapEndsAt()) {
+		previousCA = nil
+	}
+	certificates := &certgen.Certificates{CA: ca, PreviousCA: previousCA}
+
+	serving, err := r.getGeneratedKeyPair(
+		ctx,
+		agent.Namespace,
+		h.TLSSecretName(),
+		corev1.TLSCertKey,
+		corev1.TLSPrivateKeyKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if serving == nil || serving.NeedsRotation(now) || !serving.Covers(certificates.SigningCA(), h.ServiceDNSNames()) {
+		if serving, err = certgen.GenerateServing(certificates.SigningCA(), h.ServiceDNSNames(), now); err != nil {
+			return nil, err
+		}
+	}
+	certificates.Serving = serving
+
+	return certificates, nil
+}
+
+// getGeneratedKeyPair reads a key pair generated earlier from the given keys of the Secret with the given name. It
+// returns nil if there is no such key pair or if its content can no longer be used, e.g. after it was edited, so that
+// a new one is generated.
+func (r *InstanaAgentReconciler) getGeneratedKeyPair(
+	ctx context.Context,
+	namespace string,
+	name string,
+	certKey string,
+	keyKey string,
+) (*certgen.KeyPair, error) {
+	secret := &corev1.Secret{}
+	err := r.client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, secret)
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	certPEM, keyPEM := secret.Data[certKey], secret.Data[keyKey]
+	if len(certPEM) == 0 && len(keyPEM) == 0 {
+		return nil, nil
+	}
+
+	keyPair, err := certgen.Parse(certPEM, keyPEM)
+	if err != nil {
+		logf.FromContext(ctx).Error(
+			err,
+			"replacing the generated TLS key pair that can no longer be used",
+			"secret", name,
+			"key", certKey,
+		)
+		return nil, nil
+	}
+	return keyPair, nil
+}
+
+// generatedTLSStatus reports when the generated certificates expire
+func generatedTLSStatus(certificates *certgen.Certificates) *instanav1.TLSStatus {
+	if certificates == nil {
+		return nil
+	}
+
+	return &instanav1.TLSStatus{
+		CANotAfter:          metav1.NewTime(certificates.CA.Certificate.NotAfter),
+		CertificateNotAfter: metav1.NewTime(certificates.Serving.Certificate.NotAfter),
+	}
+}
@@ -0,0 +1,134 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/certgen"
+	instanaclient "github.com/instana/instana-agent-operator/pkg/k8s/client"
+	generatedtls "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/agent/generated-tls"
+)
+
+// storeGeneratedTLS writes the generated certificates to the Secrets they are applied to
+func storeGeneratedTLS(t *testing.T, k8sClient client.Client, agent *instanav1.InstanaAgent, c *certgen.Certificates) {
+	for _, obj := range []client.Object{
+		generatedtls.NewSecretBuilder(agent, c).Build().Get(),
+		generatedtls.NewCASecretBuilder(agent, c).Build().Get(),
+	} {
+		require.NoError(t, client.IgnoreNotFound(k8sClient.Delete(context.Background(), obj)))
+		require.NoError(t, k8sClient.Create(context.Background(), obj))
+	}
+}
+
+func TestGenerateTLS(t *testing.T) {
+	assertions := require.New(t)
+
+	agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", Namespace: "instana-agent"},
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{TlsSpec: instanav1.TlsSpec{AutoGenerate: true}},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().Build()
+	r := &InstanaAgentReconciler{client: instanaclient.NewInstanaAgentClient(k8sClient)}
+	now := time.Now()
+
+	generated, err := r.generateTLS(context.Background(), agent, now)
+	assertions.NoError(err)
+	assertions.True(generated.Serving.Covers(generated.CA, []string{
+		"instana-agent",
+		"instana-agent.instana-agent",
+		"instana-agent.instana-agent.svc",
+		"instana-agent-headless",
+		"instana-agent-headless.instana-agent",
+		"instana-agent-headless.instana-agent.svc",
+	}))
+	assertions.Equal(
+		&instanav1.TLSStatus{
+			CANotAfter:          metav1.NewTime(generated.CA.Certificate.NotAfter),
+			CertificateNotAfter: metav1.NewTime(generated.Serving.Certificate.NotAfter),
+		},
+		generatedTLSStatus(generated),
+	)
+	storeGeneratedTLS(t, k8sClient, agent, generated)
+
+	// the stored certificates are kept until they are due for rotation
+	kept, err := r.generateTLS(context.Background(), agent, now.Add(time.Hour))
+	assertions.NoError(err)
+	assertions.Equal(generated.CA.CertPEM, kept.CA.CertPEM)
+	assertions.Equal(generated.Serving.CertPEM, kept.Serving.CertPEM)
+
+	// the certificate of the agent is rotated with the same CA
+	servingRotated, err := r.generateTLS(context.Background(), agent, generated.Serving.RenewAt())
+	assertions.NoError(err)
+	assertions.Equal(generated.CA.CertPEM, servingRotated.CA.CertPEM)
+	assertions.NotEqual(generated.Serving.CertPEM, servingRotated.Serving.CertPEM)
+	assertions.True(servingRotated.Serving.Covers(generated.CA, servingRotated.Serving.Certificate.DNSNames))
+
+	// a rotated CA is still trusted and still signs the certificate of the agent until the overlap elapses
+	caRotated, err := r.generateTLS(context.Background(), agent, generated.CA.RenewAt())
+	assertions.NoError(err)
+	assertions.NotEqual(generated.CA.CertPEM, caRotated.CA.CertPEM)
+	assertions.Equal(generated.CA.CertPEM, caRotated.PreviousCA.CertPEM)
+	assertions.True(caRotated.Serving.Covers(generated.CA, caRotated.Serving.Certificate.DNSNames))
+	storeGeneratedTLS(t, k8sClient, agent, caRotated)
+
+	overlapping, err := r.generateTLS(context.Background(), agent, caRotated.CA.OverlapEndsAt().Add(-time.Second))
+	assertions.NoError(err)
+	assertions.Equal(caRotated.CA.CertPEM, overlapping.CA.CertPEM)
+	assertions.Equal(generated.CA.CertPEM, overlapping.PreviousCA.CertPEM)
+	assertions.Equal(caRotated.Serving.CertPEM, overlapping.Serving.CertPEM)
+
+	// once the overlap elapsed, the new CA signs a new certificate of the agent
+	overlapElapsed, err := r.generateTLS(context.Background(), agent, caRotated.CA.OverlapEndsAt())
+	assertions.NoError(err)
+	assertions.Equal(caRotated.CA.CertPEM, overlapElapsed.CA.CertPEM)
+	assertions.Nil(overlapElapsed.PreviousCA)
+	assertions.True(overlapElapsed.Serving.Covers(caRotated.CA, overlapElapsed.Serving.Certificate.DNSNames))
+	storeGeneratedTLS(t, k8sClient, agent, generated)
+
+	// an edited Secret is replaced
+	assertions.NoError(
+		k8sClient.Update(
+			context.Background(),
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "instana-agent-tls", Namespace: "instana-agent"},
+				Data:       map[string][]byte{corev1.TLSCertKey: []byte("edited")},
+			},
+		),
+	)
+	replaced, err := r.generateTLS(context.Background(), agent, now.Add(time.Hour))
+	assertions.NoError(err)
+	assertions.Equal(generated.CA.CertPEM, replaced.CA.CertPEM)
+	assertions.NotEqual(generated.Serving.CertPEM, replaced.Serving.CertPEM)
+
+	agent.Spec.Agent.TlsSpec.AutoGenerate = false
+	disabled, err := r.generateTLS(context.Background(), agent, now)
+	assertions.NoError(err)
+	assertions.Nil(disabled)
+	assertions.Nil(generatedTLSStatus(disabled))
+}
@@ -40,6 +40,7 @@ import (
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
 	instanaclient "github.com/instana/instana-agent-operator/pkg/k8s/client"
 	"github.com/instana/instana-agent-operator/pkg/k8s/operator/lifecycle"
+	"github.com/instana/instana-agent-operator/pkg/k8s/operator/metrics"
 	"github.com/instana/instana-agent-operator/pkg/k8s/operator/operator_utils"
 	"github.com/instana/instana-agent-operator/pkg/k8s/operator/status"
 	"github.com/instana/instana-agent-operator/pkg/multierror"
@@ -88,6 +89,7 @@ func Add(mgr manager.Manager) error {
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Secret{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(agentsForSecret(mgr.GetClient()))).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&corev1.Service{}).
 		Owns(&v1.PodDisruptionBudget{}).
@@ -143,7 +145,25 @@ func (r *InstanaAgentReconciler) reconcile(
 	ctx = logr.NewContext(ctx, log)
 	log.Info("reconciling Agent CR")
 
-	agent.Default()
+	if applyDefaults(agent) {
+		log.V(1).Info("applied defaults in memory, enable the defaulting webhook to persist them on the CR")
+	}
+
+	configurationErrs := agent.Spec.Validate()
+	statusManager.SetConfigurationValidation(configurationErrs)
+
+	// A paused CR is neither written to nor are its dependents looked up, only deleting it still cleans them up
+	if reconcilePaused(agent) && agent.DeletionTimestamp.IsZero() {
+		statusManager.SetReconcilePaused(true)
+		return pauseReconcile(
+			log,
+			NewAgentBuilders(
+				agent,
+				statusManager,
+				AgentBuilderOptions{KeysSecret: &corev1.Secret{}, K8SensorBackends: NewK8SensorBackends(agent)},
+			),
+		)
+	}
 
 	// Log if k8sensor is disabled
 	if !pointer.DerefOrDefault(agent.Spec.K8sSensor.DeploymentSpec.Enabled.Enabled, true) {
@@ -192,8 +212,34 @@ func (r *InstanaAgentReconciler) reconcile(
 			log.Error(err, "unable to get KeysSecret-field")
 		}
 	}
+	if keysSecretErrs := agent.Spec.Agent.ValidateKeysSecret(keysSecret); len(keysSecretErrs) > 0 {
+		configurationErrs = append(configurationErrs, keysSecretErrs...)
+		statusManager.SetConfigurationValidation(configurationErrs)
+	}
+	// Referenced credentials are resolved into the in-memory spec only, which is never written back to the CR
+	credentialsVersions, secretRefErrs := resolveSecretRefs(
+		ctx,
+		r.client,
+		agent.Namespace,
+		agent.Spec.UseSecretMounts,
+		&agent.Spec.Agent,
+	)
+	if len(secretRefErrs) > 0 {
+		configurationErrs = append(configurationErrs, secretRefErrs...)
+		statusManager.SetConfigurationValidation(configurationErrs)
+	}
+	if len(configurationErrs) > 0 {
+		log.Info("spec contains invalid or conflicting settings", "errors", configurationErrs.ToAggregate().Error())
+	}
+
+	tlsSecret, err := r.getTLSSecret(ctx, agent)
+	if err != nil {
+		log.Error(err, "unable to get the TLS secret issued by cert-manager")
+		return reconcileFailure(err)
+	}
 
-	k8SensorBackends := r.getK8SensorBackends(agent)
+	k8SensorBackends := NewK8SensorBackends(agent)
+	metrics.RecordBackends(agent, len(k8SensorBackends))
 
 	namespacesList, err := r.client.GetNamespacesWithLabels(ctx)
 	if err != nil {
@@ -208,6 +254,8 @@ func (r *InstanaAgentReconciler) reconcile(
 		operatorUtils,
 		statusManager,
 		keysSecret,
+		tlsSecret,
+		credentialsVersions,
 		k8SensorBackends,
 		namespacesList,
 	); applyResourcesRes.suppliesReconcileResult() {
@@ -216,7 +264,11 @@ func (r *InstanaAgentReconciler) reconcile(
 
 	log.Info("successfully finished reconcile on agent CR")
 
-	return reconcileSuccess(ctrl.Result{})
+	res := ctrl.Result{}
+	if agent.Spec.Agent.TlsSpec.AutoGenerate {
+		res.RequeueAfter = generatedTLSRotationCheckInterval
+	}
+	return reconcileSuccess(res)
 }
 
 // +kubebuilder:rbac:groups=instana.io,resources=agents,verbs=get;list;watch;create;update;patch;delete
@@ -225,8 +277,10 @@ func (r *InstanaAgentReconciler) reconcile(
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=get;list;watch;create;update;patch;delete;bind
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete;bind
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=update
 // +kubebuilder:rbac:groups=instana.io,resources=agents/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=instana.io,resources=agents/finalizers,verbs=update
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 
 // adding role property required to manage instana-agent-k8sensor ClusterRole
 // +kubebuilder:rbac:urls=/version;/healthz;/metrics;/metrics/*;/metrics/cadvisor;/stats/summary,verbs=get
@@ -258,6 +312,7 @@ func (r *InstanaAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			// Owned objects are automatically garbage collected.
 			// Return and don't requeue
 			logger.Info("InstanaAgent resource not found. Ignoring since object must be deleted")
+			metrics.Forget(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -271,6 +326,9 @@ func (r *InstanaAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			errBuilder := multierror.NewMultiErrorBuilder(reconcileErr, err)
 			reconcileErr = errBuilder.Build()
 		}
+		if reconcileErr == nil && agent.DeletionTimestamp == nil {
+			metrics.RecordReconcileSucceeded(agent)
+		}
 	}()
 
 	return r.reconcile(ctx, req, statusManager).reconcileResult()
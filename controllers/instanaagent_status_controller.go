@@ -0,0 +1,216 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"net"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	instanaclient "github.com/instana/instana-agent-operator/pkg/k8s/client"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/constants"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/helpers"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
+	"github.com/instana/instana-agent-operator/pkg/k8s/operator/status"
+	"github.com/instana/instana-agent-operator/pkg/recovery"
+)
+
+// availabilityComponents are the components whose rollout and readiness are reported in the status of the agent CR
+var availabilityComponents = []string{constants.ComponentInstanaAgent, constants.ComponentK8Sensor}
+
+// AddStatus will create a new controller keeping the availability reported in the status of Instana Agents up to
+// date as their pods, DaemonSets and Deployments roll out, and add this to the Manager. It never applies anything.
+func AddStatus(mgr manager.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("instanaagent-status").
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(agentForWorkload)).
+		Watches(&appsv1.DaemonSet{}, handler.EnqueueRequestsFromMapFunc(agentForWorkload)).
+		Watches(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(agentForWorkload)).
+		WithEventFilter(availabilityChangedPredicate()).
+		Complete(
+			NewInstanaAgentStatusReconciler(
+				mgr.GetClient(),
+				mgr.GetEventRecorderFor("agent-status-controller"),
+			),
+		)
+}
+
+// NewInstanaAgentStatusReconciler initializes a new InstanaAgentStatusReconciler instance
+func NewInstanaAgentStatusReconciler(
+	client client.Client,
+	recorder record.EventRecorder,
+) *InstanaAgentStatusReconciler {
+	return &InstanaAgentStatusReconciler{
+		client:   instanaclient.NewInstanaAgentClient(client),
+		recorder: recorder,
+	}
+}
+
+// InstanaAgentStatusReconciler updates the availability in the status of an Instana Agent without reconciling its
+// spec, which is left to the InstanaAgentReconciler
+type InstanaAgentStatusReconciler struct {
+	client   instanaclient.InstanaAgentClient
+	recorder record.EventRecorder
+}
+
+// agentForWorkload maps agent and k8sensor pods, DaemonSets and Deployments to the Instana Agent they belong to
+func agentForWorkload(_ context.Context, obj client.Object) []ctrl.Request {
+	objLabels := obj.GetLabels()
+	if objLabels[transformations.InstanceLabel] == "" ||
+		!slices.Contains(availabilityComponents, objLabels[transformations.ComponentLabel]) {
+		return nil
+	}
+
+	return []ctrl.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      objLabels[transformations.InstanceLabel],
+				Namespace: obj.GetNamespace(),
+			},
+		},
+	}
+}
+
+// availabilityChangedPredicate only passes on updates changing the status of a pod, DaemonSet or Deployment, since
+// nothing else affects their availability
+func availabilityChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			switch objectOld := e.ObjectOld.(type) {
+			case *corev1.Pod:
+				return !equality.Semantic.DeepEqual(objectOld.Status, e.ObjectNew.(*corev1.Pod).Status)
+			case *appsv1.DaemonSet:
+				return !equality.Semantic.DeepEqual(objectOld.Status, e.ObjectNew.(*appsv1.DaemonSet).Status)
+			case *appsv1.Deployment:
+				return !equality.Semantic.DeepEqual(objectOld.Status, e.ObjectNew.(*appsv1.Deployment).Status)
+			default:
+				return false
+			}
+		},
+	}
+}
+
+func (r *InstanaAgentStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
+	res ctrl.Result,
+	reconcileErr error,
+) {
+	defer recovery.Catch(&reconcileErr)
+
+	logger := logf.FromContext(ctx).WithName("agent-status-controller")
+	ctx = logf.IntoContext(ctx, logger)
+
+	agent := &instanav1.InstanaAgent{}
+	if err := r.client.Get(ctx, req.NamespacedName, agent); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Availability of an agent that is being deleted is no longer of interest
+	if !agent.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	statusManager := status.NewAgentStatusManager(r.client, r.recorder)
+	statusManager.SetAgentOld(agent)
+
+	if err := r.registerDependents(ctx, agent, statusManager); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, statusManager.UpdateAvailability(ctx)
+}
+
+// registerDependents records the agent DaemonSets and k8sensor Deployments currently controlled by the agent CR
+func (r *InstanaAgentStatusReconciler) registerDependents(
+	ctx context.Context,
+	agent *instanav1.InstanaAgent,
+	statusManager status.AgentStatusManager,
+) error {
+	var daemonsets appsv1.DaemonSetList
+	if err := r.client.List(
+		ctx,
+		&daemonsets,
+		client.InNamespace(agent.Namespace),
+		client.MatchingLabels{
+			transformations.InstanceLabel:  agent.Name,
+			transformations.ComponentLabel: constants.ComponentInstanaAgent,
+		},
+	); err != nil {
+		return err
+	}
+
+	for _, ds := range daemonsets.Items {
+		if metav1.IsControlledBy(&ds, agent) {
+			statusManager.AddAgentDaemonset(client.ObjectKeyFromObject(&ds))
+		}
+	}
+
+	var deployments appsv1.DeploymentList
+	if err := r.client.List(
+		ctx,
+		&deployments,
+		client.InNamespace(agent.Namespace),
+		client.MatchingLabels{
+			transformations.InstanceLabel:  agent.Name,
+			transformations.ComponentLabel: constants.ComponentK8Sensor,
+		},
+	); err != nil {
+		return err
+	}
+
+	deploymentsByName := make(map[string]*appsv1.Deployment, len(deployments.Items))
+	for i := range deployments.Items {
+		if metav1.IsControlledBy(&deployments.Items[i], agent) {
+			deploymentsByName[deployments.Items[i].Name] = &deployments.Items[i]
+		}
+	}
+
+	// The endpoints are reported as configured, so they are taken from the spec with the defaults applied and the
+	// deployments are registered in the order of the backends
+	defaultedAgent := agent.DeepCopy()
+	applyDefaults(defaultedAgent)
+
+	k8SensorResourcesName := helpers.NewHelpers(defaultedAgent).K8sSensorResourcesName()
+	for _, backend := range NewK8SensorBackends(defaultedAgent) {
+		if deployment, ok := deploymentsByName[k8SensorResourcesName+backend.ResourceSuffix]; ok {
+			statusManager.AddK8sSensorDeployment(
+				client.ObjectKeyFromObject(deployment),
+				net.JoinHostPort(backend.EndpointHost, backend.EndpointPort),
+			)
+		}
+	}
+
+	return nil
+}
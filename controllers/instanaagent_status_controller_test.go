@@ -0,0 +1,219 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/constants"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
+	"github.com/instana/instana-agent-operator/pkg/k8s/operator/status"
+)
+
+func workloadLabels(instance string, component string) map[string]string {
+	return map[string]string{
+		transformations.InstanceLabel:  instance,
+		transformations.ComponentLabel: component,
+	}
+}
+
+func TestAgentForWorkload(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		obj      client.Object
+		expected []ctrl.Request
+	}{
+		{
+			name: "agent pod",
+			obj: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "instana-agent",
+				Labels:    workloadLabels("instana-agent", constants.ComponentInstanaAgent),
+			}},
+			expected: []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: "instana-agent", Name: "instana-agent"}}},
+		},
+		{
+			name: "k8sensor deployment",
+			obj: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "instana-agent",
+				Labels:    workloadLabels("instana-agent", constants.ComponentK8Sensor),
+			}},
+			expected: []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: "instana-agent", Name: "instana-agent"}}},
+		},
+		{
+			name: "remote agent deployment",
+			obj: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "instana-agent",
+				Labels:    workloadLabels("instana-agent", constants.ComponentInstanaAgentRemote),
+			}},
+		},
+		{
+			name: "pod without instance",
+			obj: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "instana-agent",
+				Labels:    map[string]string{transformations.ComponentLabel: constants.ComponentInstanaAgent},
+			}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, agentForWorkload(context.Background(), test.obj))
+		})
+	}
+}
+
+func TestAvailabilityChangedPredicate(t *testing.T) {
+	assertions := assert.New(t)
+	p := availabilityChangedPredicate()
+
+	podOld := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod"}}
+
+	relabeled := podOld.DeepCopy()
+	relabeled.Labels = map[string]string{"foo": "bar"}
+	assertions.False(p.Update(event.UpdateEvent{ObjectOld: podOld, ObjectNew: relabeled}))
+
+	ready := podOld.DeepCopy()
+	ready.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	assertions.True(p.Update(event.UpdateEvent{ObjectOld: podOld, ObjectNew: ready}))
+
+	dsOld := &appsv1.DaemonSet{}
+	dsNew := dsOld.DeepCopy()
+	dsNew.Status.NumberReady = 1
+	assertions.True(p.Update(event.UpdateEvent{ObjectOld: dsOld, ObjectNew: dsNew}))
+
+	assertions.True(p.Create(event.CreateEvent{Object: podOld}))
+	assertions.True(p.Delete(event.DeleteEvent{Object: podOld}))
+}
+
+func TestInstanaAgentStatusReconcilerUpdatesAvailabilityWithoutApplying(t *testing.T) {
+	assertions := require.New(t)
+
+	scheme := runtime.NewScheme()
+	assertions.NoError(appsv1.AddToScheme(scheme))
+	assertions.NoError(corev1.AddToScheme(scheme))
+	assertions.NoError(instanav1.AddToScheme(scheme))
+
+	agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", Namespace: "instana-agent", UID: "agent-uid"},
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{EndpointHost: "ingress.instana.io", EndpointPort: "443"},
+		},
+	}
+	controlledByAgent := []metav1.OwnerReference{
+		*metav1.NewControllerRef(agent, instanav1.GroupVersion.WithKind("InstanaAgent")),
+	}
+
+	daemonset := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "instana-agent",
+			Namespace:       "instana-agent",
+			Labels:          workloadLabels("instana-agent", constants.ComponentInstanaAgent),
+			OwnerReferences: controlledByAgent,
+		},
+		Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, NumberAvailable: 1, UpdatedNumberScheduled: 2},
+	}
+	foreignDaemonset := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foreign",
+			Namespace: "instana-agent",
+			Labels:    workloadLabels("instana-agent", constants.ComponentInstanaAgent),
+		},
+	}
+	k8sensor := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "instana-agent-k8sensor",
+			Namespace:       "instana-agent",
+			Labels:          workloadLabels("instana-agent", constants.ComponentK8Sensor),
+			OwnerReferences: controlledByAgent,
+		},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas: 3,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+				{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "NewReplicaSetAvailable"},
+			},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(agent, daemonset, foreignDaemonset, k8sensor).
+		WithStatusSubresource(&instanav1.InstanaAgent{}).
+		Build()
+
+	statusManager := &status.MockAgentStatusManager{}
+	reconciler := NewInstanaAgentStatusReconciler(k8sClient, record.NewFakeRecorder(10))
+	assertions.NoError(reconciler.registerDependents(context.Background(), agent, statusManager))
+	assertions.Equal([]client.ObjectKey{client.ObjectKeyFromObject(daemonset)}, statusManager.AgentDaemonsets)
+	assertions.Equal(
+		map[string]client.ObjectKey{"ingress.instana.io:443": client.ObjectKeyFromObject(k8sensor)},
+		statusManager.K8sSensorDeployments,
+	)
+
+	_, err := reconciler.Reconcile(
+		context.Background(),
+		ctrl.Request{NamespacedName: client.ObjectKeyFromObject(agent)},
+	)
+	assertions.NoError(err)
+
+	var updated instanav1.InstanaAgent
+	assertions.NoError(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(agent), &updated))
+
+	allAgentsAvailable := meta.FindStatusCondition(updated.Status.Conditions, status.ConditionTypeAllAgentsAvailable)
+	assertions.NotNil(allAgentsAvailable)
+	assertions.Equal(metav1.ConditionFalse, allAgentsAvailable.Status)
+
+	allK8sSensorsAvailable := meta.FindStatusCondition(
+		updated.Status.Conditions,
+		status.CondtionTypeAllK8sSensorsAvailable,
+	)
+	assertions.NotNil(allK8sSensorsAvailable)
+	assertions.Equal(metav1.ConditionTrue, allK8sSensorsAvailable.Status)
+	assertions.Equal(
+		[]instanav1.K8sSensorStatus{
+			{
+				Endpoint:   "ingress.instana.io:443",
+				Deployment: instanav1.ResourceInfo{Name: "instana-agent-k8sensor"},
+				Desired:    1,
+				Ready:      3,
+			},
+		},
+		updated.Status.K8sSensors,
+	)
+
+	// Only the status is reported, the outcome of the last reconcile is left to the full reconcile
+	assertions.Nil(meta.FindStatusCondition(updated.Status.Conditions, status.ConditionTypeReconcileSucceeded))
+	assertions.Nil(updated.Status.ObservedGeneration)
+
+	var configMaps corev1.ConfigMapList
+	assertions.NoError(k8sClient.List(context.Background(), &configMaps))
+	assertions.Empty(configMaps.Items)
+}
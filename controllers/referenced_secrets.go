@@ -0,0 +1,141 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/helpers"
+	"github.com/instana/instana-agent-operator/pkg/pointer"
+)
+
+// agentsForSecret maps a Secret to the Instana Agents referencing it in spec.agent.keysSecret or in a credential secret
+// ref, or issuing their certificate through cert-manager. These Secrets are managed by users or by cert-manager, so
+// they are not owned by the agents and would otherwise never trigger a reconcile when rotated.
+func agentsForSecret(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, secret client.Object) []ctrl.Request {
+		var agents instanav1.InstanaAgentList
+		if err := c.List(ctx, &agents, client.InNamespace(secret.GetNamespace())); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to list InstanaAgent resources", "secret", secret.GetName())
+			return nil
+		}
+
+		var requests []ctrl.Request
+		for _, agent := range agents.Items {
+			if agent.Spec.Agent.ReferencesSecret(secret.GetName()) || issuesTLSSecret(&agent, secret.GetName()) {
+				requests = append(
+					requests,
+					ctrl.Request{NamespacedName: types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}},
+				)
+			}
+		}
+		return requests
+	}
+}
+
+// issuesTLSSecret reports whether cert-manager issues the certificate of the agent into the Secret with the given name
+func issuesTLSSecret(agent *instanav1.InstanaAgent, name string) bool {
+	return agent.Spec.Agent.TlsSpec.CertManager != nil && helpers.NewHelpers(agent).TLSSecretName() == name
+}
+
+// remoteAgentsForSecret maps a Secret to the Instana Agent Remotes referencing it in spec.agent.keysSecret or in a
+// credential secret ref
+func remoteAgentsForSecret(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, secret client.Object) []ctrl.Request {
+		var agents instanav1.InstanaAgentRemoteList
+		if err := c.List(ctx, &agents, client.InNamespace(secret.GetNamespace())); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to list InstanaAgentRemote resources", "secret", secret.GetName())
+			return nil
+		}
+
+		var requests []ctrl.Request
+		for _, agent := range agents.Items {
+			if agent.Spec.Agent.ReferencesSecret(secret.GetName()) {
+				requests = append(
+					requests,
+					ctrl.Request{NamespacedName: types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}},
+				)
+			}
+		}
+		return requests
+	}
+}
+
+// resolveSecretRefs sets the credentials the agent spec references in Secrets in the namespace of the CR and returns
+// the resourceVersion of each Secret found, so that the agents can be rolled out again when they change. Without
+// secret mounts the credentials would be rendered into plain environment variables, so they are left unresolved, the
+// validation of the spec reports the references as forbidden in that case.
+func resolveSecretRefs(
+	ctx context.Context,
+	c client.Reader,
+	namespace string,
+	useSecretMounts *bool,
+	agent *instanav1.BaseAgentSpec,
+) (map[string]string, field.ErrorList) {
+	if !pointer.DerefOrDefault(useSecretMounts, true) {
+		return nil, nil
+	}
+
+	credentialsVersions := map[string]string{}
+	errs := agent.ResolveSecretRefs(
+		func(name string) (*corev1.Secret, error) {
+			secret := &corev1.Secret{}
+			if err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, secret); err != nil {
+				return nil, err
+			}
+			credentialsVersions[name] = secret.ResourceVersion
+			return secret, nil
+		},
+	)
+	return credentialsVersions, errs
+}
+
+// getTLSSecret returns the Secret cert-manager issues the certificate of the agent into, or nil if tls.certManager is
+// not set or the certificate has not been issued yet
+func (r *InstanaAgentReconciler) getTLSSecret(
+	ctx context.Context,
+	agent *instanav1.InstanaAgent,
+) (*corev1.Secret, error) {
+	if agent.Spec.Agent.TlsSpec.CertManager == nil {
+		return nil, nil
+	}
+
+	tlsSecret := &corev1.Secret{}
+	err := r.client.Get(
+		ctx,
+		client.ObjectKey{Name: helpers.NewHelpers(agent).TLSSecretName(), Namespace: agent.Namespace},
+		tlsSecret,
+	)
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	default:
+		return tlsSecret, nil
+	}
+}
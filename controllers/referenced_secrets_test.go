@@ -0,0 +1,162 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/pointer"
+)
+
+func TestAgentsForSecret(t *testing.T) {
+	assertions := require.New(t)
+
+	scheme := runtime.NewScheme()
+	assertions.NoError(instanav1.AddToScheme(scheme))
+
+	agentWithKeysSecret := func(namespace string, name string, keysSecret string) *instanav1.InstanaAgent {
+		return &instanav1.InstanaAgent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       instanav1.InstanaAgentSpec{Agent: instanav1.BaseAgentSpec{KeysSecret: keysSecret}},
+		}
+	}
+	remoteWithProxyPasswordRef := &instanav1.InstanaAgentRemote{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "instana-agent"},
+		Spec: instanav1.InstanaAgentRemoteSpec{
+			Agent: instanav1.BaseAgentSpec{
+				Key: "key",
+				ProxyPasswordSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "keys"},
+					Key:                  "proxyPassword",
+				},
+			},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			agentWithKeysSecret("instana-agent", "instana-agent", "keys"),
+			agentWithKeysSecret("instana-agent", "inline-keys", ""),
+			agentWithKeysSecret("other", "instana-agent", "keys"),
+			remoteWithProxyPasswordRef,
+			&instanav1.InstanaAgent{
+				ObjectMeta: metav1.ObjectMeta{Name: "cert-manager", Namespace: "instana-agent"},
+				Spec: instanav1.InstanaAgentSpec{
+					Agent: instanav1.BaseAgentSpec{
+						TlsSpec: instanav1.TlsSpec{
+							CertManager: &instanav1.CertManagerSpec{
+								IssuerRef: instanav1.CertManagerIssuerRef{Name: "issuer"},
+							},
+						},
+					},
+				},
+			},
+		).
+		Build()
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "instana-agent"}}
+
+	assertions.Equal(
+		[]ctrl.Request{{NamespacedName: types.NamespacedName{Name: "instana-agent", Namespace: "instana-agent"}}},
+		agentsForSecret(k8sClient)(context.Background(), secret),
+	)
+	assertions.Equal(
+		[]ctrl.Request{{NamespacedName: types.NamespacedName{Name: "remote", Namespace: "instana-agent"}}},
+		remoteAgentsForSecret(k8sClient)(context.Background(), secret),
+	)
+
+	issuedSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cert-manager-tls", Namespace: "instana-agent"}}
+	assertions.Equal(
+		[]ctrl.Request{{NamespacedName: types.NamespacedName{Name: "cert-manager", Namespace: "instana-agent"}}},
+		agentsForSecret(k8sClient)(context.Background(), issuedSecret),
+	)
+}
+
+func TestResolveSecretRefs(t *testing.T) {
+	assertions := require.New(t)
+
+	k8sClient := fake.NewClientBuilder().
+		WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "proxy", Namespace: "instana-agent"},
+				Data:       map[string][]byte{"password": []byte("proxy-password")},
+			},
+		).
+		Build()
+
+	agent := instanav1.BaseAgentSpec{
+		ProxyPasswordSecretRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "proxy"},
+			Key:                  "password",
+		},
+	}
+	credentialsVersions, errs := resolveSecretRefs(context.Background(), k8sClient, "instana-agent", nil, &agent)
+	assertions.Empty(errs)
+	assertions.Equal("proxy-password", agent.ProxyPassword)
+	assertions.Equal(map[string]string{"proxy": "999"}, credentialsVersions)
+
+	credentialsVersions, errs = resolveSecretRefs(context.Background(), k8sClient, "other", nil, &agent)
+	assertions.Len(errs, 1)
+	assertions.Empty(credentialsVersions)
+
+	unresolved := instanav1.BaseAgentSpec{ProxyPasswordSecretRef: agent.ProxyPasswordSecretRef}
+	credentialsVersions, errs = resolveSecretRefs(
+		context.Background(),
+		k8sClient,
+		"instana-agent",
+		pointer.To(false),
+		&unresolved,
+	)
+	assertions.Empty(errs)
+	assertions.Nil(credentialsVersions)
+	assertions.Empty(unresolved.ProxyPassword)
+}
+
+func TestFilterPredicatePassesCreatedUserSecrets(t *testing.T) {
+	assertions := assert.New(t)
+
+	agent := &instanav1.InstanaAgent{ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", UID: "agent-uid"}}
+	userSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "keys"}}
+	ownedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "instana-agent",
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(agent, instanav1.GroupVersion.WithKind("InstanaAgent")),
+			},
+		},
+	}
+
+	for _, p := range []interface {
+		Create(event.CreateEvent) bool
+	}{filterPredicate(), filterPredicateRemote()} {
+		assertions.True(p.Create(event.CreateEvent{Object: userSecret}))
+		assertions.False(p.Create(event.CreateEvent{Object: ownedSecret}))
+	}
+}
@@ -37,39 +37,61 @@ func getInstanaAgentRemoteDeployments(
 	statusManager status.InstanaAgentRemoteStatusManager,
 	additionalBackends []backends.RemoteSensorBackend,
 	keysSecret *corev1.Secret,
+	credentialsVersions map[string]string,
 ) []builder.ObjectBuilder {
 	builders := make([]builder.ObjectBuilder, 0, len(additionalBackends))
 
 	for _, backend := range additionalBackends {
 		builders = append(
 			builders,
-			instanaagentremotedeployment.NewDeploymentBuilder(agent, statusManager, backend, keysSecret),
+			instanaagentremotedeployment.NewDeploymentBuilder(
+				agent,
+				statusManager,
+				backend,
+				keysSecret,
+				credentialsVersions,
+			),
 		)
 	}
 
 	return builders
 }
 
+// NewRemoteAgentBuilders returns the builders of all resources managed for an InstanaAgentRemote
+func NewRemoteAgentBuilders(
+	agent *instanav1.InstanaAgentRemote,
+	statusManager status.InstanaAgentRemoteStatusManager,
+	keysSecret *corev1.Secret,
+	credentialsVersions map[string]string,
+	additionalBackends []backends.RemoteSensorBackend,
+) []builder.ObjectBuilder {
+	return append(
+		getInstanaAgentRemoteDeployments(agent, statusManager, additionalBackends, keysSecret, credentialsVersions),
+		agentsecrets.NewConfigBuilder(agent, statusManager, keysSecret, additionalBackends),
+		agentsecrets.NewContainerBuilder(agent, keysSecret),
+		tlssecret.NewSecretBuilder(agent),
+		agentserviceaccount.NewServiceAccountBuilder(agent),
+		keyssecret.NewSecretBuilder(agent, additionalBackends),
+	)
+}
+
 func (r *InstanaAgentRemoteReconciler) applyResources(
 	ctx context.Context,
 	agent *instanav1.InstanaAgentRemote,
 	operatorUtils operator_utils.RemoteOperatorUtils,
 	statusManager status.InstanaAgentRemoteStatusManager,
 	keysSecret *corev1.Secret,
+	credentialsVersions map[string]string,
 	additionalBackends []backends.RemoteSensorBackend,
 ) reconcileReturn {
 	log := r.loggerFor(ctx, agent)
 	log.V(1).Info("applying Kubernetes resources for instana agent remote")
 
-	builders := append(
-		getInstanaAgentRemoteDeployments(agent, statusManager, additionalBackends, keysSecret),
-		agentsecrets.NewConfigBuilder(agent, statusManager, keysSecret, additionalBackends),
-		agentsecrets.NewContainerBuilder(agent, keysSecret),
-		tlssecret.NewSecretBuilder(agent),
-		agentserviceaccount.NewServiceAccountBuilder(agent),
-		keyssecret.NewSecretBuilder(agent, additionalBackends),
+	err := operatorUtils.ApplyAll(
+		NewRemoteAgentBuilders(agent, statusManager, keysSecret, credentialsVersions, additionalBackends)...,
 	)
-	if err := operatorUtils.ApplyAll(builders...); err != nil {
+	statusManager.SetInventory(operatorUtils.Inventory())
+	if err != nil {
 		log.Error(err, "failed to apply kubernetes resources for instana agent remote")
 		return reconcileFailure(err)
 	}
@@ -26,6 +26,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
@@ -45,6 +46,7 @@ func AddRemote(mgr manager.Manager) error {
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Secret{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(remoteAgentsForSecret(mgr.GetClient()))).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&corev1.Service{}).
 		WithEventFilter(filterPredicateRemote()).
@@ -92,7 +94,21 @@ func (r *InstanaAgentRemoteReconciler) reconcile(
 	ctx = logr.NewContext(ctx, log)
 	log.Info("reconciling instana agent remote CR")
 
-	agent.Default()
+	if applyDefaults(agent) {
+		log.V(1).Info("applied defaults in memory, enable the defaulting webhook to persist them on the CR")
+	}
+
+	configurationErrs := agent.Spec.Validate()
+	statusManager.SetConfigurationValidation(configurationErrs)
+
+	// A paused CR is neither written to nor are its dependents looked up, only deleting it still cleans them up
+	if reconcilePaused(agent) && agent.DeletionTimestamp.IsZero() {
+		statusManager.SetReconcilePaused(true)
+		return pauseReconcile(
+			log,
+			NewRemoteAgentBuilders(agent, statusManager, &corev1.Secret{}, nil, NewRemoteSensorBackends(agent)),
+		)
+	}
 
 	operatorUtils := operator_utils.NewRemoteOperatorUtils(
 		ctx,
@@ -115,8 +131,27 @@ func (r *InstanaAgentRemoteReconciler) reconcile(
 			log.Error(err, "unable to get KeysSecret-field")
 		}
 	}
+	if keysSecretErrs := agent.Spec.Agent.ValidateKeysSecret(keysSecret); len(keysSecretErrs) > 0 {
+		configurationErrs = append(configurationErrs, keysSecretErrs...)
+		statusManager.SetConfigurationValidation(configurationErrs)
+	}
+	// Referenced credentials are resolved into the in-memory spec only, which is never written back to the CR
+	credentialsVersions, secretRefErrs := resolveSecretRefs(
+		ctx,
+		r.client,
+		agent.Namespace,
+		agent.Spec.UseSecretMounts,
+		&agent.Spec.Agent,
+	)
+	if len(secretRefErrs) > 0 {
+		configurationErrs = append(configurationErrs, secretRefErrs...)
+		statusManager.SetConfigurationValidation(configurationErrs)
+	}
+	if len(configurationErrs) > 0 {
+		log.Info("spec contains invalid or conflicting settings", "errors", configurationErrs.ToAggregate().Error())
+	}
 
-	backends := r.getRemoteSensorBackends(agent)
+	backends := NewRemoteSensorBackends(agent)
 
 	if applyResourcesRes := r.applyResources(
 		ctx,
@@ -124,6 +159,7 @@ func (r *InstanaAgentRemoteReconciler) reconcile(
 		operatorUtils,
 		statusManager,
 		keysSecret,
+		credentialsVersions,
 		backends,
 	); applyResourcesRes.suppliesReconcileResult() {
 		return applyResourcesRes
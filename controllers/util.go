@@ -24,12 +24,16 @@ import (
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
 	backends "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/backends"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/builder"
 	"github.com/instana/instana-agent-operator/pkg/k8s/operator/operator_utils"
 )
 
@@ -127,7 +131,9 @@ func (r *InstanaAgentReconciler) shouldSetPersistHostUniqueIDEnvVar(
 	return false, reconcileFailure(err)
 }
 
-func (r *InstanaAgentReconciler) getK8SensorBackends(
+// NewK8SensorBackends returns the backends of the primary agent key followed by all additional backends, each of
+// them is served by a separate k8sensor deployment
+func NewK8SensorBackends(
 	agent *instanav1.InstanaAgent,
 ) []backends.K8SensorBackend {
 	k8SensorBackends := make(
@@ -153,7 +159,9 @@ func (r *InstanaAgentReconciler) getK8SensorBackends(
 	return k8SensorBackends
 }
 
-func (r *InstanaAgentRemoteReconciler) getRemoteSensorBackends(
+// NewRemoteSensorBackends returns the backends of the primary agent key followed by all additional backends, each
+// of them is served by a separate remote agent deployment
+func NewRemoteSensorBackends(
 	agent *instanav1.InstanaAgentRemote,
 ) []backends.RemoteSensorBackend {
 	remoteSensorBackends := make(
@@ -202,3 +210,40 @@ func (r *InstanaAgentRemoteReconciler) loggerFor(
 		agent.UID,
 	)
 }
+
+// applyDefaults fills in any defaults that were not persisted on the CR by the defaulting webhook and reports whether
+// the stored object was missing some of them. Persisted values are never overridden.
+func applyDefaults[T interface {
+	client.Object
+	Default()
+}](obj T) bool {
+	persisted := obj.DeepCopyObject()
+	obj.Default()
+	return !equality.Semantic.DeepEqual(persisted, obj)
+}
+
+// annotationEnabled reports whether the object carries the annotation with a true value
+func annotationEnabled(obj client.Object, annotation string) bool {
+	enabled, _ := strconv.ParseBool(obj.GetAnnotations()[annotation])
+	return enabled
+}
+
+// reconcilePaused reports whether the CR is annotated to skip applying its spec and cleaning up its dependents
+func reconcilePaused(obj client.Object) bool {
+	return annotationEnabled(obj, instanav1.ReconcilePausedAnnotation)
+}
+
+// pauseReconcile builds the dependents of a CR whose reconciliation is paused without applying them, so that the
+// status is still reported for them. Dependents that are no longer desired are not cleaned up either.
+func pauseReconcile(log logr.Logger, builders []builder.ObjectBuilder) reconcileReturn {
+	for _, bldr := range builders {
+		bldr.Build()
+	}
+
+	log.Info(
+		"reconciliation is paused, dependents are neither applied nor cleaned up",
+		"annotation",
+		instanav1.ReconcilePausedAnnotation,
+	)
+	return reconcileSuccess(ctrl.Result{})
+}
@@ -21,15 +21,21 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
 	instanaclient "github.com/instana/instana-agent-operator/pkg/k8s/client"
+	"github.com/instana/instana-agent-operator/pkg/k8s/operator/status"
 )
 
 type getErrorInstanaAgentClient struct {
@@ -98,3 +104,142 @@ func TestShouldSetPersistHostUniqueIDEnvVarGetErrorReturnsFailure(t *testing.T)
 	_, err := res.reconcileResult()
 	assert.ErrorIs(t, err, getErr)
 }
+
+func TestApplyDefaults(t *testing.T) {
+	agent := &instanav1.InstanaAgent{}
+	assert.True(t, applyDefaults(agent), "defaults missing on the stored CR should be reported")
+	assert.Equal(t, "ingress-red-saas.instana.io", agent.Spec.Agent.EndpointHost)
+
+	persisted := agent.DeepCopy()
+	assert.False(t, applyDefaults(agent), "a CR defaulted at admission should be left untouched")
+	assert.Equal(t, persisted, agent)
+
+	custom := &instanav1.InstanaAgentRemote{}
+	custom.Spec.Agent.EndpointHost = "custom.instana.io"
+	assert.True(t, applyDefaults(custom))
+	assert.Equal(t, "custom.instana.io", custom.Spec.Agent.EndpointHost)
+}
+
+func TestReconcilePaused(t *testing.T) {
+	for value, expected := range map[string]bool{"true": true, "True": true, "false": false, "paused": false} {
+		agent := &instanav1.InstanaAgentRemote{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{instanav1.ReconcilePausedAnnotation: value}},
+		}
+		assert.Equal(t, expected, reconcilePaused(agent), value)
+	}
+	assert.False(t, reconcilePaused(&instanav1.InstanaAgent{}))
+}
+
+func TestPauseReconcileRegistersDependentsWithoutApplying(t *testing.T) {
+	agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "instana-agent",
+			Namespace:   "instana-agent",
+			Annotations: map[string]string{instanav1.ReconcilePausedAnnotation: "true"},
+		},
+		Spec: instanav1.InstanaAgentSpec{
+			Agent:   instanav1.BaseAgentSpec{Key: "agent-key"},
+			Cluster: instanav1.Name{Name: "cluster"},
+			Zones:   []instanav1.Zone{{Name: instanav1.Name{Name: "zone-a"}}},
+		},
+	}
+	agent.Default()
+
+	statusManager := &status.MockAgentStatusManager{}
+	res := pauseReconcile(
+		logr.Discard(),
+		NewAgentBuilders(
+			agent,
+			statusManager,
+			AgentBuilderOptions{KeysSecret: &corev1.Secret{}, K8SensorBackends: NewK8SensorBackends(agent)},
+		),
+	)
+
+	assert.True(t, res.suppliesReconcileResult())
+	_, err := res.reconcileResult()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]client.ObjectKey{{Namespace: "instana-agent", Name: "instana-agent-zone-a"}},
+		statusManager.AgentDaemonsets,
+	)
+	assert.Len(t, statusManager.K8sSensorDeployments, 1)
+	for _, deployment := range statusManager.K8sSensorDeployments {
+		assert.Equal(t, "instana-agent-k8sensor", deployment.Name)
+	}
+}
+
+func TestReconcileLeavesPausedAgentUntouched(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, instanav1.AddToScheme(scheme))
+	assert.NoError(t, corev1.AddToScheme(scheme))
+
+	agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "instana-agent",
+			Namespace:   "instana-agent",
+			Annotations: map[string]string{instanav1.ReconcilePausedAnnotation: "true"},
+		},
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{
+				Key: "agent-key",
+				TlsSpec: instanav1.TlsSpec{
+					CertManager: &instanav1.CertManagerSpec{IssuerRef: instanav1.CertManagerIssuerRef{Name: "issuer"}},
+				},
+			},
+			Cluster: instanav1.Name{Name: "cluster"},
+		},
+	}
+
+	var lookups, writes []string
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(agent).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(
+				ctx context.Context,
+				c client.WithWatch,
+				key client.ObjectKey,
+				obj client.Object,
+				opts ...client.GetOption,
+			) error {
+				if _, ok := obj.(*instanav1.InstanaAgent); !ok {
+					lookups = append(lookups, key.Name)
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				writes = append(writes, obj.GetName())
+				return c.Update(ctx, obj, opts...)
+			},
+			Patch: func(
+				ctx context.Context,
+				c client.WithWatch,
+				obj client.Object,
+				patch client.Patch,
+				opts ...client.PatchOption,
+			) error {
+				writes = append(writes, obj.GetName())
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	r := &InstanaAgentReconciler{client: instanaclient.NewInstanaAgentClient(k8sClient), scheme: scheme}
+	statusManager := &status.MockAgentStatusManager{}
+	res := r.reconcile(
+		context.Background(),
+		ctrl.Request{NamespacedName: client.ObjectKeyFromObject(agent)},
+		statusManager,
+	)
+
+	_, err := res.reconcileResult()
+	assert.NoError(t, err)
+	assert.True(t, statusManager.ReconcilePaused)
+	assert.Empty(t, lookups, "the missing TLS Secret must not be looked up")
+	assert.Empty(t, writes)
+
+	stored := &instanav1.InstanaAgent{}
+	assert.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(agent), stored))
+	assert.Empty(t, stored.Finalizers)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
@@ -38,8 +39,8 @@ func (m *MockAgentStatusManager) SetAgentOld(agent *instanav1.InstanaAgent) {
 	m.Called(agent)
 }
 
-func (m *MockAgentStatusManager) SetK8sSensorDeployment(k8sSensorDeployment client.ObjectKey) {
-	m.Called(k8sSensorDeployment)
+func (m *MockAgentStatusManager) AddK8sSensorDeployment(k8sSensorDeployment client.ObjectKey, endpoint string) {
+	m.Called(k8sSensorDeployment, endpoint)
 }
 
 func (m *MockAgentStatusManager) SetAgentSecretConfig(agentSecretConfig client.ObjectKey) {
@@ -52,7 +53,36 @@ func (m *MockAgentStatusManager) SetAgentNamespacesConfigMap(
 	m.Called(agentNamespacesConfigmap)
 }
 
+func (m *MockAgentStatusManager) SetConfigurationValidation(configurationErrs field.ErrorList) {
+	m.Called(configurationErrs)
+}
+
+func (m *MockAgentStatusManager) SetInventory(inventory []instanav1.ResourceInventoryEntry) {
+	m.Called(inventory)
+}
+
+func (m *MockAgentStatusManager) SetPreview(preview *instanav1.PreviewStatus) {
+	m.Called(preview)
+}
+
+func (m *MockAgentStatusManager) SetReconcilePaused(paused bool) {
+	m.Called(paused)
+}
+
 func (m *MockAgentStatusManager) UpdateAgentStatus(ctx context.Context, reconcileErr error) error {
 	args := m.Called(ctx, reconcileErr)
 	return args.Error(0)
 }
+
+func (m *MockAgentStatusManager) SetETCDMonitoring(etcdMonitoring *instanav1.ETCDMonitoringStatus) {
+	m.Called(etcdMonitoring)
+}
+
+func (m *MockAgentStatusManager) SetTLS(tls *instanav1.TLSStatus) {
+	m.Called(tls)
+}
+
+func (m *MockAgentStatusManager) UpdateAvailability(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -41,6 +41,21 @@ func (m *MockHelpers) TLSSecretName() string {
 	return args.String(0)
 }
 
+func (m *MockHelpers) TLSCASecretName() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockHelpers) TLSCAConfigMapName() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockHelpers) ServiceDNSNames() []string {
+	args := m.Called()
+	return args.Get(0).([]string)
+}
+
 func (m *MockHelpers) HeadlessServiceName() string {
 	args := m.Called()
 	return args.String(0)
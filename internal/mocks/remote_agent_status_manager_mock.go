@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
@@ -30,8 +31,8 @@ type MockRemoteAgentStatusManager struct {
 	mock.Mock
 }
 
-func (m *MockRemoteAgentStatusManager) AddAgentDeployment(agentDeployment client.ObjectKey) {
-	m.Called(agentDeployment)
+func (m *MockRemoteAgentStatusManager) AddAgentDeployment(agentDeployment client.ObjectKey, endpoint string) {
+	m.Called(agentDeployment, endpoint)
 }
 
 func (m *MockRemoteAgentStatusManager) SetAgentOld(agent *instanav1.InstanaAgentRemote) {
@@ -42,6 +43,18 @@ func (m *MockRemoteAgentStatusManager) SetAgentSecretConfig(agentSecretConfig cl
 	m.Called(agentSecretConfig)
 }
 
+func (m *MockRemoteAgentStatusManager) SetConfigurationValidation(configurationErrs field.ErrorList) {
+	m.Called(configurationErrs)
+}
+
+func (m *MockRemoteAgentStatusManager) SetInventory(inventory []instanav1.ResourceInventoryEntry) {
+	m.Called(inventory)
+}
+
+func (m *MockRemoteAgentStatusManager) SetReconcilePaused(paused bool) {
+	m.Called(paused)
+}
+
 func (m *MockRemoteAgentStatusManager) UpdateAgentStatus(
 	ctx context.Context,
 	reconcileErr error,
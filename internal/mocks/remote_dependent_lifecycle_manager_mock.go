@@ -19,6 +19,8 @@ package mocks
 import (
 	"github.com/stretchr/testify/mock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
 )
 
 // MockRemoteDependentLifecycleManager provides a testify mock implementation of RemoteDependentLifecycleManager
@@ -39,3 +41,21 @@ func (m *MockRemoteDependentLifecycleManager) CleanupDependents(
 	args := m.Called(currentDependents)
 	return args.Error(0)
 }
+
+func (m *MockRemoteDependentLifecycleManager) Inventory() []instanav1.ResourceInventoryEntry {
+	args := m.Called()
+	if inventory := args.Get(0); inventory != nil {
+		return inventory.([]instanav1.ResourceInventoryEntry)
+	}
+	return nil
+}
+
+func (m *MockRemoteDependentLifecycleManager) PendingCleanup(
+	currentDependents ...client.Object,
+) ([]client.Object, error) {
+	args := m.Called(currentDependents)
+	if orphans := args.Get(0); orphans != nil {
+		return orphans.([]client.Object), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -36,8 +36,10 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	agentoperatorv1 "github.com/instana/instana-agent-operator/api/v1"
+	agentoperatorv2 "github.com/instana/instana-agent-operator/api/v2"
 	"github.com/instana/instana-agent-operator/controllers"
 	instanaclient "github.com/instana/instana-agent-operator/pkg/k8s/client"
+	"github.com/instana/instana-agent-operator/pkg/k8s/operator/migration"
 	"github.com/instana/instana-agent-operator/version"
 	// +kubebuilder:scaffold:imports
 )
@@ -55,6 +57,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(agentoperatorv1.AddToScheme(scheme))
+	utilruntime.Must(agentoperatorv2.AddToScheme(scheme))
 	utilruntime.Must(appsv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
@@ -97,6 +100,10 @@ func getCacheOptions() (cache.Options, error) {
 			&rbacv1.ClusterRoleBinding{}: {
 				Label: managedByOperator,
 			},
+			// Agent pods - compared with the nodes of the cluster to report the node coverage of an agent
+			&corev1.Pod{}: {
+				Label: managedByOperator,
+			},
 
 			// ConfigMaps and Secrets - watch all namespaces without label filter
 			// This allows access to user-provided KeysSecrets and ETCD resources
@@ -111,6 +118,11 @@ func getCacheOptions() (cache.Options, error) {
 }
 
 func main() {
+	// Render the resources of a CR file offline instead of running the operator
+	if len(os.Args) > 1 && os.Args[1] == renderCommand {
+		os.Exit(renderMain(os.Args[2:]))
+	}
+
 	var metricsAddr string
 	var probeAddr string
 	var enableLeaderElection bool
@@ -196,12 +208,49 @@ func main() {
 		log.Error(err, "Failure setting up Instana Agent Controller")
 		os.Exit(1)
 	}
+	// Add the controller keeping the availability in the status of Instana Agents up to date to the manager
+	if err := controllers.AddStatus(mgr); err != nil {
+		log.Error(err, "Failure setting up Instana Agent Status Controller")
+		os.Exit(1)
+	}
 	// Add our own Instana Agent Remote Controller to the manager
 	if err := controllers.AddRemote(mgr); err != nil {
 		log.Error(err, "Failure setting up Remote Instana Agent Controller")
 		os.Exit(1)
 	}
 
+	// Admission webhooks need a serving certificate, so they are only registered when explicitly enabled
+	if enableWebhooks, _ := strconv.ParseBool(os.Getenv("ENABLE_WEBHOOKS")); enableWebhooks {
+		if err := agentoperatorv1.SetupInstanaAgentWebhookWithManager(mgr); err != nil {
+			log.Error(err, "Failure setting up Instana Agent webhook")
+			os.Exit(1)
+		}
+		if err := agentoperatorv1.SetupInstanaAgentRemoteWebhookWithManager(mgr); err != nil {
+			log.Error(err, "Failure setting up Remote Instana Agent webhook")
+			os.Exit(1)
+		}
+		// Also serves the conversion webhook between instana.io/v1 and instana.io/v2
+		if err := agentoperatorv2.SetupInstanaAgentWebhookWithManager(mgr); err != nil {
+			log.Error(err, "Failure setting up Instana Agent v2 webhook")
+			os.Exit(1)
+		}
+		if err := agentoperatorv2.SetupInstanaAgentRemoteWebhookWithManager(mgr); err != nil {
+			log.Error(err, "Failure setting up Remote Instana Agent v2 webhook")
+			os.Exit(1)
+		}
+
+		// Rewrite stored resources once the storage version of their CRD has changed, e.g. to instana.io/v2. A storage
+		// version other than v1 can only be served through the conversion webhook, so this is skipped without webhooks.
+		for _, crdName := range []string{"agents.instana.io", "agentsremote.instana.io"} {
+			if err := mgr.Add(
+				migration.NewStorageVersionMigrator(mgr.GetClient(), mgr.GetAPIReader(), crdName),
+			); err != nil {
+				log.Error(err, "Failure setting up storage version migration", "crd", crdName)
+				os.Exit(1)
+			}
+		}
+	}
+
 	// controller-manager only runs controllers/runnables after getting the lock
 	// we do the cleanup beforehand so our new deployment gets the lock
 	log.Info("Deleting the controller-manager deployment and RBAC if it's present")
@@ -37,7 +37,7 @@ func TestLabelBasedCacheConfiguration(t *testing.T) {
 	cacheOpts, err := getCacheOptions()
 	assertions.NoError(err)
 	assertions.NotNil(cacheOpts.ByObject)
-	assertions.Len(cacheOpts.ByObject, 12, "Should have 12 resource types configured")
+	assertions.Len(cacheOpts.ByObject, 13, "Should have 13 resource types configured")
 
 	// Verify the cache configuration structure is correct
 	assertions.IsType(map[client.Object]cache.ByObject{}, cacheOpts.ByObject)
@@ -0,0 +1,191 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certgen generates the self-signed CA and the serving certificate signed by it that the operator issues for
+// the agent TLS listener when no certificate is provided
+package certgen
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+)
+
+const (
+	// CAValidity is how long a generated CA is valid
+	CAValidity = 5 * 365 * 24 * time.Hour
+	// ServingValidity is how long a generated serving certificate is valid
+	ServingValidity = 365 * 24 * time.Hour
+	// CAOverlap is how long a rotated CA is still trusted, and still signs the serving certificate, after the new CA
+	// was generated, so that clients can pick up the new CA before the serving certificate switches to it
+	CAOverlap = 30 * 24 * time.Hour
+
+	keySize = 2048
+)
+
+// KeyPair is a certificate along with its private key, both PEM encoded
+type KeyPair struct {
+	CertPEM     []byte
+	KeyPEM      []byte
+	Certificate *x509.Certificate
+
+	key *rsa.PrivateKey
+}
+
+// RenewAt is when the certificate is due for rotation, once two thirds of its validity have passed
+func (k *KeyPair) RenewAt() time.Time {
+	validity := k.Certificate.NotAfter.Sub(k.Certificate.NotBefore)
+	return k.Certificate.NotBefore.Add(validity * 2 / 3)
+}
+
+// NeedsRotation reports whether the certificate is due for rotation at the given time
+func (k *KeyPair) NeedsRotation(now time.Time) bool {
+	return !now.Before(k.RenewAt())
+}
+
+// Covers reports whether the certificate was signed by the given CA for exactly the given DNS names
+func (k *KeyPair) Covers(ca *KeyPair, dnsNames []string) bool {
+	return k.Certificate.CheckSignatureFrom(ca.Certificate) == nil &&
+		slices.Equal(k.Certificate.DNSNames, dnsNames)
+}
+
+// OverlapEndsAt is when a CA rotated in favor of this one is no longer trusted
+func (k *KeyPair) OverlapEndsAt() time.Time {
+	return k.Certificate.NotBefore.Add(CAOverlap)
+}
+
+// Certificates are a CA and the serving certificate signed by it. Right after the CA was rotated, the serving
+// certificate is still signed by the previous CA until the overlap elapses.
+type Certificates struct {
+	CA         *KeyPair
+	PreviousCA *KeyPair
+	Serving    *KeyPair
+}
+
+// SigningCA is the CA that signs the serving certificate, the previous one during the overlap
+func (c *Certificates) SigningCA() *KeyPair {
+	if c.PreviousCA != nil {
+		return c.PreviousCA
+	}
+	return c.CA
+}
+
+// CABundle are the PEM encoded certificates of all CAs to trust, the CA and the previous one during the overlap
+func (c *Certificates) CABundle() []byte {
+	if c.PreviousCA == nil {
+		return c.CA.CertPEM
+	}
+	return slices.Concat(c.CA.CertPEM, c.PreviousCA.CertPEM)
+}
+
+// GenerateCA creates a self-signed CA with the given common name, valid for CAValidity from now on
+func GenerateCA(commonName string, now time.Time) (*KeyPair, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now,
+		NotAfter:              now.Add(CAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return generate(template, nil)
+}
+
+// GenerateServing creates a serving certificate for the given DNS names signed by the CA, valid for ServingValidity
+// from now on
+func GenerateServing(ca *KeyPair, dnsNames []string, now time.Time) (*KeyPair, error) {
+	if len(dnsNames) == 0 {
+		return nil, errors.New("at least one DNS name is required")
+	}
+
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:    dnsNames,
+		NotBefore:   now,
+		NotAfter:    now.Add(ServingValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	return generate(template, ca)
+}
+
+// Parse reads a key pair generated earlier. The private key must be a PKCS#1 RSA key.
+func Parse(certPEM []byte, keyPEM []byte) (*KeyPair, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil || certBlock.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil || keyBlock.Type != "RSA PRIVATE KEY" {
+		return nil, errors.New("no PEM encoded RSA private key found")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	if !key.PublicKey.Equal(cert.PublicKey) {
+		return nil, errors.New("private key does not match the certificate")
+	}
+
+	return &KeyPair{CertPEM: certPEM, KeyPEM: keyPEM, Certificate: cert, key: key}, nil
+}
+
+// generate signs the template with the CA, or self-signs it if there is none
+func generate(template *x509.Certificate, ca *KeyPair) (*KeyPair, error) {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	template.SerialNumber = serialNumber
+
+	parent, signer := template, key
+	if ca != nil {
+		parent, signer = ca.Certificate, ca.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse created certificate: %w", err)
+	}
+
+	return &KeyPair{
+		CertPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:      pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		Certificate: cert,
+		key:         key,
+	}, nil
+}
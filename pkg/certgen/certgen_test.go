@@ -0,0 +1,118 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certgen
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateServingSignedByCA(t *testing.T) {
+	assertions := require.New(t)
+
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	dnsNames := []string{"instana-agent.instana-agent.svc", "instana-agent-headless.instana-agent.svc"}
+
+	ca, err := GenerateCA("instana-agent CA", now)
+	assertions.NoError(err)
+	assertions.True(ca.Certificate.IsCA)
+	assertions.Equal(now.Add(CAValidity), ca.Certificate.NotAfter)
+
+	serving, err := GenerateServing(ca, dnsNames, now)
+	assertions.NoError(err)
+	assertions.Equal(dnsNames, serving.Certificate.DNSNames)
+	assertions.Equal(now.Add(ServingValidity), serving.Certificate.NotAfter)
+	assertions.True(serving.Covers(ca, dnsNames))
+	assertions.False(serving.Covers(ca, dnsNames[:1]))
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+	_, err = serving.Certificate.Verify(
+		x509.VerifyOptions{DNSName: dnsNames[1], Roots: roots, CurrentTime: now.Add(time.Hour)},
+	)
+	assertions.NoError(err)
+
+	otherCA, err := GenerateCA("other CA", now)
+	assertions.NoError(err)
+	assertions.False(serving.Covers(otherCA, dnsNames))
+
+	_, err = GenerateServing(ca, nil, now)
+	assertions.Error(err)
+}
+
+func TestParse(t *testing.T) {
+	assertions := require.New(t)
+
+	now := time.Now()
+	ca, err := GenerateCA("instana-agent CA", now)
+	assertions.NoError(err)
+	serving, err := GenerateServing(ca, []string{"instana-agent"}, now)
+	assertions.NoError(err)
+
+	parsed, err := Parse(ca.CertPEM, ca.KeyPEM)
+	assertions.NoError(err)
+	assertions.Equal(ca.Certificate.Raw, parsed.Certificate.Raw)
+
+	// a CA read back from the cluster can sign the rotated certificate
+	rotated, err := GenerateServing(parsed, []string{"instana-agent"}, now)
+	assertions.NoError(err)
+	assertions.True(rotated.Covers(ca, []string{"instana-agent"}))
+
+	_, err = Parse(ca.CertPEM, serving.KeyPEM)
+	assertions.ErrorContains(err, "private key does not match the certificate")
+	_, err = Parse([]byte("edited"), ca.KeyPEM)
+	assertions.Error(err)
+	_, err = Parse(ca.CertPEM, nil)
+	assertions.Error(err)
+}
+
+func TestRenewAt(t *testing.T) {
+	assertions := require.New(t)
+
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ca, err := GenerateCA("instana-agent CA", now)
+	assertions.NoError(err)
+	serving, err := GenerateServing(ca, []string{"instana-agent"}, now)
+	assertions.NoError(err)
+
+	renewAt := now.Add(ServingValidity * 2 / 3)
+	assertions.Equal(renewAt, serving.RenewAt())
+	assertions.False(serving.NeedsRotation(renewAt.Add(-time.Second)))
+	assertions.True(serving.NeedsRotation(renewAt))
+}
+
+func TestCertificatesDuringCAOverlap(t *testing.T) {
+	assertions := require.New(t)
+
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	previousCA, err := GenerateCA("instana-agent CA", now)
+	assertions.NoError(err)
+	ca, err := GenerateCA("instana-agent CA", previousCA.RenewAt())
+	assertions.NoError(err)
+	assertions.Equal(previousCA.RenewAt().Add(CAOverlap), ca.OverlapEndsAt())
+
+	certificates := &Certificates{CA: ca}
+	assertions.Equal(ca, certificates.SigningCA())
+	assertions.Equal(ca.CertPEM, certificates.CABundle())
+
+	certificates.PreviousCA = previousCA
+	assertions.Equal(previousCA, certificates.SigningCA())
+	assertions.Equal(append(append([]byte{}, ca.CertPEM...), previousCA.CertPEM...), certificates.CABundle())
+}
@@ -246,11 +246,9 @@ func (c *instanaAgentClient) GetNamespacesWithLabels(
 ) (namespaces.NamespacesDetails, error) {
 	log := logf.FromContext(ctx)
 	namespaceList := &corev1.NamespaceList{}
-	namespacesMap := make(map[string]namespaces.NamespaceMetadata)
 
-	instanaWorkloadMonitoringLabel := "instana-workload-monitoring"
-	log.Info("Requesting list of namespaces with label " + instanaWorkloadMonitoringLabel)
-	labelSelector, _ := labels.Parse("instana-workload-monitoring")
+	log.Info("Requesting list of namespaces with label " + namespaces.WorkloadMonitoringLabel)
+	labelSelector, _ := labels.Parse(namespaces.WorkloadMonitoringLabel)
 	err := c.k8sClient.List(ctx, namespaceList, &client.ListOptions{
 		LabelSelector: labelSelector,
 	})
@@ -261,16 +259,8 @@ func (c *instanaAgentClient) GetNamespacesWithLabels(
 	var namespacesReceived []string
 	for _, ns := range namespaceList.Items {
 		namespacesReceived = append(namespacesReceived, ns.Name)
-		labelsCopy := make(map[string]string)
-		labelsCopy[instanaWorkloadMonitoringLabel] = ns.Labels[instanaWorkloadMonitoringLabel]
-		namespacesMap[ns.Name] = namespaces.NamespaceMetadata{
-			Labels: labelsCopy,
-		}
 	}
 
 	log.Info("Received details of namespaces", "namespaceNames", namespacesReceived)
-	return namespaces.NamespacesDetails{
-		Version:    1,
-		Namespaces: namespacesMap,
-	}, nil
+	return namespaces.NewNamespacesDetails(namespaceList.Items), nil
 }
@@ -0,0 +1,91 @@
+/*
+(c) Copyright IBM Corp. 2026
+*/
+
+package certificate
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/builder"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/constants"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/helpers"
+	"github.com/instana/instana-agent-operator/pkg/optional"
+)
+
+// GroupVersionKind of the cert-manager Certificate. The object is built as unstructured, so that the operator does
+// not depend on the API of cert-manager, which might not even be installed in the cluster.
+var GroupVersionKind = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+const (
+	componentName = constants.ComponentInstanaAgent
+)
+
+type certificateBuilder struct {
+	*instanav1.InstanaAgent
+	helpers.Helpers
+}
+
+func NewCertificateBuilder(agent *instanav1.InstanaAgent) builder.ObjectBuilder {
+	return &certificateBuilder{
+		InstanaAgent: agent,
+		Helpers:      helpers.NewHelpers(agent),
+	}
+}
+
+func (c *certificateBuilder) ComponentName() string {
+	return componentName
+}
+
+func (c *certificateBuilder) IsNamespaced() bool {
+	return true
+}
+
+func (c *certificateBuilder) dnsNames() []any {
+	var dnsNames []any
+	for _, dnsName := range append(c.ServiceDNSNames(), c.Spec.Agent.TlsSpec.CertManager.DNSNames...) {
+		dnsNames = append(dnsNames, dnsName)
+	}
+	return dnsNames
+}
+
+func (c *certificateBuilder) build() *unstructured.Unstructured {
+	certManager := c.Spec.Agent.TlsSpec.CertManager
+
+	issuerRef := map[string]any{"name": certManager.IssuerRef.Name}
+	if certManager.IssuerRef.Kind != "" {
+		issuerRef["kind"] = certManager.IssuerRef.Kind
+	}
+	if certManager.IssuerRef.Group != "" {
+		issuerRef["group"] = certManager.IssuerRef.Group
+	}
+
+	certificate := &unstructured.Unstructured{
+		Object: map[string]any{
+			"metadata": map[string]any{
+				"name":      c.TLSSecretName(),
+				"namespace": c.Namespace,
+			},
+			"spec": map[string]any{
+				"secretName": c.TLSSecretName(),
+				"dnsNames":   c.dnsNames(),
+				"issuerRef":  issuerRef,
+			},
+		},
+	}
+	certificate.SetGroupVersionKind(GroupVersionKind)
+
+	return certificate
+}
+
+func (c *certificateBuilder) Build() builder.OptionalObject {
+	switch c.Spec.Agent.TlsSpec.CertManager {
+	case nil:
+		return optional.Empty[client.Object]()
+	default:
+		return optional.Of[client.Object](c.build())
+	}
+}
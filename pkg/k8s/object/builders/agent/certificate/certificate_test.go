@@ -0,0 +1,81 @@
+/*
+(c) Copyright IBM Corp. 2026
+*/
+
+package certificate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+)
+
+func TestCertificateBuilder_IsNamespaced_ComponentName(t *testing.T) {
+	assertions := require.New(t)
+
+	c := NewCertificateBuilder(&instanav1.InstanaAgent{})
+
+	assertions.True(c.IsNamespaced())
+	assertions.Equal("instana-agent", c.ComponentName())
+}
+
+func TestCertificateBuilder_BuildWithoutCertManager(t *testing.T) {
+	agent := &instanav1.InstanaAgent{
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{TlsSpec: instanav1.TlsSpec{SecretName: "tls"}},
+		},
+	}
+
+	require.False(t, NewCertificateBuilder(agent).Build().IsPresent())
+}
+
+func TestCertificateBuilder_Build(t *testing.T) {
+	assertions := require.New(t)
+
+	agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", Namespace: "instana-agent"},
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{
+				TlsSpec: instanav1.TlsSpec{
+					CertManager: &instanav1.CertManagerSpec{
+						IssuerRef: instanav1.CertManagerIssuerRef{Name: "ca", Kind: "ClusterIssuer"},
+						DNSNames:  []string{"agent.example.com"},
+					},
+				},
+			},
+		},
+	}
+
+	expected := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata": map[string]any{
+				"name":      "instana-agent-tls",
+				"namespace": "instana-agent",
+			},
+			"spec": map[string]any{
+				"secretName": "instana-agent-tls",
+				"dnsNames": []any{
+					"instana-agent",
+					"instana-agent.instana-agent",
+					"instana-agent.instana-agent.svc",
+					"instana-agent-headless",
+					"instana-agent-headless.instana-agent",
+					"instana-agent-headless.instana-agent.svc",
+					"agent.example.com",
+				},
+				"issuerRef": map[string]any{"name": "ca", "kind": "ClusterIssuer"},
+			},
+		},
+	}
+
+	actual := NewCertificateBuilder(agent).Build()
+
+	assertions.True(actual.IsPresent())
+	assertions.Equal(expected, actual.Get())
+}
@@ -6,6 +6,7 @@ package daemonset
 
 import (
 	"fmt"
+	"maps"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +37,9 @@ func NewDaemonSetBuilder(
 	isOpenshift bool,
 	statusManager status.AgentStatusManager,
 	shouldSetPersistHostUniqueIDEnvVar bool,
+	keysSecret *corev1.Secret,
+	tlsSecret *corev1.Secret,
+	credentialsVersions map[string]string,
 ) builder.ObjectBuilder {
 	return NewDaemonSetBuilderWithZoneInfo(
 		agent,
@@ -43,6 +47,9 @@ func NewDaemonSetBuilder(
 		statusManager,
 		nil,
 		shouldSetPersistHostUniqueIDEnvVar,
+		keysSecret,
+		tlsSecret,
+		credentialsVersions,
 	)
 }
 
@@ -52,6 +59,9 @@ func NewDaemonSetBuilderWithZoneInfo(
 	statusManager status.AgentStatusManager,
 	zone *instanav1.Zone,
 	shouldSetPersistHostUniqueIDEnvVar bool,
+	keysSecret *corev1.Secret,
+	tlsSecret *corev1.Secret,
+	credentialsVersions map[string]string,
 ) builder.ObjectBuilder {
 	return &daemonSetBuilder{
 		InstanaAgent:                       agent,
@@ -69,6 +79,10 @@ func NewDaemonSetBuilderWithZoneInfo(
 		EnvBuilder:    env.NewEnvBuilder(agent, zone),
 		VolumeBuilder: volume.NewVolumeBuilder(agent, isOpenshift),
 		zone:          zone,
+		keysSecret:    keysSecret,
+		tlsSecret:     tlsSecret,
+
+		credentialsVersions: credentialsVersions,
 	}
 }
 
@@ -85,6 +99,10 @@ type daemonSetBuilder struct {
 
 	portsBuilder ports.PortsBuilder
 	zone         *instanav1.Zone
+	keysSecret   *corev1.Secret
+	tlsSecret    *corev1.Secret
+	// credentialsVersions maps the Secrets holding the referenced credentials to their resourceVersion
+	credentialsVersions map[string]string
 }
 
 func (d *daemonSetBuilder) ComponentName() string {
@@ -95,6 +113,53 @@ func (d *daemonSetBuilder) IsNamespaced() bool {
 	return true
 }
 
+// getPodAnnotationsWithKeysChecksum adds a checksum of the agent keys, referenced credentials and issued certificate
+// to the pod annotations, so that the agents are rolled out again when they change, e.g. when the keys secret is
+// rotated
+func (d *daemonSetBuilder) getPodAnnotationsWithKeysChecksum() map[string]string {
+	// Deep copy annotations to extend them with a checksum
+	annotations := make(map[string]string, len(d.Spec.Agent.Pod.Annotations)+1)
+	maps.Copy(annotations, d.Spec.Agent.Pod.Annotations)
+
+	switch d.Spec.Agent.KeysSecret {
+	case "":
+		// the keys are part of the CR
+		keys := []string{d.Spec.Agent.Key, d.Spec.Agent.DownloadKey}
+		for _, backend := range d.Spec.Agent.AdditionalBackends {
+			keys = append(keys, backend.Key)
+		}
+		annotations[constants.AnnotationKeysChecksum] = d.HashJsonOrDie(keys)
+	default:
+		var keysSecretData map[string][]byte
+		if d.keysSecret != nil {
+			keysSecretData = d.keysSecret.Data
+		}
+		annotations[constants.AnnotationKeysChecksum] = d.HashJsonOrDie(keysSecretData)
+	}
+
+	// credentials referenced in secrets are resolved into the spec by the reconciler, but the CR does not change when
+	// they are rotated. Only the versions of the secrets are hashed, so that the checksum reveals nothing about the
+	// credentials.
+	agent := &d.Spec.Agent
+	if agent.ProxyPasswordSecretRef != nil ||
+		agent.MirrorReleaseRepoPasswordSecretRef != nil ||
+		agent.MirrorSharedRepoPasswordSecretRef != nil {
+		annotations[constants.AnnotationCredentialsChecksum] = d.HashJsonOrDie(d.credentialsVersions)
+	}
+
+	// certificates issued by cert-manager or generated by the operator are renewed in place, without any change to
+	// the CR
+	if agent.TlsSpec.CertManager != nil || agent.TlsSpec.AutoGenerate {
+		var tlsSecretData map[string][]byte
+		if d.tlsSecret != nil {
+			tlsSecretData = d.tlsSecret.Data
+		}
+		annotations[constants.AnnotationTLSChecksum] = d.HashJsonOrDie(tlsSecretData)
+	}
+
+	return annotations
+}
+
 func (d *daemonSetBuilder) getPodTemplateLabels() map[string]string {
 	podLabels := optional.Of(d.InstanaAgent.Spec.Agent.Pod.Labels).GetOrDefault(map[string]string{})
 	podLabels[constants.LabelAgentMode] = string(
@@ -296,7 +361,7 @@ func (d *daemonSetBuilder) build() *appsv1.DaemonSet {
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      d.getPodTemplateLabels(),
-					Annotations: d.InstanaAgent.Spec.Agent.Pod.Annotations,
+					Annotations: d.getPodAnnotationsWithKeysChecksum(),
 				},
 				Spec: corev1.PodSpec{
 					Volumes:            append(volumes, userVolumes...),
@@ -341,7 +406,7 @@ func (d *daemonSetBuilder) Build() (res optional.Optional[client.Object]) {
 	}()
 
 	switch {
-	case d.Spec.Agent.Key == "" && d.Spec.Agent.KeysSecret == "":
+	case d.Spec.Agent.Key == "" && d.Spec.Agent.KeysSecret == "" && d.Spec.Agent.SecretProviderClass == "":
 		fallthrough
 	case d.zone == nil && d.Spec.Zone.Name == "" && d.Spec.Cluster.Name == "":
 		fallthrough
@@ -75,7 +75,7 @@ func TestDaemonSetBuilder_PodEnvVars(t *testing.T) {
 	}
 
 	statusManager := status.NewAgentStatusManager(nil, nil)
-	dsBuilder := NewDaemonSetBuilder(agent, false, statusManager, false)
+	dsBuilder := NewDaemonSetBuilder(agent, false, statusManager, false, nil, nil, nil)
 
 	// When
 	obj := dsBuilder.Build()
@@ -163,7 +163,7 @@ func TestDaemonSetBuilder_EnvVarPrecedence(t *testing.T) {
 	}
 
 	statusManager := status.NewAgentStatusManager(nil, nil)
-	dsBuilder := NewDaemonSetBuilder(agent, false, statusManager, false)
+	dsBuilder := NewDaemonSetBuilder(agent, false, statusManager, false, nil, nil, nil)
 
 	// When
 	obj := dsBuilder.Build()
@@ -229,7 +229,7 @@ func TestDaemonSetBuilder_NodeNameEnvVar(t *testing.T) {
 	}
 
 	statusManager := status.NewAgentStatusManager(nil, nil)
-	dsBuilder := NewDaemonSetBuilder(agent, false, statusManager, false)
+	dsBuilder := NewDaemonSetBuilder(agent, false, statusManager, false, nil, nil, nil)
 
 	// When
 	obj := dsBuilder.Build()
@@ -283,7 +283,7 @@ func TestDaemonSetBuilder_NodeNameEnvVarWithPersistence(t *testing.T) {
 
 	statusManager := status.NewAgentStatusManager(nil, nil)
 	// Enable persistence flag
-	dsBuilder := NewDaemonSetBuilder(agent, false, statusManager, true)
+	dsBuilder := NewDaemonSetBuilder(agent, false, statusManager, true, nil, nil, nil)
 
 	// When
 	obj := dsBuilder.Build()
@@ -361,7 +361,7 @@ func TestDaemonSetBuilder_NodeNameCanBeOverridden(t *testing.T) {
 	}
 
 	statusManager := status.NewAgentStatusManager(nil, nil)
-	dsBuilder := NewDaemonSetBuilder(agent, false, statusManager, false)
+	dsBuilder := NewDaemonSetBuilder(agent, false, statusManager, false, nil, nil, nil)
 
 	// When
 	obj := dsBuilder.Build()
@@ -75,6 +75,9 @@ func TestDaemonSetBuilder_PersistHostUniqueID(t *testing.T) {
 				false,
 				statusManager,
 				tt.shouldSetPersistHostUniqueIDEnvVar,
+				nil,
+				nil,
+				nil,
 			)
 
 			// When
@@ -138,7 +141,7 @@ func TestDaemonSetBuilder_PersistHostUniqueID_WithPodEnv(t *testing.T) {
 
 	statusManager := status.NewAgentStatusManager(nil, nil)
 	// Even though we set the flag to true, pod.env should take precedence
-	dsBuilder := NewDaemonSetBuilder(agent, false, statusManager, true)
+	dsBuilder := NewDaemonSetBuilder(agent, false, statusManager, true, nil, nil, nil)
 
 	// When
 	obj := dsBuilder.Build()
@@ -448,7 +448,7 @@ func TestDaemonSetBuilder_getUserVolumes(t *testing.T) {
 func TestDaemonSetBuilder_IsNamespaced_ComponentName(t *testing.T) {
 	assertions := assert.New(t)
 
-	dsBuilder := NewDaemonSetBuilder(&instanav1.InstanaAgent{}, false, nil, false)
+	dsBuilder := NewDaemonSetBuilder(&instanav1.InstanaAgent{}, false, nil, false, nil, nil, nil)
 
 	assertions.True(dsBuilder.IsNamespaced())
 	assertions.Equal(constants.ComponentInstanaAgent, dsBuilder.ComponentName())
@@ -609,7 +609,7 @@ func TestDaemonSetBuilder_Build(t *testing.T) {
 					status.On("AddAgentDaemonset", mock.Anything)
 				}
 
-				dsBuilder := NewDaemonSetBuilder(test.agent, false, status, false)
+				dsBuilder := NewDaemonSetBuilder(test.agent, false, status, false, nil, nil, nil)
 
 				result := dsBuilder.Build()
 				assertions.Equal(test.expectPresent, result.IsPresent())
@@ -644,7 +644,7 @@ func TestGetLivenessProbe_DefaultValues(t *testing.T) {
 	mockClient := &mocks.MockInstanaAgentClient{}
 	eventRecorder := record.NewFakeRecorder(10)
 	statusManager := status.NewAgentStatusManager(mockClient, eventRecorder)
-	builder := NewDaemonSetBuilder(agent, false, statusManager, false).(*daemonSetBuilder)
+	builder := NewDaemonSetBuilder(agent, false, statusManager, false, nil, nil, nil).(*daemonSetBuilder)
 
 	// Get the liveness probe
 	probe := builder.getLivenessProbe()
@@ -707,7 +707,7 @@ func TestGetLivenessProbe_CustomValues(t *testing.T) {
 	mockClient := &mocks.MockInstanaAgentClient{}
 	eventRecorder := record.NewFakeRecorder(10)
 	statusManager := status.NewAgentStatusManager(mockClient, eventRecorder)
-	builder := NewDaemonSetBuilder(agent, false, statusManager, false).(*daemonSetBuilder)
+	builder := NewDaemonSetBuilder(agent, false, statusManager, false, nil, nil, nil).(*daemonSetBuilder)
 
 	// Get the liveness probe
 	probe := builder.getLivenessProbe()
@@ -768,7 +768,7 @@ func TestGetLivenessProbe_PartialCustomValues(t *testing.T) {
 	mockClient := &mocks.MockInstanaAgentClient{}
 	eventRecorder := record.NewFakeRecorder(10)
 	statusManager := status.NewAgentStatusManager(mockClient, eventRecorder)
-	builder := NewDaemonSetBuilder(agent, false, statusManager, false).(*daemonSetBuilder)
+	builder := NewDaemonSetBuilder(agent, false, statusManager, false, nil, nil, nil).(*daemonSetBuilder)
 
 	// Get the liveness probe
 	probe := builder.getLivenessProbe()
@@ -824,7 +824,7 @@ func TestGetLivenessProbe_TCPSocket(t *testing.T) {
 	mockClient := &mocks.MockInstanaAgentClient{}
 	eventRecorder := record.NewFakeRecorder(10)
 	statusManager := status.NewAgentStatusManager(mockClient, eventRecorder)
-	builder := NewDaemonSetBuilder(agent, false, statusManager, false).(*daemonSetBuilder)
+	builder := NewDaemonSetBuilder(agent, false, statusManager, false, nil, nil, nil).(*daemonSetBuilder)
 
 	// Get the liveness probe
 	probe := builder.getLivenessProbe()
@@ -883,7 +883,7 @@ func TestGetLivenessProbe_ExecAction(t *testing.T) {
 	mockClient := &mocks.MockInstanaAgentClient{}
 	eventRecorder := record.NewFakeRecorder(10)
 	statusManager := status.NewAgentStatusManager(mockClient, eventRecorder)
-	builder := NewDaemonSetBuilder(agent, false, statusManager, false).(*daemonSetBuilder)
+	builder := NewDaemonSetBuilder(agent, false, statusManager, false, nil, nil, nil).(*daemonSetBuilder)
 
 	// Get the liveness probe
 	probe := builder.getLivenessProbe()
@@ -946,7 +946,7 @@ func TestBuild_LivenessProbeInDaemonSet(t *testing.T) {
 	mockClient := &mocks.MockInstanaAgentClient{}
 	eventRecorder := record.NewFakeRecorder(10)
 	statusManager := status.NewAgentStatusManager(mockClient, eventRecorder)
-	builder := NewDaemonSetBuilder(agent, false, statusManager, false).(*daemonSetBuilder)
+	builder := NewDaemonSetBuilder(agent, false, statusManager, false, nil, nil, nil).(*daemonSetBuilder)
 
 	// Build the DaemonSet
 	ds := builder.build()
@@ -989,7 +989,7 @@ func TestBuild_DefaultLivenessProbeInDaemonSet(t *testing.T) {
 	mockClient := &mocks.MockInstanaAgentClient{}
 	eventRecorder := record.NewFakeRecorder(10)
 	statusManager := status.NewAgentStatusManager(mockClient, eventRecorder)
-	builder := NewDaemonSetBuilder(agent, false, statusManager, false).(*daemonSetBuilder)
+	builder := NewDaemonSetBuilder(agent, false, statusManager, false, nil, nil, nil).(*daemonSetBuilder)
 
 	// Build the DaemonSet
 	ds := builder.build()
@@ -1035,7 +1035,7 @@ func TestGetLivenessProbe_NilPointer(t *testing.T) {
 	mockClient := &mocks.MockInstanaAgentClient{}
 	eventRecorder := record.NewFakeRecorder(10)
 	statusManager := status.NewAgentStatusManager(mockClient, eventRecorder)
-	builder := NewDaemonSetBuilder(agent, false, statusManager, false).(*daemonSetBuilder)
+	builder := NewDaemonSetBuilder(agent, false, statusManager, false, nil, nil, nil).(*daemonSetBuilder)
 
 	// Get the liveness probe
 	probe := builder.getLivenessProbe()
@@ -1092,7 +1092,7 @@ func TestGetLivenessProbe_WithSuccessThreshold(t *testing.T) {
 	mockClient := &mocks.MockInstanaAgentClient{}
 	eventRecorder := record.NewFakeRecorder(10)
 	statusManager := status.NewAgentStatusManager(mockClient, eventRecorder)
-	builder := NewDaemonSetBuilder(agent, false, statusManager, false).(*daemonSetBuilder)
+	builder := NewDaemonSetBuilder(agent, false, statusManager, false, nil, nil, nil).(*daemonSetBuilder)
 
 	// Get the liveness probe
 	probe := builder.getLivenessProbe()
@@ -1101,3 +1101,108 @@ func TestGetLivenessProbe_WithSuccessThreshold(t *testing.T) {
 	require.NotNil(t, probe)
 	assert.Equal(t, int32(2), probe.SuccessThreshold)
 }
+
+func TestPodAnnotationsWithKeysChecksum(t *testing.T) {
+	assertions := assert.New(t)
+
+	agent := &instanav1.InstanaAgent{
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{
+				KeysSecret: "user-managed-keys",
+				Pod: instanav1.AgentPodSpec{
+					Annotations: map[string]string{"user": "annotation"},
+				},
+			},
+		},
+	}
+	checksum := func(key string) string {
+		keysSecret := &corev1.Secret{Data: map[string][]byte{constants.AgentKey: []byte(key)}}
+		builder := NewDaemonSetBuilder(agent, false, nil, false, keysSecret, nil, nil).(*daemonSetBuilder)
+		annotations := builder.getPodAnnotationsWithKeysChecksum()
+		assertions.Equal("annotation", annotations["user"])
+		return annotations[constants.AnnotationKeysChecksum]
+	}
+
+	assertions.NotEmpty(checksum("old-key"))
+	assertions.Equal(checksum("old-key"), checksum("old-key"))
+	assertions.NotEqual(checksum("old-key"), checksum("rotated-key"))
+	assertions.NotContains(agent.Spec.Agent.Pod.Annotations, constants.AnnotationKeysChecksum)
+
+	agent.Spec.Agent.KeysSecret = ""
+	agent.Spec.Agent.Key = "inline-key"
+	inline := NewDaemonSetBuilder(agent, false, nil, false, nil, nil, nil).(*daemonSetBuilder).
+		getPodAnnotationsWithKeysChecksum()
+	agent.Spec.Agent.Key = "rotated-inline-key"
+	rotated := NewDaemonSetBuilder(agent, false, nil, false, nil, nil, nil).(*daemonSetBuilder).
+		getPodAnnotationsWithKeysChecksum()
+	assertions.NotEqual(inline[constants.AnnotationKeysChecksum], rotated[constants.AnnotationKeysChecksum])
+}
+
+func TestPodAnnotationsWithCredentialsChecksum(t *testing.T) {
+	assertions := assert.New(t)
+
+	agent := &instanav1.InstanaAgent{
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{Key: "key", ProxyPassword: "inline-password"},
+		},
+	}
+	annotations := NewDaemonSetBuilder(agent, false, nil, false, nil, nil, nil).(*daemonSetBuilder).
+		getPodAnnotationsWithKeysChecksum()
+	assertions.NotContains(annotations, constants.AnnotationCredentialsChecksum)
+
+	// the reconciler resolves the referenced password into the spec and passes on the version of the secret
+	agent.Spec.Agent.ProxyPasswordSecretRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "proxy"},
+		Key:                  "password",
+	}
+	podAnnotations := func(proxySecretVersion string) map[string]string {
+		credentialsVersions := map[string]string{"proxy": proxySecretVersion}
+		return NewDaemonSetBuilder(agent, false, nil, false, nil, nil, credentialsVersions).(*daemonSetBuilder).
+			getPodAnnotationsWithKeysChecksum()
+	}
+	agent.Spec.Agent.ProxyPassword = "password"
+	annotations = podAnnotations("1")
+	agent.Spec.Agent.ProxyPassword = "rotated-password"
+	sameVersion := podAnnotations("1")
+	rotated := podAnnotations("2")
+
+	assertions.NotEmpty(annotations[constants.AnnotationCredentialsChecksum])
+	assertions.Equal(
+		annotations[constants.AnnotationCredentialsChecksum],
+		sameVersion[constants.AnnotationCredentialsChecksum],
+		"the checksum must not depend on the credentials themselves",
+	)
+	assertions.NotEqual(
+		annotations[constants.AnnotationCredentialsChecksum],
+		rotated[constants.AnnotationCredentialsChecksum],
+	)
+	assertions.Equal(annotations[constants.AnnotationKeysChecksum], rotated[constants.AnnotationKeysChecksum])
+}
+
+func TestPodAnnotationsWithTLSChecksum(t *testing.T) {
+	assertions := assert.New(t)
+
+	agent := &instanav1.InstanaAgent{
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{Key: "key", TlsSpec: instanav1.TlsSpec{SecretName: "tls"}},
+		},
+	}
+	annotations := NewDaemonSetBuilder(agent, false, nil, false, nil, nil, nil).(*daemonSetBuilder).
+		getPodAnnotationsWithKeysChecksum()
+	assertions.NotContains(annotations, constants.AnnotationTLSChecksum)
+
+	agent.Spec.Agent.TlsSpec.CertManager = &instanav1.CertManagerSpec{
+		IssuerRef: instanav1.CertManagerIssuerRef{Name: "issuer"},
+	}
+	issued := &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: []byte("issued")}}
+	renewed := &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: []byte("renewed")}}
+
+	annotations = NewDaemonSetBuilder(agent, false, nil, false, nil, issued, nil).(*daemonSetBuilder).
+		getPodAnnotationsWithKeysChecksum()
+	rotated := NewDaemonSetBuilder(agent, false, nil, false, nil, renewed, nil).(*daemonSetBuilder).
+		getPodAnnotationsWithKeysChecksum()
+
+	assertions.NotEmpty(annotations[constants.AnnotationTLSChecksum])
+	assertions.NotEqual(annotations[constants.AnnotationTLSChecksum], rotated[constants.AnnotationTLSChecksum])
+	assertions.Equal(annotations[constants.AnnotationKeysChecksum], rotated[constants.AnnotationKeysChecksum])
+}
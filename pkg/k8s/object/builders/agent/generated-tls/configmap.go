@@ -0,0 +1,63 @@
+/*
+(c) Copyright IBM Corp. 2026
+*/
+
+package generated_tls
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/certgen"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/builder"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/constants"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/helpers"
+	"github.com/instana/instana-agent-operator/pkg/optional"
+)
+
+type caConfigMapBuilder struct {
+	*instanav1.InstanaAgent
+	helpers.Helpers
+
+	certificates *certgen.Certificates
+}
+
+// NewCAConfigMapBuilder builds the ConfigMap publishing the generated CA, so that clients of the agent can verify its
+// certificate. Right after the CA was rotated, the previous CA is published along with it until the overlap elapses.
+func NewCAConfigMapBuilder(agent *instanav1.InstanaAgent, certificates *certgen.Certificates) builder.ObjectBuilder {
+	return &caConfigMapBuilder{
+		InstanaAgent: agent,
+		Helpers:      helpers.NewHelpers(agent),
+		certificates: certificates,
+	}
+}
+
+func (c *caConfigMapBuilder) IsNamespaced() bool {
+	return true
+}
+
+func (c *caConfigMapBuilder) ComponentName() string {
+	return constants.ComponentInstanaAgent
+}
+
+func (c *caConfigMapBuilder) Build() builder.OptionalObject {
+	if c.certificates == nil {
+		return optional.Empty[client.Object]()
+	}
+
+	return optional.Of[client.Object](
+		&corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      c.TLSCAConfigMapName(),
+				Namespace: c.Namespace,
+			},
+			Data: map[string]string{CACertKey: string(c.certificates.CABundle())},
+		},
+	)
+}
@@ -0,0 +1,120 @@
+/*
+(c) Copyright IBM Corp. 2026
+*/
+
+package generated_tls
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/certgen"
+)
+
+func generatedCertificates(t *testing.T) *certgen.Certificates {
+	ca, err := certgen.GenerateCA("instana-agent CA", time.Now())
+	require.NoError(t, err)
+	serving, err := certgen.GenerateServing(ca, []string{"instana-agent"}, time.Now())
+	require.NoError(t, err)
+	return &certgen.Certificates{CA: ca, Serving: serving}
+}
+
+func TestBuildersSkippedWithoutGeneratedCertificates(t *testing.T) {
+	assertions := require.New(t)
+
+	agent := &instanav1.InstanaAgent{}
+
+	assertions.False(NewSecretBuilder(agent, nil).Build().IsPresent())
+	assertions.False(NewCASecretBuilder(agent, nil).Build().IsPresent())
+	assertions.False(NewCAConfigMapBuilder(agent, nil).Build().IsPresent())
+}
+
+func TestBuilders(t *testing.T) {
+	assertions := require.New(t)
+
+	agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", Namespace: "instana-agent"},
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{TlsSpec: instanav1.TlsSpec{AutoGenerate: true}},
+		},
+	}
+	certificates := generatedCertificates(t)
+
+	for _, bldr := range []interface {
+		IsNamespaced() bool
+		ComponentName() string
+	}{
+		NewSecretBuilder(agent, certificates),
+		NewCASecretBuilder(agent, certificates),
+		NewCAConfigMapBuilder(agent, certificates),
+	} {
+		assertions.True(bldr.IsNamespaced())
+		assertions.Equal("instana-agent", bldr.ComponentName())
+	}
+
+	secret := NewSecretBuilder(agent, certificates).Build().Get().(*corev1.Secret)
+	assertions.Equal("instana-agent-tls", secret.Name)
+	assertions.Equal(corev1.SecretTypeTLS, secret.Type)
+	assertions.Equal(
+		map[string][]byte{
+			corev1.TLSCertKey:       certificates.Serving.CertPEM,
+			corev1.TLSPrivateKeyKey: certificates.Serving.KeyPEM,
+			CACertKey:               certificates.CA.CertPEM,
+		},
+		secret.Data,
+	)
+
+	caSecret := NewCASecretBuilder(agent, certificates).Build().Get().(*corev1.Secret)
+	assertions.Equal("instana-agent-tls-ca", caSecret.Name)
+	assertions.Equal(
+		map[string][]byte{
+			corev1.TLSCertKey:       certificates.CA.CertPEM,
+			corev1.TLSPrivateKeyKey: certificates.CA.KeyPEM,
+		},
+		caSecret.Data,
+	)
+
+	configMap := NewCAConfigMapBuilder(agent, certificates).Build().Get().(*corev1.ConfigMap)
+	assertions.Equal("instana-agent-ca", configMap.Name)
+	assertions.Equal(map[string]string{CACertKey: string(certificates.CA.CertPEM)}, configMap.Data)
+}
+
+func TestBuildersDuringCAOverlap(t *testing.T) {
+	assertions := require.New(t)
+
+	agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", Namespace: "instana-agent"},
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{TlsSpec: instanav1.TlsSpec{AutoGenerate: true}},
+		},
+	}
+	certificates := generatedCertificates(t)
+	ca, err := certgen.GenerateCA("instana-agent CA", time.Now())
+	assertions.NoError(err)
+	certificates.PreviousCA, certificates.CA = certificates.CA, ca
+
+	secret := NewSecretBuilder(agent, certificates).Build().Get().(*corev1.Secret)
+	assertions.Equal(certificates.CABundle(), secret.Data[CACertKey])
+
+	caSecret := NewCASecretBuilder(agent, certificates).Build().Get().(*corev1.Secret)
+	assertions.Equal(
+		map[string][]byte{
+			corev1.TLSCertKey:       ca.CertPEM,
+			corev1.TLSPrivateKeyKey: ca.KeyPEM,
+			PreviousCACertKey:       certificates.PreviousCA.CertPEM,
+			PreviousCAKeyKey:        certificates.PreviousCA.KeyPEM,
+		},
+		caSecret.Data,
+	)
+
+	configMap := NewCAConfigMapBuilder(agent, certificates).Build().Get().(*corev1.ConfigMap)
+	assertions.Equal(
+		string(ca.CertPEM)+string(certificates.PreviousCA.CertPEM),
+		configMap.Data[CACertKey],
+	)
+}
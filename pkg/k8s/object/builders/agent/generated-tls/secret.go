@@ -0,0 +1,137 @@
+/*
+(c) Copyright IBM Corp. 2026
+*/
+
+// Package generated_tls builds the Secrets and the ConfigMap holding the CA and the certificate the operator generates
+// for the agent TLS listener if spec.agent.tls.autoGenerate is set
+package generated_tls
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/certgen"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/builder"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/constants"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/helpers"
+	"github.com/instana/instana-agent-operator/pkg/optional"
+)
+
+const (
+	// CACertKey is the key of the CA certificates in the Secret mounted by the agent and in the published ConfigMap
+	CACertKey = "ca.crt"
+	// PreviousCACertKey and PreviousCAKeyKey hold the rotated CA in the CA Secret until the overlap elapses
+	PreviousCACertKey = "previous.crt"
+	PreviousCAKeyKey  = "previous.key"
+)
+
+// SecretData is the content of the Secret mounted by the agent
+func SecretData(certificates *certgen.Certificates) map[string][]byte {
+	return map[string][]byte{
+		corev1.TLSCertKey:       certificates.Serving.CertPEM,
+		corev1.TLSPrivateKeyKey: certificates.Serving.KeyPEM,
+		CACertKey:               certificates.CABundle(),
+	}
+}
+
+type secretBuilder struct {
+	*instanav1.InstanaAgent
+	helpers.Helpers
+
+	certificates *certgen.Certificates
+}
+
+// NewSecretBuilder builds the Secret holding the generated certificate of the agent, which is mounted by the agent
+func NewSecretBuilder(agent *instanav1.InstanaAgent, certificates *certgen.Certificates) builder.ObjectBuilder {
+	return &secretBuilder{
+		InstanaAgent: agent,
+		Helpers:      helpers.NewHelpers(agent),
+		certificates: certificates,
+	}
+}
+
+func (s *secretBuilder) IsNamespaced() bool {
+	return true
+}
+
+func (s *secretBuilder) ComponentName() string {
+	return constants.ComponentInstanaAgent
+}
+
+func (s *secretBuilder) Build() builder.OptionalObject {
+	if s.certificates == nil {
+		return optional.Empty[client.Object]()
+	}
+
+	return optional.Of[client.Object](
+		&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.TLSSecretName(),
+				Namespace: s.Namespace,
+			},
+			Data: SecretData(s.certificates),
+			Type: corev1.SecretTypeTLS,
+		},
+	)
+}
+
+type caSecretBuilder struct {
+	*instanav1.InstanaAgent
+	helpers.Helpers
+
+	certificates *certgen.Certificates
+}
+
+// NewCASecretBuilder builds the Secret holding the generated CA along with its key, so that the certificate of the
+// agent can be signed again when it is rotated
+func NewCASecretBuilder(agent *instanav1.InstanaAgent, certificates *certgen.Certificates) builder.ObjectBuilder {
+	return &caSecretBuilder{
+		InstanaAgent: agent,
+		Helpers:      helpers.NewHelpers(agent),
+		certificates: certificates,
+	}
+}
+
+func (s *caSecretBuilder) IsNamespaced() bool {
+	return true
+}
+
+func (s *caSecretBuilder) ComponentName() string {
+	return constants.ComponentInstanaAgent
+}
+
+func (s *caSecretBuilder) Build() builder.OptionalObject {
+	if s.certificates == nil {
+		return optional.Empty[client.Object]()
+	}
+
+	data := map[string][]byte{
+		corev1.TLSCertKey:       s.certificates.CA.CertPEM,
+		corev1.TLSPrivateKeyKey: s.certificates.CA.KeyPEM,
+	}
+	if previousCA := s.certificates.PreviousCA; previousCA != nil {
+		data[PreviousCACertKey] = previousCA.CertPEM
+		data[PreviousCAKeyKey] = previousCA.KeyPEM
+	}
+
+	return optional.Of[client.Object](
+		&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.TLSCASecretName(),
+				Namespace: s.Namespace,
+			},
+			Data: data,
+			Type: corev1.SecretTypeTLS,
+		},
+	)
+}
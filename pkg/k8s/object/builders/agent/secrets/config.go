@@ -125,6 +125,11 @@ func (c *configBuilder) data() (map[string][]byte, error) {
 func (c *configBuilder) backendConfig() (map[string][]byte, error) {
 	config := map[string][]byte{}
 
+	// the entrypoint of the agent configures the backend with the key provided through the SecretProviderClass
+	if c.Spec.Agent.SecretProviderClass != "" {
+		return config, nil
+	}
+
 	// render additional backends configuration
 	var backendKey string
 	for i, backend := range c.backends {
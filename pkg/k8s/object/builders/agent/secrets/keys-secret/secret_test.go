@@ -538,3 +538,13 @@ func TestSecretBuilder_BuildWithSecretMounts(t *testing.T) {
 		})
 	}
 }
+
+func TestSecretBuilder_BuildSkippedWithSecretProviderClass(t *testing.T) {
+	agent := &instanav1.InstanaAgent{
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{SecretProviderClass: "instana-vault"},
+		},
+	}
+
+	require.False(t, NewSecretBuilder(agent, make([]backends.K8SensorBackend, 0)).Build().IsPresent())
+}
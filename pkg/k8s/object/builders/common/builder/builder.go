@@ -54,7 +54,7 @@ func (b *builderTransformer) Apply(builder ObjectBuilder) optional.Optional[clie
 		if builder.IsNamespaced() {
 			b.transformations.AddOwnerReference(obj)
 		}
-		return optional.Of(obj)
+		return optional.Of(b.transformations.ApplyFieldOwnership(obj, builder.ComponentName()))
 	default:
 		return opt
 	}
@@ -29,6 +29,13 @@ const (
 	LabelAgentMode = "instana/agent-mode"
 )
 
+// annotations
+const (
+	AnnotationKeysChecksum        = "checksum/keys"
+	AnnotationCredentialsChecksum = "checksum/credentials"
+	AnnotationTLSChecksum         = "checksum/tls"
+)
+
 // keys
 const (
 	AgentKey    = "key"
@@ -46,6 +53,9 @@ const InstanaConfigDirectory = "/opt/instana/agent/etc/instana-config-yml"
 const InstanaNamespacesDetailsDirectory = "/opt/instana/agent/etc/namespaces"
 const InstanaSecretsDirectory = "/opt/instana/agent/etc/instana/secrets"
 
+// SecretsStoreCSIDriver is the name of the Secrets Store CSI driver mounting the objects of a SecretProviderClass
+const SecretsStoreCSIDriver = "secrets-store.csi.k8s.io"
+
 // Secret file names
 const (
 	SecretFileAgentKey                  = "INSTANA_AGENT_KEY"
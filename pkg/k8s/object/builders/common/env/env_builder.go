@@ -188,12 +188,18 @@ func (e *envBuilder) build(envVar EnvVar) *corev1.EnvVar {
 	case MirrorSharedRepoPasswordEnv:
 		return stringToEnvVar(
 			"INSTANA_SHARED_REPOSITORY_MIRROR_PASSWORD",
-			e.agent.Spec.Agent.MirrorSharedRepoPassword,
+			referencedCredential(
+				e.agent.Spec.Agent.MirrorSharedRepoPassword,
+				e.agent.Spec.Agent.MirrorSharedRepoPasswordSecretRef,
+			),
 		)
 	case MirrorReleaseRepoPasswordEnv:
 		return stringToEnvVar(
 			"AGENT_RELEASE_REPOSITORY_MIRROR_PASSWORD",
-			e.agent.Spec.Agent.MirrorReleaseRepoPassword,
+			referencedCredential(
+				e.agent.Spec.Agent.MirrorReleaseRepoPassword,
+				e.agent.Spec.Agent.MirrorReleaseRepoPasswordSecretRef,
+			),
 		)
 	case ProxyHostEnv:
 		return stringToEnvVar("INSTANA_AGENT_PROXY_HOST", e.agent.Spec.Agent.ProxyHost)
@@ -204,7 +210,10 @@ func (e *envBuilder) build(envVar EnvVar) *corev1.EnvVar {
 	case ProxyUserEnv:
 		return stringToEnvVar("INSTANA_AGENT_PROXY_USER", e.agent.Spec.Agent.ProxyUser)
 	case ProxyPasswordEnv:
-		return stringToEnvVar("INSTANA_AGENT_PROXY_PASSWORD", e.agent.Spec.Agent.ProxyPassword)
+		return stringToEnvVar(
+			"INSTANA_AGENT_PROXY_PASSWORD",
+			referencedCredential(e.agent.Spec.Agent.ProxyPassword, e.agent.Spec.Agent.ProxyPasswordSecretRef),
+		)
 	case ProxyUseDNSEnv:
 		return boolToEnvVar("INSTANA_AGENT_PROXY_USE_DNS", e.agent.Spec.Agent.ProxyUseDNS)
 	case ListenAddressEnv:
@@ -240,6 +249,10 @@ func (e *envBuilder) build(envVar EnvVar) *corev1.EnvVar {
 			),
 		}
 	case EntrypointSkipBackendTemplateGeneration:
+		// Only the entrypoint can read the key provided through a SecretProviderClass to configure the backend
+		if e.agent.Spec.Agent.SecretProviderClass != "" {
+			return nil
+		}
 		return &corev1.EnvVar{Name: "ENTRYPOINT_SKIP_BACKEND_TEMPLATE_GENERATION", Value: "true"}
 	case BackendEnv:
 		return e.backendEnv()
@@ -324,7 +337,8 @@ func (e *envBuilder) httpsProxyEnv() *corev1.EnvVar {
 		return nil
 	}
 
-	if e.agent.Spec.Agent.ProxyUser == "" || e.agent.Spec.Agent.ProxyPassword == "" {
+	proxyPassword := referencedCredential(e.agent.Spec.Agent.ProxyPassword, e.agent.Spec.Agent.ProxyPasswordSecretRef)
+	if e.agent.Spec.Agent.ProxyUser == "" || proxyPassword == "" {
 		return &corev1.EnvVar{
 			Name: "HTTPS_PROXY",
 			Value: fmt.Sprintf(
@@ -341,7 +355,7 @@ func (e *envBuilder) httpsProxyEnv() *corev1.EnvVar {
 		Value: fmt.Sprintf(
 			"%s://%s%s:%s",
 			optional.Of(e.agent.Spec.Agent.ProxyProtocol).GetOrDefault("http"),
-			e.agent.Spec.Agent.ProxyUser+":"+e.agent.Spec.Agent.ProxyPassword+"@",
+			e.agent.Spec.Agent.ProxyUser+":"+proxyPassword+"@",
 			e.agent.Spec.Agent.ProxyHost,
 			optional.Of(e.agent.Spec.Agent.ProxyPort).GetOrDefault("80"),
 		),
@@ -413,6 +427,15 @@ func (e *envBuilder) getUserProvidedEnvs() []corev1.EnvVar {
 	return envVars
 }
 
+// referencedCredential returns the credential unless it was resolved from a Secret reference, referenced credentials
+// are only ever mounted from the keys secret and never rendered into plain environment variables
+func referencedCredential(val string, ref *corev1.SecretKeySelector) string {
+	if ref != nil {
+		return ""
+	}
+	return val
+}
+
 func stringToEnvVar(name string, val string) *corev1.EnvVar {
 	if val == "" {
 		return nil
@@ -379,3 +379,49 @@ func TestEnvBuilderBuild(t *testing.T) {
 		)
 	}
 }
+
+func TestEnvBuilderLeavesBackendTemplateGenerationToEntrypointWithSecretProviderClass(t *testing.T) {
+	assertions := require.New(t)
+
+	agent := &instanav1.InstanaAgent{
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{SecretProviderClass: "instana-vault"},
+		},
+	}
+
+	assertions.Empty(NewEnvBuilder(agent, nil).Build(EntrypointSkipBackendTemplateGeneration))
+}
+
+func TestEnvBuilderNeverRendersReferencedCredentials(t *testing.T) {
+	assertions := require.New(t)
+
+	ref := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+		Key:                  "password",
+	}
+	agent := &instanav1.InstanaAgent{
+		Spec: instanav1.InstanaAgentSpec{
+			UseSecretMounts: pointer.To(false),
+			Agent: instanav1.BaseAgentSpec{
+				ProxyHost:                          "proxy",
+				ProxyUser:                          "user",
+				ProxyPassword:                      "proxy-password",
+				ProxyPasswordSecretRef:             ref,
+				MirrorReleaseRepoPassword:          "release-password",
+				MirrorReleaseRepoPasswordSecretRef: ref,
+				MirrorSharedRepoPassword:           "shared-password",
+				MirrorSharedRepoPasswordSecretRef:  ref,
+			},
+		},
+	}
+
+	assertions.Equal(
+		[]corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://proxy:80"}},
+		NewEnvBuilder(agent, nil).Build(
+			ProxyPasswordEnv,
+			HTTPSProxyEnv,
+			MirrorReleaseRepoPasswordEnv,
+			MirrorSharedRepoPasswordEnv,
+		),
+	)
+}
@@ -155,9 +155,21 @@ func (e *envBuilderRemote) buildRemote(envVar EnvVarRemote) *corev1.EnvVar {
 	case MirrorSharedRepoUsernameEnvRemote:
 		return stringToEnvVar("INSTANA_SHARED_REPOSITORY_MIRROR_USERNAME", e.agent.Spec.Agent.MirrorSharedRepoUsername)
 	case MirrorSharedRepoPasswordEnvRemote:
-		return stringToEnvVar("INSTANA_SHARED_REPOSITORY_MIRROR_PASSWORD", e.agent.Spec.Agent.MirrorSharedRepoPassword)
+		return stringToEnvVar(
+			"INSTANA_SHARED_REPOSITORY_MIRROR_PASSWORD",
+			referencedCredential(
+				e.agent.Spec.Agent.MirrorSharedRepoPassword,
+				e.agent.Spec.Agent.MirrorSharedRepoPasswordSecretRef,
+			),
+		)
 	case MirrorReleaseRepoPasswordEnvRemote:
-		return stringToEnvVar("AGENT_RELEASE_REPOSITORY_MIRROR_PASSWORD", e.agent.Spec.Agent.MirrorReleaseRepoPassword)
+		return stringToEnvVar(
+			"AGENT_RELEASE_REPOSITORY_MIRROR_PASSWORD",
+			referencedCredential(
+				e.agent.Spec.Agent.MirrorReleaseRepoPassword,
+				e.agent.Spec.Agent.MirrorReleaseRepoPasswordSecretRef,
+			),
+		)
 	case ProxyHostEnvRemote:
 		return stringToEnvVar("INSTANA_AGENT_PROXY_HOST", e.agent.Spec.Agent.ProxyHost)
 	case ProxyPortEnvRemote:
@@ -167,7 +179,10 @@ func (e *envBuilderRemote) buildRemote(envVar EnvVarRemote) *corev1.EnvVar {
 	case ProxyUserEnvRemote:
 		return stringToEnvVar("INSTANA_AGENT_PROXY_USER", e.agent.Spec.Agent.ProxyUser)
 	case ProxyPasswordEnvRemote:
-		return stringToEnvVar("INSTANA_AGENT_PROXY_PASSWORD", e.agent.Spec.Agent.ProxyPassword)
+		return stringToEnvVar(
+			"INSTANA_AGENT_PROXY_PASSWORD",
+			referencedCredential(e.agent.Spec.Agent.ProxyPassword, e.agent.Spec.Agent.ProxyPasswordSecretRef),
+		)
 	case ProxyUseDNSEnvRemote:
 		return boolToEnvVar("INSTANA_AGENT_PROXY_USE_DNS", e.agent.Spec.Agent.ProxyUseDNS)
 	case ListenAddressEnvRemote:
@@ -188,6 +203,10 @@ func (e *envBuilderRemote) buildRemote(envVar EnvVarRemote) *corev1.EnvVar {
 	case ConfigPathEnvRemote:
 		return &corev1.EnvVar{Name: "CONFIG_PATH", Value: volume.RemoteConfigDirectory}
 	case EntrypointSkipBackendTemplateGenerationRemote:
+		// Only the entrypoint can read the key provided through a SecretProviderClass to configure the backend
+		if e.agent.Spec.Agent.SecretProviderClass != "" {
+			return nil
+		}
 		return &corev1.EnvVar{Name: "ENTRYPOINT_SKIP_BACKEND_TEMPLATE_GENERATION", Value: "true"}
 	case BackendEnvRemote:
 		return e.backendEnv()
@@ -236,7 +255,8 @@ func (e *envBuilderRemote) httpsProxyEnv() *corev1.EnvVar {
 		return nil
 	}
 
-	if e.agent.Spec.Agent.ProxyUser == "" || e.agent.Spec.Agent.ProxyPassword == "" {
+	proxyPassword := referencedCredential(e.agent.Spec.Agent.ProxyPassword, e.agent.Spec.Agent.ProxyPasswordSecretRef)
+	if e.agent.Spec.Agent.ProxyUser == "" || proxyPassword == "" {
 		return &corev1.EnvVar{
 			Name: "HTTPS_PROXY",
 			Value: fmt.Sprintf(
@@ -253,7 +273,7 @@ func (e *envBuilderRemote) httpsProxyEnv() *corev1.EnvVar {
 		Value: fmt.Sprintf(
 			"%s://%s%s:%s",
 			optional.Of(e.agent.Spec.Agent.ProxyProtocol).GetOrDefault("http"),
-			e.agent.Spec.Agent.ProxyUser+":"+e.agent.Spec.Agent.ProxyPassword+"@",
+			e.agent.Spec.Agent.ProxyUser+":"+proxyPassword+"@",
 			e.agent.Spec.Agent.ProxyHost,
 			optional.Of(e.agent.Spec.Agent.ProxyPort).GetOrDefault("80"),
 		),
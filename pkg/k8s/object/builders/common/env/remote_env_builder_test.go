@@ -253,3 +253,37 @@ func TestRemoteEnvBuilderBuild(t *testing.T) {
 		)
 	}
 }
+
+func TestRemoteEnvBuilderNeverRendersReferencedCredentials(t *testing.T) {
+	assertions := require.New(t)
+
+	ref := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+		Key:                  "password",
+	}
+	agent := &instanav1.InstanaAgentRemote{
+		Spec: instanav1.InstanaAgentRemoteSpec{
+			UseSecretMounts: pointer.To(false),
+			Agent: instanav1.BaseAgentSpec{
+				ProxyHost:                          "proxy",
+				ProxyUser:                          "user",
+				ProxyPassword:                      "proxy-password",
+				ProxyPasswordSecretRef:             ref,
+				MirrorReleaseRepoPassword:          "release-password",
+				MirrorReleaseRepoPasswordSecretRef: ref,
+				MirrorSharedRepoPassword:           "shared-password",
+				MirrorSharedRepoPasswordSecretRef:  ref,
+			},
+		},
+	}
+
+	assertions.Equal(
+		[]corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://proxy:80"}},
+		NewEnvBuilderRemote(agent, nil).Build(
+			ProxyPasswordEnvRemote,
+			HTTPSProxyEnvRemote,
+			MirrorReleaseRepoPasswordEnvRemote,
+			MirrorSharedRepoPasswordEnvRemote,
+		),
+	)
+}
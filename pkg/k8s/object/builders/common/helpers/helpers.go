@@ -28,6 +28,9 @@ type Helpers interface {
 	ServiceAccountName() string
 	TLSIsEnabled() bool
 	TLSSecretName() string
+	TLSCASecretName() string
+	TLSCAConfigMapName() string
+	ServiceDNSNames() []string
 	HeadlessServiceName() string
 	K8sSensorResourcesName() string
 	ContainersSecretName() string
@@ -50,13 +53,39 @@ func (h *helpers) ServiceAccountName() string {
 }
 
 func (h *helpers) TLSIsEnabled() bool {
-	return h.Spec.Agent.TlsSpec.SecretName != "" || (len(h.Spec.Agent.TlsSpec.Certificate) > 0 && len(h.Spec.Agent.TlsSpec.Key) > 0)
+	tls := h.Spec.Agent.TlsSpec
+	return tls.SecretName != "" || tls.CertManager != nil || tls.AutoGenerate ||
+		(len(tls.Certificate) > 0 && len(tls.Key) > 0)
 }
 
 func (h *helpers) TLSSecretName() string {
 	return optional.Of(h.Spec.Agent.TlsSpec.SecretName).GetOrDefault(h.Name + "-tls")
 }
 
+// TLSCASecretName is the Secret holding the CA that signs the generated certificate of the agent
+func (h *helpers) TLSCASecretName() string {
+	return h.Name + "-tls-ca"
+}
+
+// TLSCAConfigMapName is the ConfigMap publishing the CA of the generated certificate of the agent to its clients
+func (h *helpers) TLSCAConfigMapName() string {
+	return h.Name + "-ca"
+}
+
+// ServiceDNSNames are the names the agent Service and headless Service can be reached by from within the cluster
+func (h *helpers) ServiceDNSNames() []string {
+	var dnsNames []string
+	for _, service := range []string{h.Name, h.HeadlessServiceName()} {
+		dnsNames = append(
+			dnsNames,
+			service,
+			service+"."+h.Namespace,
+			service+"."+h.Namespace+".svc",
+		)
+	}
+	return dnsNames
+}
+
 func (h *helpers) HeadlessServiceName() string {
 	return h.Name + "-headless"
 }
@@ -75,7 +104,8 @@ func (h *helpers) UseContainersSecret() bool {
 	// (original logic was to only use the generated secret if the registry matches AND the pullSecrets field was
 	// omitted by the user). I don't understand why anyone would want this, but the original chart had comments
 	// specifically mentioning that this was the desired behavior, so keeping it until someone says otherwise.
-	return h.Spec.Agent.PullSecrets == nil && strings.HasPrefix(
+	// With a SecretProviderClass the download key is not known to the operator, so the pull secrets must be provided
+	return h.Spec.Agent.PullSecrets == nil && h.Spec.Agent.SecretProviderClass == "" && strings.HasPrefix(
 		h.Spec.Agent.ImageSpec.Name,
 		ContainersInstanaIORegistry,
 	)
@@ -97,15 +97,27 @@ func TestHelpers_ServiceAccountName(t *testing.T) {
 
 func TestHelpers_TLSIsEnabled(t *testing.T) {
 	for _, test := range []struct {
-		name        string
-		secretName  string
-		certificate string
-		key         string
-		expected    bool
+		name         string
+		secretName   string
+		certificate  string
+		key          string
+		certManager  *instanav1.CertManagerSpec
+		autoGenerate bool
+		expected     bool
 	}{
 		{
 			name: "all_empty",
 		},
+		{
+			name:        "cert_manager_filled",
+			certManager: &instanav1.CertManagerSpec{IssuerRef: instanav1.CertManagerIssuerRef{Name: "issuer"}},
+			expected:    true,
+		},
+		{
+			name:         "auto_generate",
+			autoGenerate: true,
+			expected:     true,
+		},
 		{
 			name:       "secret_name_filled",
 			secretName: "adsfasg",
@@ -154,9 +166,11 @@ func TestHelpers_TLSIsEnabled(t *testing.T) {
 						Spec: instanav1.InstanaAgentSpec{
 							Agent: instanav1.BaseAgentSpec{
 								TlsSpec: instanav1.TlsSpec{
-									SecretName:  test.secretName,
-									Certificate: []byte(test.certificate),
-									Key:         []byte(test.key),
+									SecretName:   test.secretName,
+									Certificate:  []byte(test.certificate),
+									Key:          []byte(test.key),
+									CertManager:  test.certManager,
+									AutoGenerate: test.autoGenerate,
 								},
 							},
 						},
@@ -227,6 +241,44 @@ func TestHelpers_HeadlessServiceName(t *testing.T) {
 	assertions.Equal("rhjaoijdsoijoidsf-headless", h.HeadlessServiceName())
 }
 
+func TestHelpers_TLSCANames(t *testing.T) {
+	assertions := require.New(t)
+
+	h := NewHelpers(
+		&instanav1.InstanaAgent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "rhjaoijdsoijoidsf",
+			},
+		},
+	)
+	assertions.Equal("rhjaoijdsoijoidsf-tls-ca", h.TLSCASecretName())
+	assertions.Equal("rhjaoijdsoijoidsf-ca", h.TLSCAConfigMapName())
+}
+
+func TestHelpers_ServiceDNSNames(t *testing.T) {
+	assertions := require.New(t)
+
+	h := NewHelpers(
+		&instanav1.InstanaAgent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "instana-agent",
+				Namespace: "agents",
+			},
+		},
+	)
+	assertions.Equal(
+		[]string{
+			"instana-agent",
+			"instana-agent.agents",
+			"instana-agent.agents.svc",
+			"instana-agent-headless",
+			"instana-agent-headless.agents",
+			"instana-agent-headless.agents.svc",
+		},
+		h.ServiceDNSNames(),
+	)
+}
+
 func TestHelpers_K8sSensorResourcesName(t *testing.T) {
 	assertions := require.New(t)
 
@@ -261,6 +313,7 @@ func TestHelpers_UseContainersSecret(t *testing.T) {
 		name                    string
 		userProvidedPullSecrets []corev1.LocalObjectReference
 		imageName               string
+		secretProviderClass     string
 		expected                bool
 	}{
 		{
@@ -313,6 +366,13 @@ func TestHelpers_UseContainersSecret(t *testing.T) {
 			imageName: "containers.instana.io/" + rand.String(rand.IntnRange(1, 15)),
 			expected:  false,
 		},
+		{
+			name:                    "nil_secrets_image_has_prefix_secret_provider_class",
+			userProvidedPullSecrets: nil,
+			imageName:               "containers.instana.io/" + rand.String(rand.IntnRange(1, 15)),
+			secretProviderClass:     "instana-vault",
+			expected:                false,
+		},
 	} {
 		t.Run(
 			test.name, func(t *testing.T) {
@@ -325,6 +385,7 @@ func TestHelpers_UseContainersSecret(t *testing.T) {
 						},
 						Spec: instanav1.InstanaAgentSpec{
 							Agent: instanav1.BaseAgentSpec{
+								SecretProviderClass: test.secretProviderClass,
 								ExtendedImageSpec: instanav1.ExtendedImageSpec{
 									PullSecrets: test.userProvidedPullSecrets,
 									ImageSpec: instanav1.ImageSpec{
@@ -73,7 +73,8 @@ func (h *remoteHelpers) UseContainersSecret() bool {
 	// (original logic was to only use the generated secret if the registry matches AND the pullSecrets field was
 	// omitted by the user). I don't understand why anyone would want this, but the original chart had comments
 	// specifically mentioning that this was the desired behavior, so keeping it until someone says otherwise.
-	return h.Spec.Agent.PullSecrets == nil && strings.HasPrefix(
+	// With a SecretProviderClass the download key is not known to the operator, so the pull secrets must be provided
+	return h.Spec.Agent.PullSecrets == nil && h.Spec.Agent.SecretProviderClass == "" && strings.HasPrefix(
 		h.Spec.Agent.ImageSpec.Name,
 		ContainersInstanaIORegistry,
 	)
@@ -5,6 +5,11 @@
 
 package namespaces
 
+import corev1 "k8s.io/api/core/v1"
+
+// WorkloadMonitoringLabel marks the namespaces whose details are provided to the agents
+const WorkloadMonitoringLabel = "instana-workload-monitoring"
+
 // File content to mount into the agent daemonset at /opt/instana/agent/etc/namespaces/namespaces.yaml (provided as env var)
 // An empty file will look like below
 /*
@@ -27,3 +32,23 @@ type NamespacesDetails struct {
 type NamespaceMetadata struct {
 	Labels map[string]string `json:"labels"`
 }
+
+// NewNamespacesDetails returns the details of all given namespaces carrying the WorkloadMonitoringLabel
+func NewNamespacesDetails(namespaceList []corev1.Namespace) NamespacesDetails {
+	namespacesMap := make(map[string]NamespaceMetadata)
+
+	for _, ns := range namespaceList {
+		value, ok := ns.Labels[WorkloadMonitoringLabel]
+		if !ok {
+			continue
+		}
+		namespacesMap[ns.Name] = NamespaceMetadata{
+			Labels: map[string]string{WorkloadMonitoringLabel: value},
+		}
+	}
+
+	return NamespacesDetails{
+		Version:    1,
+		Namespaces: namespacesMap,
+	}
+}
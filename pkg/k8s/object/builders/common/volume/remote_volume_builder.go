@@ -141,6 +141,10 @@ func (v *volumeBuilderRemote) secretsVolume() (*corev1.Volume, *corev1.VolumeMou
 		return nil, nil
 	}
 
+	if v.remoteAgent.Spec.Agent.SecretProviderClass != "" {
+		return secretsStoreCSIVolume(v.remoteAgent.Spec.Agent.SecretProviderClass)
+	}
+
 	volumeName := "instana-secrets"
 	secretName := v.remoteAgent.Spec.Agent.KeysSecret
 	if secretName == "" {
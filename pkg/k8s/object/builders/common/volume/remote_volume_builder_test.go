@@ -208,3 +208,22 @@ func TestRemoteVolumeBuilder_SecretsVolume(t *testing.T) {
 }
 
 // Made with Bob
+
+func TestRemoteVolumeBuilder_SecretsVolumeFromSecretProviderClass(t *testing.T) {
+	agent := &instanav1.InstanaAgentRemote{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-agent"},
+		Spec: instanav1.InstanaAgentRemoteSpec{
+			Agent: instanav1.BaseAgentSpec{SecretProviderClass: "instana-vault"},
+		},
+	}
+
+	volumes, mounts := NewVolumeBuilderRemote(agent).Build(SecretsVolumeRemote)
+
+	require.Len(t, volumes, 1)
+	require.Len(t, mounts, 1)
+	assert.Nil(t, volumes[0].Secret)
+	require.NotNil(t, volumes[0].CSI)
+	assert.Equal(t, constants.SecretsStoreCSIDriver, volumes[0].CSI.Driver)
+	assert.Equal(t, "instana-vault", volumes[0].CSI.VolumeAttributes["secretProviderClass"])
+	assert.Equal(t, constants.InstanaSecretsDirectory, mounts[0].MountPath)
+}
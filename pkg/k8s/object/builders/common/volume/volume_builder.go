@@ -293,6 +293,10 @@ func (v *volumeBuilder) k8sensorSecretsVolume() (*corev1.Volume, *corev1.VolumeM
 		return nil, nil
 	}
 
+	if v.instanaAgent.Spec.Agent.SecretProviderClass != "" {
+		return secretsStoreCSIVolume(v.instanaAgent.Spec.Agent.SecretProviderClass)
+	}
+
 	volumeName := "instana-secrets"
 	secretName := v.instanaAgent.Spec.Agent.KeysSecret
 	if secretName == "" {
@@ -350,6 +354,10 @@ func (v *volumeBuilder) secretsVolume() (*corev1.Volume, *corev1.VolumeMount) {
 		return nil, nil
 	}
 
+	if v.instanaAgent.Spec.Agent.SecretProviderClass != "" {
+		return secretsStoreCSIVolume(v.instanaAgent.Spec.Agent.SecretProviderClass)
+	}
+
 	volumeName := "instana-secrets"
 	secretName := v.instanaAgent.Spec.Agent.KeysSecret
 	if secretName == "" {
@@ -384,6 +392,28 @@ func (v *volumeBuilder) secretsVolume() (*corev1.Volume, *corev1.VolumeMount) {
 	return &volume, &volumeMount
 }
 
+// secretsStoreCSIVolume mounts the objects of the SecretProviderClass in place of the secrets volume
+func secretsStoreCSIVolume(secretProviderClass string) (*corev1.Volume, *corev1.VolumeMount) {
+	volumeName := "instana-secrets"
+	volume := corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			CSI: &corev1.CSIVolumeSource{
+				Driver:           constants.SecretsStoreCSIDriver,
+				ReadOnly:         pointer.To(true),
+				VolumeAttributes: map[string]string{"secretProviderClass": secretProviderClass},
+			},
+		},
+	}
+	volumeMount := corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: constants.InstanaSecretsDirectory,
+		ReadOnly:  true,
+	}
+
+	return &volume, &volumeMount
+}
+
 func (v *volumeBuilder) etcdCAVolume() (*corev1.Volume, *corev1.VolumeMount) {
 	if v.instanaAgent.Spec.K8sSensor.ETCD.CA.SecretName == "" {
 		// For OpenShift, use the etcd-metrics-ca-bundle ConfigMap from openshift-etcd namespace
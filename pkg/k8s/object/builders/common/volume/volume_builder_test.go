@@ -23,8 +23,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/constants"
 	"github.com/instana/instana-agent-operator/pkg/pointer"
 )
 
@@ -417,3 +419,39 @@ func TestVolumeBuilderK8SensorSecretsVolume(t *testing.T) {
 		)
 	}
 }
+
+func TestVolumeBuilderSecretsVolumesFromSecretProviderClass(t *testing.T) {
+	agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent"},
+		Spec: instanav1.InstanaAgentSpec{
+			UseSecretMounts: pointer.To(true),
+			Agent:           instanav1.BaseAgentSpec{SecretProviderClass: "instana-vault"},
+		},
+	}
+
+	assertions := require.New(t)
+	for _, vol := range []Volume{SecretsVolume, K8SensorSecretsVolume} {
+		volumes, volumeMounts := NewVolumeBuilder(agent, false).Build(vol)
+		assertions.Equal(
+			[]corev1.Volume{
+				{
+					Name: "instana-secrets",
+					VolumeSource: corev1.VolumeSource{
+						CSI: &corev1.CSIVolumeSource{
+							Driver:           constants.SecretsStoreCSIDriver,
+							ReadOnly:         pointer.To(true),
+							VolumeAttributes: map[string]string{"secretProviderClass": "instana-vault"},
+						},
+					},
+				},
+			},
+			volumes,
+		)
+		assertions.Equal(
+			[]corev1.VolumeMount{
+				{Name: "instana-secrets", MountPath: constants.InstanaSecretsDirectory, ReadOnly: true},
+			},
+			volumeMounts,
+		)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"maps"
+	"net"
 	"path"
 	"regexp"
 	"strings"
@@ -362,12 +363,17 @@ func (d *deploymentBuilder) Build() (res optional.Optional[client.Object]) {
 	defer func() {
 		res.IfPresent(
 			func(dpl client.Object) {
-				d.statusManager.SetK8sSensorDeployment(client.ObjectKeyFromObject(dpl))
+				d.statusManager.AddK8sSensorDeployment(
+					client.ObjectKeyFromObject(dpl),
+					net.JoinHostPort(d.backend.EndpointHost, d.backend.EndpointPort),
+				)
 			},
 		)
 	}()
 
-	switch (d.Spec.Agent.Key == "" && d.Spec.Agent.KeysSecret == "") ||
+	switch (d.Spec.Agent.Key == "" &&
+		d.Spec.Agent.KeysSecret == "" &&
+		d.Spec.Agent.SecretProviderClass == "") ||
 		(d.Spec.Zone.Name == "" && d.Spec.Cluster.Name == "") ||
 		!pointer.DerefOrDefault(d.Spec.K8sSensor.DeploymentSpec.Enabled.Enabled, true) {
 	case true:
@@ -403,7 +409,7 @@ func NewDeploymentBuilder(
 
 const (
 	// PollRateRegex defines the validation pattern for pollrate values (seconds only)
-	PollRateRegex = `^[0-9]+s$`
+	PollRateRegex = instanav1.PollRateRegex
 	// DefaultPollRate is the default polling rate for k8sensor
 	DefaultPollRate = "10s"
 )
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
@@ -27,8 +28,8 @@ type MockStatusManager struct {
 	mock.Mock
 }
 
-func (m *MockStatusManager) SetK8sSensorDeployment(key client.ObjectKey) {
-	m.Called(key)
+func (m *MockStatusManager) AddK8sSensorDeployment(key client.ObjectKey, endpoint string) {
+	m.Called(key, endpoint)
 }
 
 func (m *MockStatusManager) AddAgentDaemonset(agentDaemonset client.ObjectKey) {
@@ -47,11 +48,40 @@ func (m *MockStatusManager) SetAgentNamespacesConfigMap(agentNamespacesConfigmap
 	m.Called(agentNamespacesConfigmap)
 }
 
+func (m *MockStatusManager) SetConfigurationValidation(configurationErrs field.ErrorList) {
+	m.Called(configurationErrs)
+}
+
+func (m *MockStatusManager) SetInventory(inventory []instanav1.ResourceInventoryEntry) {
+	m.Called(inventory)
+}
+
+func (m *MockStatusManager) SetPreview(preview *instanav1.PreviewStatus) {
+	m.Called(preview)
+}
+
+func (m *MockStatusManager) SetReconcilePaused(paused bool) {
+	m.Called(paused)
+}
+
 func (m *MockStatusManager) UpdateAgentStatus(ctx context.Context, reconcileErr error) error {
 	args := m.Called(ctx, reconcileErr)
 	return args.Error(0)
 }
 
+func (m *MockStatusManager) SetETCDMonitoring(etcdMonitoring *instanav1.ETCDMonitoringStatus) {
+	m.Called(etcdMonitoring)
+}
+
+func (m *MockStatusManager) SetTLS(tls *instanav1.TLSStatus) {
+	m.Called(tls)
+}
+
+func (m *MockStatusManager) UpdateAvailability(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 type MockHelpers struct {
 	mock.Mock
 }
@@ -80,6 +110,21 @@ func (m *MockHelpers) TLSSecretName() string {
 	return args.String(0)
 }
 
+func (m *MockHelpers) TLSCASecretName() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockHelpers) TLSCAConfigMapName() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockHelpers) ServiceDNSNames() []string {
+	args := m.Called()
+	return args.Get(0).([]string)
+}
+
 func (m *MockHelpers) ServiceAccountName() string {
 	args := m.Called()
 	return args.String(0)
@@ -651,7 +696,7 @@ func TestBuildDeploymentWithSecretMountsEnabled(t *testing.T) {
 	builder.VolumeBuilder.(*MockVolumeBuilder).On("Build", mock.Anything).
 		Return([]corev1.Volume{configVolume, secretsVolume}, []corev1.VolumeMount{configMount, secretsMount})
 
-	builder.statusManager.(*MockStatusManager).On("SetK8sSensorDeployment", mock.Anything).Return()
+	builder.statusManager.(*MockStatusManager).On("AddK8sSensorDeployment", mock.Anything, mock.Anything).Return()
 
 	// Act
 	deploymentObj := builder.build()
@@ -709,7 +754,7 @@ func TestBuildDeploymentWithSecretMountsDisabled(t *testing.T) {
 	builder.VolumeBuilder.(*MockVolumeBuilder).On("Build", mock.Anything).
 		Return([]corev1.Volume{configVolume}, []corev1.VolumeMount{configMount})
 
-	builder.statusManager.(*MockStatusManager).On("SetK8sSensorDeployment", mock.Anything).Return()
+	builder.statusManager.(*MockStatusManager).On("AddK8sSensorDeployment", mock.Anything, mock.Anything).Return()
 
 	// Override the mock setup to include AGENT_KEY
 	mockEnvBuilder := new(MockEnvBuilder)
@@ -814,7 +859,7 @@ func TestBuild(t *testing.T) {
 	builder.VolumeBuilder.(*MockVolumeBuilder).On("Build", mock.Anything).
 		Return([]corev1.Volume{configVolume, secretsVolume}, []corev1.VolumeMount{configMount, secretsMount})
 
-	builder.statusManager.(*MockStatusManager).On("SetK8sSensorDeployment", mock.Anything).Return()
+	builder.statusManager.(*MockStatusManager).On("AddK8sSensorDeployment", mock.Anything, mock.Anything).Return()
 
 	// Act
 	result := builder.Build()
@@ -867,7 +912,7 @@ func TestBuildEnabledIsNotSet(t *testing.T) {
 	builder.VolumeBuilder.(*MockVolumeBuilder).On("Build", mock.Anything).
 		Return([]corev1.Volume{configVolume, secretsVolume}, []corev1.VolumeMount{configMount, secretsMount})
 
-	builder.statusManager.(*MockStatusManager).On("SetK8sSensorDeployment", mock.Anything).Return()
+	builder.statusManager.(*MockStatusManager).On("AddK8sSensorDeployment", mock.Anything, mock.Anything).Return()
 
 	// Act
 	result := builder.Build()
@@ -954,7 +999,7 @@ func TestBuildDeploymentWithProxyConfiguration(t *testing.T) {
 	builder.VolumeBuilder.(*MockVolumeBuilder).On("Build", mock.Anything).
 		Return([]corev1.Volume{configVolume, secretsVolume}, []corev1.VolumeMount{configMount, secretsMount})
 
-	builder.statusManager.(*MockStatusManager).On("SetK8sSensorDeployment", mock.Anything).Return()
+	builder.statusManager.(*MockStatusManager).On("AddK8sSensorDeployment", mock.Anything, mock.Anything).Return()
 
 	// Act
 	deploymentObj := builder.build()
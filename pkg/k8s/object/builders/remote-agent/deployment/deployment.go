@@ -17,6 +17,8 @@ package deployment
 
 import (
 	"fmt"
+	"maps"
+	"net"
 	"regexp"
 	"strings"
 
@@ -55,6 +57,8 @@ type deploymentBuilder struct {
 	backend    backends.RemoteSensorBackend
 	keysSecret *corev1.Secret
 	zone       *instanav1.Zone
+	// credentialsVersions maps the Secrets holding the referenced credentials to their resourceVersion
+	credentialsVersions map[string]string
 }
 
 func (d *deploymentBuilder) ComponentName() string {
@@ -245,6 +249,24 @@ func (d *deploymentBuilder) getLivenessProbe() *corev1.Probe {
 	}
 }
 
+func (d *deploymentBuilder) getPodAnnotations() map[string]string {
+	// credentials referenced in secrets are resolved into the spec by the reconciler, but the CR does not change when
+	// they are rotated. Only the versions of the secrets are hashed, so that the checksum reveals nothing about the
+	// credentials.
+	agent := &d.Spec.Agent
+	if agent.ProxyPasswordSecretRef == nil &&
+		agent.MirrorReleaseRepoPasswordSecretRef == nil &&
+		agent.MirrorSharedRepoPasswordSecretRef == nil {
+		return agent.Pod.Annotations
+	}
+
+	// Deep copy annotations to extend them with a checksum
+	annotations := make(map[string]string, len(agent.Pod.Annotations)+1)
+	maps.Copy(annotations, agent.Pod.Annotations)
+	annotations[constants.AnnotationCredentialsChecksum] = d.HashJsonOrDie(d.credentialsVersions)
+	return annotations
+}
+
 func (d *deploymentBuilder) build() *appsv1.Deployment {
 	volumes, volumeMounts := d.getVolumes()
 	userVolumes, userVolumeMounts := d.getUserVolumes()
@@ -268,7 +290,7 @@ func (d *deploymentBuilder) build() *appsv1.Deployment {
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      d.getPodTemplateLabels(),
-					Annotations: d.InstanaAgentRemote.Spec.Agent.Pod.Annotations,
+					Annotations: d.getPodAnnotations(),
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: "instana-agent-remote",
@@ -304,13 +326,16 @@ func (d *deploymentBuilder) Build() (res optional.Optional[client.Object]) {
 	defer func() {
 		res.IfPresent(
 			func(dp client.Object) {
-				d.statusManager.AddAgentDeployment(client.ObjectKeyFromObject(dp))
+				d.statusManager.AddAgentDeployment(
+					client.ObjectKeyFromObject(dp),
+					net.JoinHostPort(d.backend.EndpointHost, d.backend.EndpointPort),
+				)
 			},
 		)
 	}()
 
 	switch {
-	case d.Spec.Agent.Key == "" && d.Spec.Agent.KeysSecret == "":
+	case d.Spec.Agent.Key == "" && d.Spec.Agent.KeysSecret == "" && d.Spec.Agent.SecretProviderClass == "":
 		fallthrough
 	case d.zone == nil && d.Spec.Zone.Name == "":
 		fallthrough
@@ -326,15 +351,18 @@ func NewDeploymentBuilder(
 	statusManager status.InstanaAgentRemoteStatusManager,
 	backend backends.RemoteSensorBackend,
 	keysSecret *corev1.Secret,
+	credentialsVersions map[string]string,
 ) builder.ObjectBuilder {
 	return &deploymentBuilder{
 		InstanaAgentRemote:              agent,
 		statusManager:                   statusManager,
 		RemoteHelpers:                   helpers.NewRemoteHelpers(agent),
 		PodSelectorLabelGeneratorRemote: transformations.PodSelectorLabelsRemote(agent, componentName),
+		JsonHasher:                      hash.NewJsonHasher(),
 		EnvBuilderRemote:                env.NewEnvBuilderRemote(agent, nil),
 		VolumeBuilderRemote:             volume.NewVolumeBuilderRemote(agent),
 		backend:                         backend,
 		keysSecret:                      keysSecret,
+		credentialsVersions:             credentialsVersions,
 	}
 }
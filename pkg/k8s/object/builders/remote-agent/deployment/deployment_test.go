@@ -30,6 +30,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -258,7 +259,7 @@ func TestDeploymentBuilder_IsNamespaced_ComponentName(t *testing.T) {
 	assertions := assert.New(t)
 
 	emptyBackend := backend.RemoteSensorBackend{}
-	dBuilder := NewDeploymentBuilder(nil, nil, emptyBackend, nil)
+	dBuilder := NewDeploymentBuilder(nil, nil, emptyBackend, nil, nil)
 
 	assertions.True(dBuilder.IsNamespaced())
 	assertions.Equal(constants.ComponentInstanaAgentRemote, dBuilder.ComponentName())
@@ -411,11 +412,11 @@ func TestDeploymentBuilder_Build(t *testing.T) {
 			status := &mocks.MockRemoteAgentStatusManager{}
 			defer status.AssertExpectations(t)
 			if test.expectPresent {
-				status.On("AddAgentDeployment", mock.Anything)
+				status.On("AddAgentDeployment", mock.Anything, mock.Anything)
 			}
 
 			emptyBackend := backend.RemoteSensorBackend{}
-			dBuilder := NewDeploymentBuilder(test.agent, status, emptyBackend, nil)
+			dBuilder := NewDeploymentBuilder(test.agent, status, emptyBackend, nil, nil)
 
 			result := dBuilder.Build()
 			assertions.Equal(test.expectPresent, result.IsPresent())
@@ -423,6 +424,47 @@ func TestDeploymentBuilder_Build(t *testing.T) {
 	}
 }
 
+func TestDeploymentBuilder_CredentialsChecksum(t *testing.T) {
+	assertions := require.New(t)
+
+	agent := &instanav1.InstanaAgentRemote{
+		Spec: instanav1.InstanaAgentRemoteSpec{
+			Agent: instanav1.BaseAgentSpec{
+				Key:           "key",
+				ProxyPassword: "proxy-password",
+				Pod:           instanav1.AgentPodSpec{Annotations: map[string]string{"user": "annotation"}},
+			},
+			Zone: instanav1.Name{Name: "zone-a"},
+		},
+	}
+	credentialsVersions := map[string]string{"proxy": "1"}
+	podAnnotations := func() map[string]string {
+		status := &mocks.MockRemoteAgentStatusManager{}
+		status.On("AddAgentDeployment", mock.Anything, mock.Anything)
+		deployment := NewDeploymentBuilder(agent, status, backend.RemoteSensorBackend{}, nil, credentialsVersions).
+			Build().
+			Get()
+		return deployment.(*appsv1.Deployment).Spec.Template.Annotations
+	}
+
+	assertions.Equal(map[string]string{"user": "annotation"}, podAnnotations())
+
+	agent.Spec.Agent.ProxyPasswordSecretRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "proxy"},
+		Key:                  "password",
+	}
+	checksum := podAnnotations()[constants.AnnotationCredentialsChecksum]
+	assertions.NotEmpty(checksum)
+	assertions.Equal("annotation", podAnnotations()["user"])
+	assertions.Equal(map[string]string{"user": "annotation"}, agent.Spec.Agent.Pod.Annotations)
+
+	agent.Spec.Agent.ProxyPassword = "rotated-password"
+	assertions.Equal(checksum, podAnnotations()[constants.AnnotationCredentialsChecksum])
+
+	credentialsVersions["proxy"] = "2"
+	assertions.NotEqual(checksum, podAnnotations()[constants.AnnotationCredentialsChecksum])
+}
+
 func TestGetLivenessProbe_DefaultValues(t *testing.T) {
 	agent := &instanav1.InstanaAgentRemote{
 		ObjectMeta: metav1.ObjectMeta{
@@ -54,12 +54,11 @@ func (s *secretBuilder) ComponentName() string {
 }
 
 func (s *secretBuilder) Build() optional.Optional[client.Object] {
-	switch s.Spec.Agent.KeysSecret {
-	case "":
-		return optional.Of[client.Object](s.build())
-	default:
+	// the keys are provided by the user, either in a Secret or through a SecretProviderClass
+	if s.Spec.Agent.KeysSecret != "" || s.Spec.Agent.SecretProviderClass != "" {
 		return optional.Empty[client.Object]()
 	}
+	return optional.Of[client.Object](s.build())
 }
 
 func (s *secretBuilder) build() *corev1.Secret {
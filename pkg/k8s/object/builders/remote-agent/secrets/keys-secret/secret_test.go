@@ -198,3 +198,13 @@ func TestRemoteSecretBuilder_BuildOmitsSecretMountDataWhenDisabled(t *testing.T)
 
 	assertions.Equal(expected, secret.Data)
 }
+
+func TestRemoteSecretBuilder_BuildSkippedWithSecretProviderClass(t *testing.T) {
+	agent := &instanav1.InstanaAgentRemote{
+		Spec: instanav1.InstanaAgentRemoteSpec{
+			Agent: instanav1.BaseAgentSpec{SecretProviderClass: "instana-vault"},
+		},
+	}
+
+	require.False(t, NewSecretBuilder(agent, make([]backends.RemoteSensorBackend, 0)).Build().IsPresent())
+}
@@ -17,9 +17,12 @@ package transformations
 
 import (
 	"strconv"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
@@ -40,6 +43,12 @@ const (
 	GenerationLabel = "agent.instana.io/generation"
 )
 
+// annotations
+const (
+	// IgnoredFieldManagersAnnotation lists the field managers whose changes to an object do not require a reconcile
+	IgnoredFieldManagersAnnotation = "agent.instana.io/ignored-field-managers"
+)
+
 const (
 	name       = "instana-agent"
 	partOf     = "instana"
@@ -58,12 +67,14 @@ func GetVersion() string {
 type Transformations interface {
 	AddCommonLabels(obj client.Object, component string)
 	AddOwnerReference(obj client.Object)
+	ApplyFieldOwnership(obj client.Object, component string) client.Object
 	PreviousGenerationsSelector() labels.Selector
 }
 
 type transformations struct {
 	metav1.OwnerReference
 	generation string
+	ownership  instanav1.FieldOwnershipSpec
 }
 
 func (t *transformations) AddCommonLabels(obj client.Object, component string) {
@@ -145,6 +156,79 @@ func (t *transformations) AddOwnerReference(obj client.Object) {
 	obj.SetOwnerReferences(newRefs)
 }
 
+// ApplyFieldOwnership leaves the fields that other controllers own for the component out of the object, so that they
+// are neither set nor taken back when applying it, and records the field managers whose changes do not require a
+// reconcile. The object is converted to unstructured if any of its fields are left out.
+func (t *transformations) ApplyFieldOwnership(obj client.Object, component string) client.Object {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+
+	var content map[string]any
+	removed := false
+	for _, ignoredField := range t.ownership.IgnoredFields {
+		if ignoredField.Component != component || (ignoredField.Kind != "" && ignoredField.Kind != kind) {
+			continue
+		}
+
+		// invalid paths are reported by the validation of the spec
+		segments, err := ignoredField.Segments()
+		if err != nil {
+			continue
+		}
+
+		if content == nil {
+			content = or_die.New[map[string]any]().ResultOrDie(
+				func() (map[string]any, error) {
+					return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				},
+			)
+		}
+		removed = removeField(content, segments) || removed
+	}
+
+	if removed {
+		obj = &unstructured.Unstructured{Object: content}
+	}
+
+	if len(t.ownership.FieldManagers) > 0 {
+		annotations := optional.Of(obj.GetAnnotations()).GetOrDefault(make(map[string]string, 1))
+		annotations[IgnoredFieldManagersAnnotation] = strings.Join(t.ownership.FieldManagers, ",")
+		obj.SetAnnotations(annotations)
+	}
+
+	return obj
+}
+
+// removeField deletes the field at the path from the object and reports whether it was present, paths into lists are
+// followed for all of their items
+func removeField(obj any, path []string) bool {
+	switch typed := obj.(type) {
+	case map[string]any:
+		if len(path) == 1 {
+			_, found := typed[path[0]]
+			delete(typed, path[0])
+			return found
+		}
+		return removeField(typed[path[0]], path[1:])
+	case []any:
+		removed := false
+		for _, item := range typed {
+			removed = removeField(item, path) || removed
+		}
+		return removed
+	default:
+		return false
+	}
+}
+
+// IgnoredFieldManagers returns the field managers whose changes to the object do not require a reconcile
+func IgnoredFieldManagers(obj client.Object) []string {
+	fieldManagers, ok := obj.GetAnnotations()[IgnoredFieldManagersAnnotation]
+	if !ok || fieldManagers == "" {
+		return nil
+	}
+	return strings.Split(fieldManagers, ",")
+}
+
 func NewTransformations(agent *instanav1.InstanaAgent) Transformations {
 	return &transformations{
 		OwnerReference: metav1.OwnerReference{
@@ -156,6 +240,7 @@ func NewTransformations(agent *instanav1.InstanaAgent) Transformations {
 			BlockOwnerDeletion: pointer.To(true),
 		},
 		generation: strconv.Itoa(int(agent.Generation)),
+		ownership:  agent.Spec.Ownership,
 	}
 }
 
@@ -170,5 +255,6 @@ func NewTransformationsRemote(agent *instanav1.InstanaAgentRemote) Transformatio
 			BlockOwnerDeletion: pointer.To(true),
 		},
 		generation: strconv.Itoa(int(agent.Generation)),
+		ownership:  agent.Spec.Ownership,
 	}
 }
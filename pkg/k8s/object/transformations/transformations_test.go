@@ -19,7 +19,10 @@ package transformations
 import (
 	"testing"
 
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
 	"github.com/instana/instana-agent-operator/pkg/pointer"
@@ -297,3 +300,74 @@ func TestTransformations_AddOwnerReference_WithSameNameDifferentUID(t *testing.T
 		)
 	}
 }
+
+func TestTransformations_ApplyFieldOwnership(t *testing.T) {
+	assertions := require.New(t)
+
+	agent := &instanav1.InstanaAgent{
+		Spec: instanav1.InstanaAgentSpec{
+			Ownership: instanav1.FieldOwnershipSpec{
+				IgnoredFields: []instanav1.IgnoredField{
+					{Component: "k8sensor", Kind: "Deployment", Path: "spec.replicas"},
+					{Component: "k8sensor", Path: "spec.template.spec.containers.resources"},
+					{Component: "k8sensor", Path: "spec.template.metadata.annotations[reloader.stakater.com/hash]"},
+					{Component: "k8sensor", Kind: "ConfigMap", Path: "spec.template"},
+					{Component: "instana-agent", Path: "spec.template"},
+				},
+				FieldManagers: []string{"kube-controller-manager", "Reloader"},
+			},
+		},
+	}
+
+	deployment := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "k8sensor"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.To(int32(3)),
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"reloader.stakater.com/hash": "abc", "other": "value"},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:      "k8sensor",
+							Image:     "k8sensor:latest",
+							Resources: v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}},
+						},
+						{
+							Name:      "sidecar",
+							Resources: v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	obj := NewTransformations(agent).ApplyFieldOwnership(deployment, "k8sensor")
+	applied, ok := obj.(*unstructured.Unstructured)
+	assertions.True(ok)
+
+	_, found, _ := unstructured.NestedFieldNoCopy(applied.Object, "spec", "replicas")
+	assertions.False(found)
+
+	annotations, _, _ := unstructured.NestedStringMap(applied.Object, "spec", "template", "metadata", "annotations")
+	assertions.Equal(map[string]string{"other": "value"}, annotations)
+
+	containers, _, _ := unstructured.NestedSlice(applied.Object, "spec", "template", "spec", "containers")
+	assertions.Len(containers, 2)
+	for _, container := range containers {
+		assertions.NotContains(container, "resources")
+		assertions.Contains(container, "name")
+	}
+
+	assertions.Equal([]string{"kube-controller-manager", "Reloader"}, IgnoredFieldManagers(applied))
+
+	// Objects without ignored fields keep their type
+	configMap := &v1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}}
+	assertions.Same(configMap, NewTransformations(agent).ApplyFieldOwnership(configMap, "k8sensor"))
+	assertions.Equal([]string{"kube-controller-manager", "Reloader"}, IgnoredFieldManagers(configMap))
+
+	assertions.Nil(IgnoredFieldManagers(&v1.ConfigMap{}))
+}
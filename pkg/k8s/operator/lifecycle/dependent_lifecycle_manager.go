@@ -6,36 +6,40 @@ package lifecycle
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
-	"github.com/instana/instana-agent-operator/pkg/collections/list"
 	instanaClient "github.com/instana/instana-agent-operator/pkg/k8s/client"
-	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/constants"
 	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
 	"github.com/instana/instana-agent-operator/pkg/multierror"
 )
 
-// DependentLifecycleManager is responsible of adding and removing dependents
-// from the ConfigMap.Data field
+// DependentLifecycleManager is responsible for keeping the inventory of the
+// dependents of a CR and for removing dependents of earlier generations
 type DependentLifecycleManager interface {
 	UpdateDependentLifecycleInfo(currentGenerationDependents []client.Object) error
 	CleanupDependents(currentDependents ...client.Object) error
+	PendingCleanup(currentDependents ...client.Object) ([]client.Object, error)
+	Inventory() []instanav1.ResourceInventoryEntry
 }
 
 type dependentLifecycleManager struct {
 	ctx                context.Context
-	agent              *instanav1.InstanaAgent
+	owner              client.Object
+	previousInventory  []instanav1.ResourceInventoryEntry
+	inventory          []instanav1.ResourceInventoryEntry
+	legacyCmLoaded     bool
+	legacyCmFound      bool
 	instanaAgentClient instanaClient.InstanaAgentClient
 	transformations    transformations.Transformations
 }
@@ -47,221 +51,301 @@ func NewDependentLifecycleManager(
 ) DependentLifecycleManager {
 	return &dependentLifecycleManager{
 		ctx:                ctx,
-		agent:              agent,
+		owner:              agent,
+		previousInventory:  agent.Status.Inventory,
+		inventory:          agent.Status.Inventory,
 		instanaAgentClient: instanaClient,
 		transformations:    transformations.NewTransformations(agent),
 	}
 }
 
-// UpdateDependentLifecycleInfo is responsible for adding all dependents listed
-// in currentGenerationDependents to the ConfigMap.Data field and applying it
-// through the InstanaAgentClient
+// UpdateDependentLifecycleInfo records all dependents listed in
+// currentGenerationDependents in the inventory before they are applied. Dependents
+// of earlier generations remain part of the inventory until they are cleaned up.
 func (d *dependentLifecycleManager) UpdateDependentLifecycleInfo(
 	currentGenerationDependents []client.Object,
 ) error {
-	log := logf.FromContext(d.ctx)
+	if err := d.loadLegacyInventory(); err != nil {
+		return err
+	}
 
-	retryError := d.retry(5, 100*time.Millisecond,
-		func() (bool, error) {
-			// Get the initial ConfigMap
-			lifecycleCm, _ := d.getOrInitializeLifecycleCm()
+	d.inventory = mergeInventories(asInventory(currentGenerationDependents...), d.previousInventory)
 
-			currentGenKey := d.getCurrentGenKey()
+	return nil
+}
 
-			// Ensures that a lifecycle comparison will be performed even if neither the generation nor the operator version
-			// have been updated, should only be necessary for the sake of testing during development
-			if existingVersion, isPresent := lifecycleCm.Data[currentGenKey]; isPresent {
-				lifecycleCm.Data[currentGenKey+"-dirty"] = existingVersion
-			}
+// CleanupDependents deletes all dependents labelled as belonging to an earlier
+// generation of the CR as well as all dependents of the previous inventory which
+// are not part of currentDependents. Once successful, the inventory only holds
+// currentDependents.
+func (d *dependentLifecycleManager) CleanupDependents(
+	currentDependents ...client.Object,
+) error {
+	log := logf.FromContext(d.ctx)
 
-			currentDependentsJson, _ := json.Marshal(asUnstructureds(currentGenerationDependents...))
-			lifecycleCm.Data[currentGenKey] = string(currentDependentsJson)
+	if err := d.loadLegacyInventory(); err != nil {
+		return err
+	}
 
-			d.transformations.AddCommonLabels(&lifecycleCm, constants.ComponentInstanaAgent)
-			d.transformations.AddOwnerReference(&lifecycleCm)
+	orphans, err := d.findOrphans(currentDependents)
+	if err != nil {
+		return err
+	}
 
-			// Try to apply the changes
-			_, err := d.instanaAgentClient.Apply(d.ctx, &lifecycleCm).Get()
+	if len(orphans) > 0 {
+		log.V(1).Info("deleting dependents of earlier generations", "count", len(orphans))
+	}
 
-			// If successful or error is not a conflict, break the loop
-			if err == nil || !k8serrors.IsConflict(err) {
-				return true, err
-			}
+	if _, err := d.deleteAll(orphans); err != nil {
+		return err
+	}
 
-			// If we got a conflict error, log and retry with exponential backoff
-			log.Info("Conflict detected when updating ConfigMap, retrying...",
-				"configmap", d.getConfigMapName(),
-				"namespace", d.agent.GetNamespace(),
-			)
+	if d.legacyCmFound {
+		if err := d.deleteLegacyLifecycleConfigMap(); err != nil {
+			return err
+		}
+		d.legacyCmFound = false
+	}
 
-			return false, err
-		})
+	current := make(map[string]struct{}, len(currentDependents))
+	for _, obj := range currentDependents {
+		current[objectIdentity(obj)] = struct{}{}
+	}
 
-	if retryError != nil && k8serrors.IsConflict(retryError) {
-		log.Error(retryError, "Failed to update ConfigMap after maximum retries due to conflicts",
-			"configmap", d.getConfigMapName(),
-			"namespace", d.agent.GetNamespace())
+	// keep the hashes recorded before the dependents were applied, the applied objects also contain server-side state
+	inventory := make([]instanav1.ResourceInventoryEntry, 0, len(currentDependents))
+	for _, entry := range mergeInventories(d.inventory, asInventory(currentDependents...)) {
+		if _, ok := current[entryIdentity(entry)]; ok {
+			inventory = append(inventory, entry)
+		}
 	}
+	d.inventory = withUIDs(inventory, currentDependents)
 
-	return retryError
+	return nil
 }
 
-// CleanupDependents is responsible of deleting all dependents that don't appear
-// in the list whitelistedDependents from the ConfigMap.Data field and applying
-// changes through the InstanaAgentClient
-func (d *dependentLifecycleManager) CleanupDependents(
-	currentDependents ...client.Object,
-) error {
-	log := logf.FromContext(d.ctx)
+// PendingCleanup returns the dependents CleanupDependents would delete once currentDependents have been applied,
+// without deleting them
+func (d *dependentLifecycleManager) PendingCleanup(currentDependents ...client.Object) ([]client.Object, error) {
+	if err := d.loadLegacyInventory(); err != nil {
+		return nil, err
+	}
 
-	retryError := d.retry(5, 100*time.Millisecond,
-		func() (bool, error) {
-			// Get the latest ConfigMap on each attempt
-			lifecycleConfigMap, _ := d.getLifecycleConfigMap()
-
-			errBuilder := multierror.NewMultiErrorBuilder()
-			currentGeneration := asUnstructureds(currentDependents...)
-
-			for key, jsonString := range lifecycleConfigMap.Data {
-				olderGeneration, _ := d.unmarshalToUnstructured(jsonString)
-				deprecatedDependents := list.
-					NewDeepDiff[unstructured.Unstructured]().
-					Diff(
-						olderGeneration,
-						currentGeneration,
-					)
-				_, deleteErr := d.deleteAll(deprecatedDependents)
-				if deleteErr != nil {
-					errBuilder.AddSingle(deleteErr)
-				}
+	return d.findOrphans(currentDependents)
+}
 
-				if deleteErr == nil && key != d.getCurrentGenKey() {
-					delete(lifecycleConfigMap.Data, key)
-				}
-			}
+// Inventory returns the dependents that have been applied for the CR and not yet removed
+func (d *dependentLifecycleManager) Inventory() []instanav1.ResourceInventoryEntry {
+	return d.inventory
+}
 
-			result := d.instanaAgentClient.Apply(d.ctx, &lifecycleConfigMap)
-			result.OnFailure(errBuilder.AddSingle)
+// findOrphans lists the operator-labelled objects of the kinds in the previous inventory whose generation label is
+// stale. Objects recorded with a UID in the previous inventory are matched by it, otherwise namespaced objects must be
+// owned by the CR, while cluster-scoped objects, which can't have an owner, must be part of the previous inventory.
+// Entries of the previous inventory that aren't part of currentDependents are orphaned as well, as long as the object
+// they identify still has the recorded UID.
+func (d *dependentLifecycleManager) findOrphans(currentDependents []client.Object) ([]client.Object, error) {
+	errBuilder := multierror.NewMultiErrorBuilder()
+
+	current := make(map[string]struct{}, len(currentDependents))
+	for _, obj := range currentDependents {
+		current[objectIdentity(obj)] = struct{}{}
+	}
 
-			err := errBuilder.Build()
+	previous := make(map[string]instanav1.ResourceInventoryEntry, len(d.previousInventory))
+	for _, entry := range d.previousInventory {
+		previous[entryIdentity(entry)] = entry
+	}
 
-			// If successful or error is not a conflict, break the loop
-			if err == nil || !isConflictError(err) {
-				return true, err
-			}
+	orphans := make([]client.Object, 0)
+	found := make(map[string]struct{})
+	addOrphan := func(identity string, obj client.Object) {
+		if _, ok := current[identity]; ok {
+			return
+		}
+		if _, ok := found[identity]; ok {
+			return
+		}
+		found[identity] = struct{}{}
+		orphans = append(orphans, obj)
+	}
 
-			// If we got a conflict error, log and retry with exponential backoff
-			log.Info("Conflict detected when cleaning up dependents, retrying...",
-				"configmap", d.getConfigMapName(),
-				"namespace", d.agent.GetNamespace())
+	// objects that have been listed are matched against the previous inventory here and not looked up again below
+	listed := make(map[string]struct{})
+	for _, gvk := range d.groupVersionKinds(currentDependents) {
+		objects := &unstructured.UnstructuredList{}
+		objects.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		err := d.instanaAgentClient.List(
+			d.ctx,
+			objects,
+			client.MatchingLabelsSelector{Selector: d.transformations.PreviousGenerationsSelector()},
+		)
+		switch {
+		case meta.IsNoMatchError(err):
+			continue
+		case err != nil:
+			errBuilder.AddSingle(err)
+			continue
+		}
 
-			return false, err
-		})
+		for i := range objects.Items {
+			obj := &objects.Items[i]
+			identity := objectIdentity(obj)
+			listed[identity] = struct{}{}
 
-	if retryError != nil && isConflictError(retryError) {
-		log.Error(retryError, "Failed to clean up dependents after maximum retries due to conflicts",
-			"configmap", d.getConfigMapName(),
-			"namespace", d.agent.GetNamespace())
-	}
+			entry, inPrevious := previous[identity]
+			switch {
+			case inPrevious && entry.UID != "":
+				if entry.UID != string(obj.GetUID()) {
+					continue
+				}
+			case obj.GetNamespace() == "":
+				if !inPrevious {
+					continue
+				}
+			case !d.isOwnedByCR(obj):
+				continue
+			}
 
-	return retryError
-}
+			addOrphan(identity, obj)
+		}
+	}
 
-// Retry is a simple retry function with a backtrack
-func (d *dependentLifecycleManager) retry(attempts int, sleep time.Duration, fn func() (bool, error),
-) error {
-	var err error
-	for i := range attempts {
-		done, err := fn()
-		if done {
-			return err
+	for _, entry := range d.previousInventory {
+		identity := entryIdentity(entry)
+		if _, ok := current[identity]; ok {
+			continue
+		}
+		if _, ok := listed[identity]; ok {
+			continue
 		}
 
-		// Skip sleeping after the last retry
-		if i < attempts-1 {
-			time.Sleep(sleep * time.Duration(1<<i))
+		if entry.UID != "" {
+			matches, err := d.hasRecordedUID(entry)
+			if err != nil {
+				errBuilder.AddSingle(err)
+			}
+			if !matches {
+				continue
+			}
 		}
+
+		addOrphan(identity, asObject(entry))
+	}
+
+	return orphans, errBuilder.Build()
+}
+
+// hasRecordedUID reports whether the object identified by the inventory entry still exists with the UID recorded in
+// the entry, an object that has been recreated under the same name in the meantime isn't a dependent of the CR
+func (d *dependentLifecycleManager) hasRecordedUID(entry instanav1.ResourceInventoryEntry) (bool, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(entryGroupVersionKind(entry))
+
+	err := d.instanaAgentClient.Get(d.ctx, types.NamespacedName{Namespace: entry.Namespace, Name: entry.Name}, obj)
+	switch {
+	case k8serrors.IsNotFound(err), meta.IsNoMatchError(err):
+		return false, nil
+	case err != nil:
+		return false, err
 	}
 
-	return err
+	return string(obj.GetUID()) == entry.UID, nil
 }
 
-// isConflictError checks if the error is a conflict error
-func isConflictError(err error) bool {
-	if err == nil {
-		return false
+// groupVersionKinds returns the kinds of the previous inventory, only these kinds can have dependents of earlier
+// generations as all dependents are recorded in the inventory before they are applied. Without a previous inventory,
+// e.g. after upgrading from an operator version that didn't keep one, the kinds of currentDependents are returned.
+func (d *dependentLifecycleManager) groupVersionKinds(currentDependents []client.Object) []schema.GroupVersionKind {
+	kinds := make([]schema.GroupVersionKind, 0, len(d.previousInventory))
+	for _, entry := range d.previousInventory {
+		kinds = append(kinds, entryGroupVersionKind(entry))
+	}
+	if len(kinds) == 0 {
+		for _, obj := range currentDependents {
+			kinds = append(kinds, obj.GetObjectKind().GroupVersionKind())
+		}
 	}
 
-	// Check if it's a direct conflict error
-	if k8serrors.IsConflict(err) {
-		return true
+	gvks := make([]schema.GroupVersionKind, 0, len(kinds))
+	seen := make(map[schema.GroupKind]struct{})
+	for _, gvk := range kinds {
+		if _, ok := seen[gvk.GroupKind()]; ok || gvk.Kind == "" {
+			continue
+		}
+		seen[gvk.GroupKind()] = struct{}{}
+		gvks = append(gvks, gvk)
 	}
 
-	// Check if it contains a conflict error message
-	return err.Error() != "" &&
-		(err.Error() == "Operation cannot be fulfilled" ||
-			err.Error() == "the object has been modified")
+	return gvks
 }
 
-func (d *dependentLifecycleManager) getConfigMapName() string {
-	return d.agent.GetName() + "-dependents"
+func (d *dependentLifecycleManager) isOwnedByCR(obj client.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == d.owner.GetUID() {
+			return true
+		}
+	}
+	return false
 }
 
-func (d *dependentLifecycleManager) getCurrentGenKey() string {
-	return fmt.Sprintf("%s_%d", transformations.GetVersion(), d.agent.GetGeneration())
+func (d *dependentLifecycleManager) getLegacyConfigMapName() string {
+	return d.owner.GetName() + "-dependents"
 }
 
-func (d *dependentLifecycleManager) getOrInitializeLifecycleCm() (corev1.ConfigMap, error) {
-	lifecycleConfigMap, err := d.getLifecycleConfigMap()
-
-	// Initialize ConfigMap if we weren't able to fetch one
-	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			lifecycleConfigMap = corev1.ConfigMap{
-				TypeMeta: metav1.TypeMeta{
-					APIVersion: "v1",
-					Kind:       "ConfigMap",
-				},
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      d.getConfigMapName(),
-					Namespace: d.agent.GetNamespace(),
-				},
-			}
-		} else {
-			lifecycleConfigMap = corev1.ConfigMap{}
-		}
+// loadLegacyInventory adds the dependents tracked in the ConfigMap of earlier operator versions to the previous
+// inventory, so that they are cleaned up like any other dependent of an earlier generation
+func (d *dependentLifecycleManager) loadLegacyInventory() error {
+	if d.legacyCmLoaded {
+		return nil
 	}
 
-	// If the Data field is empty, set a value so that we wont die when attempting to read
-	if lifecycleConfigMap.Data == nil {
-		lifecycleConfigMap.Data = make(map[string]string, 1)
+	legacyCm, err := d.getLegacyLifecycleConfigMap()
+	switch {
+	case k8serrors.IsNotFound(err):
+	case err != nil:
+		return err
+	default:
+		d.previousInventory = mergeInventories(d.previousInventory, legacyInventory(legacyCm.Data))
+		d.legacyCmFound = true
 	}
 
-	return lifecycleConfigMap, err
+	d.legacyCmLoaded = true
+	return nil
 }
 
-func (d *dependentLifecycleManager) getLifecycleConfigMap() (corev1.ConfigMap, error) {
+// getLegacyLifecycleConfigMap returns the ConfigMap earlier operator versions used to track the dependents of a CR
+func (d *dependentLifecycleManager) getLegacyLifecycleConfigMap() (corev1.ConfigMap, error) {
 	lifecycleCm := corev1.ConfigMap{}
 	err := d.instanaAgentClient.Get(
 		d.ctx,
-		types.NamespacedName{Name: d.getConfigMapName(), Namespace: d.agent.GetNamespace()},
+		types.NamespacedName{Name: d.getLegacyConfigMapName(), Namespace: d.owner.GetNamespace()},
 		&lifecycleCm,
 	)
 	return lifecycleCm, err
 }
 
-func (d *dependentLifecycleManager) unmarshalToUnstructured(jsonString string) ([]unstructured.Unstructured, error) {
-	var unstructuredData []unstructured.Unstructured
-	err := json.Unmarshal([]byte(jsonString), &unstructuredData)
-	return unstructuredData, err
+// deleteLegacyLifecycleConfigMap removes the ConfigMap earlier operator versions used to track the dependents of a
+// CR, once its dependents have been taken over by the inventory
+func (d *dependentLifecycleManager) deleteLegacyLifecycleConfigMap() error {
+	return client.IgnoreNotFound(
+		d.instanaAgentClient.Delete(
+			d.ctx,
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      d.getLegacyConfigMapName(),
+					Namespace: d.owner.GetNamespace(),
+				},
+			},
+		),
+	)
 }
 
-func (d *dependentLifecycleManager) deleteAll(toBeDeleted []unstructured.Unstructured) ([]client.Object, error) {
+func (d *dependentLifecycleManager) deleteAll(toBeDeleted []client.Object) ([]client.Object, error) {
 	return d.instanaAgentClient.DeleteAllInTimeLimit(
 		d.ctx,
-		list.
-			NewListMapTo[unstructured.Unstructured, client.Object]().
-			MapTo(toBeDeleted, asObject),
+		toBeDeleted,
 		30*time.Second,
 		5*time.Second).Get()
 }
@@ -21,361 +21,343 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"strconv"
 	"testing"
 
-	instanav1 "github.com/instana/instana-agent-operator/api/v1"
-	"github.com/instana/instana-agent-operator/internal/mocks"
-	"github.com/instana/instana-agent-operator/pkg/multierror"
-	"github.com/instana/instana-agent-operator/pkg/result"
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	instanaclient "github.com/instana/instana-agent-operator/pkg/k8s/client"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
 )
 
-func genMockObjs(amount int) []client.Object {
-	objects := []client.Object{}
-	for i := range amount {
-		unstrctrd := &unstructured.Unstructured{}
-		unstrctrd.SetName("i" + strconv.Itoa(i))
-		unstrctrd.SetKind("agent-data")
-		objects = append(objects, unstrctrd)
+const testNamespace = "instana-agent"
+
+func agentAtGeneration(generation int64) *instanav1.InstanaAgent {
+	return &instanav1.InstanaAgent{
+		TypeMeta: metav1.TypeMeta{APIVersion: "instana.io/v1", Kind: "InstanaAgent"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "instana-agent",
+			Namespace:  testNamespace,
+			UID:        "agent-uid",
+			Generation: generation,
+		},
 	}
-	return objects
 }
 
-func TestAsObjectConversion(t *testing.T) {
-	conversion := asObject(unstructured.Unstructured{})
-	var expectedType client.Object = conversion
-	assert.IsType(t, expectedType, conversion)
+// dependent returns a Secret or ClusterRole labelled and owned the way the builder transformer would do it for the
+// given generation of the agent
+func dependent(agent *instanav1.InstanaAgent, name string, clusterScoped bool) client.Object {
+	var obj client.Object
+	switch clusterScoped {
+	case true:
+		obj = &rbacv1.ClusterRole{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+		}
+	default:
+		obj = &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Data:       map[string][]byte{"key": []byte(name)},
+		}
+	}
+
+	t := transformations.NewTransformations(agent)
+	t.AddCommonLabels(obj, "instana-agent")
+	if !clusterScoped {
+		t.AddOwnerReference(obj)
+	}
+	return obj
 }
 
-// TestClenupDependentsDeletesUnmatchedData - In an instance where ConfigMap.Data
-// contains more than the current generated key to hold data in, the code will
-// remove that field from the array
-func TestCleanupDependentsDeletesUnmatchedData(t *testing.T) {
-	ctx := t.Context()
-
-	instanaAgentClient := &mocks.MockInstanaAgentClient{}
-	defer instanaAgentClient.AssertExpectations(t)
-
-	// Create two client.Object arrays which have some overlap for comparisons and deletion calls
-	oldDependentsJson := genMockObjs(10)
-	currentDependentsJson := genMockObjs(5)
-	currentDependentsJson = append(currentDependentsJson, oldDependentsJson[:5]...)
-
-	instanaAgentClient.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Run(func(args mock.Arguments) {
-			config := args.Get(2).(*corev1.ConfigMap)
-			config.TypeMeta = metav1.TypeMeta{
-				APIVersion: "v1",
-				Kind:       "ConfigMap",
-			}
-			config.ObjectMeta = metav1.ObjectMeta{
-				Name:      "asdasd",
-				Namespace: "asdasd",
-			}
-
-			config.Data = make(map[string]string, 1)
-			olderDependentsJsonString, _ := json.Marshal(asUnstructureds(oldDependentsJson...))
-			config.Data["v0.0.1-dev"] = string(olderDependentsJsonString)
-			config.Data["v0.0.1-dev-to-be-deleted"] = string(olderDependentsJsonString)
-		}).Return(nil)
-
-	instanaAgentClient.On("DeleteAllInTimeLimit",
-		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(result.Of(genMockObjs(1), nil)).
-		Times(2)
-
-	var obj client.Object = &unstructured.Unstructured{}
-	instanaAgentClient.On("Apply", mock.Anything, mock.Anything, mock.Anything).
-		Return(result.Of(obj, nil))
+func newFakeClient(t *testing.T, funcs interceptor.Funcs, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	return interceptor.NewClient(
+		fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		funcs,
+	)
+}
+
+func requireExists(t *testing.T, c client.Client, obj client.Object, expected bool) {
+	res := &unstructured.Unstructured{}
+	res.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	err := c.Get(context.Background(), client.ObjectKeyFromObject(obj), res)
+	switch expected {
+	case true:
+		require.NoError(t, err, "expected %s to exist", obj.GetName())
+	default:
+		require.True(t, k8serrors.IsNotFound(err), "expected %s to be deleted", obj.GetName())
+	}
+}
+
+func TestCleanupDependentsDeletesDependentsOfEarlierGenerations(t *testing.T) {
+	oldAgent := agentAtGeneration(1)
+	agent := agentAtGeneration(2)
+
+	current := dependent(agent, "current", false)
+	stale := dependent(oldAgent, "stale", false)
+
+	foreign := dependent(oldAgent, "foreign", false)
+	foreign.SetOwnerReferences([]metav1.OwnerReference{{Name: "instana-agent", UID: "other-uid"}})
+
+	staleClusterRole := dependent(oldAgent, "stale-cluster-role", true)
+	untrackedClusterRole := dependent(oldAgent, "untracked-cluster-role", true)
+
+	agent.Status.Inventory = asInventory(dependent(oldAgent, "current", false), staleClusterRole)
+
+	c := newFakeClient(t, interceptor.Funcs{}, current, stale, foreign, staleClusterRole, untrackedClusterRole)
 
 	dependentLifecycleManager := NewDependentLifecycleManager(
-		ctx,
-		&instanav1.InstanaAgent{},
-		instanaAgentClient,
+		context.Background(),
+		agent,
+		instanaclient.NewInstanaAgentClient(c),
 	)
 
-	err := dependentLifecycleManager.CleanupDependents(currentDependentsJson...)
-	assert.Nil(t, err)
+	require.NoError(t, dependentLifecycleManager.UpdateDependentLifecycleInfo([]client.Object{current}))
+	require.NoError(t, dependentLifecycleManager.CleanupDependents(current))
+
+	requireExists(t, c, current, true)
+	requireExists(t, c, stale, false)
+	requireExists(t, c, foreign, true)
+	requireExists(t, c, staleClusterRole, false)
+	requireExists(t, c, untrackedClusterRole, true)
+
+	require.Equal(t, asInventory(current), dependentLifecycleManager.Inventory())
 }
 
-// TestCleanupDependentsDeleteAllReturnsError - returns an error from the function
-// delete all and returns that correctly back to the caller
-func TestCleanupDependentsDeleteAllReturnsError(t *testing.T) {
+func TestCleanupDependentsDeletesDependentsMissingFromCurrentGeneration(t *testing.T) {
+	agent := agentAtGeneration(3)
+
+	current := dependent(agent, "current", false)
+	removed := dependent(agent, "removed", false)
 
-	ctx := t.Context()
+	agent.Status.Inventory = asInventory(current, removed)
 
-	instanaAgentClient := &mocks.MockInstanaAgentClient{}
-	defer instanaAgentClient.AssertExpectations(t)
+	c := newFakeClient(t, interceptor.Funcs{}, current, removed)
 
-	instanaAgentClient.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Run(func(args mock.Arguments) {
-			config := args.Get(2).(*corev1.ConfigMap)
-			config.TypeMeta = metav1.TypeMeta{
-				APIVersion: "v1",
-				Kind:       "ConfigMap",
-			}
-			config.ObjectMeta = metav1.ObjectMeta{
-				Name:      "asdasd",
-				Namespace: "asdasd",
-			}
+	dependentLifecycleManager := NewDependentLifecycleManager(
+		context.Background(),
+		agent,
+		instanaclient.NewInstanaAgentClient(c),
+	)
+
+	require.NoError(t, dependentLifecycleManager.UpdateDependentLifecycleInfo([]client.Object{current}))
+	require.Equal(
+		t,
+		append(asInventory(current), agent.Status.Inventory[1]),
+		dependentLifecycleManager.Inventory(),
+	)
+
+	require.NoError(t, dependentLifecycleManager.CleanupDependents(current))
+
+	requireExists(t, c, current, true)
+	requireExists(t, c, removed, false)
+	require.Equal(t, asInventory(current), dependentLifecycleManager.Inventory())
+}
+
+func TestCleanupDependentsMatchesInventoryByUID(t *testing.T) {
+	oldAgent := agentAtGeneration(1)
+	agent := agentAtGeneration(2)
 
-			config.Data = make(map[string]string, 1)
-			currentDependentsJson, _ := json.Marshal(asUnstructureds(genMockObjs(12)...))
-			config.Data["v0.0.1-dev_1234"] = string(currentDependentsJson)
-		}).Return(nil)
+	current := dependent(agent, "current", false)
 
-	errBuilder := multierror.NewMultiErrorBuilder()
-	expected := errors.New("Error returned from d.deleteAll")
-	errBuilder.Add(expected)
+	stale := dependent(oldAgent, "stale", true)
+	stale.SetUID("stale-uid")
+	recreated := dependent(oldAgent, "recreated", true)
+	recreated.SetUID("recreated-uid")
+	relabelled := dependent(agent, "relabelled", true)
+	relabelled.SetUID("relabelled-uid")
 
-	instanaAgentClient.On("DeleteAllInTimeLimit",
-		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(result.Of(genMockObjs(1), expected))
+	staleEntry := asInventoryEntry(stale)
+	recreatedEntry := asInventoryEntry(recreated)
+	recreatedEntry.UID = "previous-uid"
+	relabelledEntry := asInventoryEntry(relabelled)
+	relabelledEntry.UID = "previous-uid"
+	agent.Status.Inventory = []instanav1.ResourceInventoryEntry{staleEntry, recreatedEntry, relabelledEntry}
 
-	var obj client.Object = &unstructured.Unstructured{}
-	instanaAgentClient.On("Apply", mock.Anything, mock.Anything, mock.Anything).
-		Return(result.Of(obj, nil))
+	c := newFakeClient(t, interceptor.Funcs{}, current, stale, recreated, relabelled)
 
 	dependentLifecycleManager := NewDependentLifecycleManager(
-		ctx,
-		&instanav1.InstanaAgent{},
-		instanaAgentClient,
+		context.Background(),
+		agent,
+		instanaclient.NewInstanaAgentClient(c),
 	)
 
-	err := dependentLifecycleManager.CleanupDependents(genMockObjs(10)...)
+	require.NoError(t, dependentLifecycleManager.UpdateDependentLifecycleInfo([]client.Object{current}))
+	require.NoError(t, dependentLifecycleManager.CleanupDependents(current))
 
-	assert.True(t, errors.Is(errBuilder.Build(), err))
+	requireExists(t, c, current, true)
+	requireExists(t, c, stale, false)
+	requireExists(t, c, recreated, true)
+	requireExists(t, c, relabelled, true)
 }
 
-func TestCleanupDependents(t *testing.T) {
-	for _, test := range []struct {
-		name             string
-		expected         string
-		instanaAgent     instanav1.InstanaAgent
-		generatedObjects int
-		clientGetterFunc func(
-			_ context.Context,
-			_ types.NamespacedName,
-			config *corev1.ConfigMap,
-		) error
-	}{
-		{
-			name:             "Completely successsful run of CleanupDependents without ConfigMap.Data containing any data",
-			expected:         "",
-			generatedObjects: 0,
-			clientGetterFunc: func(
-				_ context.Context,
-				_ types.NamespacedName,
-				config *corev1.ConfigMap,
-			) error {
-				config.TypeMeta = metav1.TypeMeta{
-					APIVersion: "v1",
-					Kind:       "ConfigMap",
-				}
-				config.ObjectMeta = metav1.ObjectMeta{
-					Name:      "asdasd",
-					Namespace: "asdasd",
-				}
-
-				config.Data = make(map[string]string, 1)
-				currentDependentsJson, _ := json.Marshal(asUnstructureds(genMockObjs(0)...))
-				config.Data["v0.0.1-dev_1234"] = string(currentDependentsJson)
-
-				return nil
-			},
-			instanaAgent: instanav1.InstanaAgent{ObjectMeta: metav1.ObjectMeta{Generation: 1234}},
-		},
-		{
-			name:             "Completely successful run of CleanupDependents with ConfigMap.Data containing already data",
-			expected:         "",
-			generatedObjects: 10,
-			clientGetterFunc: func(
-				_ context.Context,
-				_ types.NamespacedName,
-				config *corev1.ConfigMap,
-			) error {
-				config.TypeMeta = metav1.TypeMeta{
-					APIVersion: "v1",
-					Kind:       "ConfigMap",
-				}
-				config.ObjectMeta = metav1.ObjectMeta{
-					Name:      "asdasd",
-					Namespace: "asdasd",
-				}
-
-				config.Data = make(map[string]string, 1)
-				currentDependentsJson, _ := json.Marshal(asUnstructureds(genMockObjs(12)...))
-				config.Data["v0.0.1-dev_1234"] = string(currentDependentsJson)
-
-				return nil
+func TestCleanupDependentsOnlyListsKindsOfPreviousInventory(t *testing.T) {
+	oldAgent := agentAtGeneration(1)
+	agent := agentAtGeneration(2)
+
+	current := dependent(agent, "current", false)
+	agent.Status.Inventory = asInventory(dependent(oldAgent, "cluster-role", true))
+
+	var listed []string
+	c := newFakeClient(
+		t,
+		interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				listed = append(listed, list.GetObjectKind().GroupVersionKind().Kind)
+				return c.List(ctx, list, opts...)
 			},
-			instanaAgent: instanav1.InstanaAgent{ObjectMeta: metav1.ObjectMeta{Generation: 1234}},
 		},
-	} {
-		t.Run(
-			test.name, func(t *testing.T) {
-				assertions := require.New(t)
-				ctx := t.Context()
-
-				instanaAgentClient := &mocks.MockInstanaAgentClient{}
-				defer instanaAgentClient.AssertExpectations(t)
-				getReturn := test.clientGetterFunc(
-					context.Background(),
-					types.NamespacedName{},
-					&corev1.ConfigMap{})
-				instanaAgentClient.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-					Run(func(args mock.Arguments) {
-						if getReturn == nil {
-							_ = test.clientGetterFunc(
-								args.Get(0).(context.Context),
-								args.Get(1).(types.NamespacedName),
-								args.Get(2).(*corev1.ConfigMap))
-						}
-					}).
-					Return(getReturn)
-				instanaAgentClient.On("DeleteAllInTimeLimit",
-					mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-					Return(result.Of(genMockObjs(test.generatedObjects), nil))
-				var obj client.Object = &unstructured.Unstructured{}
-				instanaAgentClient.On("Apply", mock.Anything, mock.Anything, mock.Anything).
-					Return(result.Of(obj, nil))
-
-				dependentLifecycleManager := NewDependentLifecycleManager(
-					ctx,
-					&test.instanaAgent,
-					instanaAgentClient,
-				)
-
-				err := dependentLifecycleManager.CleanupDependents(
-					genMockObjs(test.generatedObjects)...)
-				assertions.Nil(err)
-			},
-		)
-	}
+		current,
+	)
 
+	dependentLifecycleManager := NewDependentLifecycleManager(
+		context.Background(),
+		agent,
+		instanaclient.NewInstanaAgentClient(c),
+	)
+
+	require.NoError(t, dependentLifecycleManager.UpdateDependentLifecycleInfo([]client.Object{current}))
+	require.NoError(t, dependentLifecycleManager.CleanupDependents(current))
+	require.Equal(t, []string{"ClusterRoleList"}, listed)
 }
 
-func TestUpdateLifecycleInfo(t *testing.T) {
-	for _, test := range []struct {
-		name             string
-		expected         string
-		instanaAgent     instanav1.InstanaAgent
-		clientGetterFunc func(
-			_ context.Context,
-			_ types.NamespacedName,
-			config *corev1.ConfigMap,
-		) error
-	}{
+func TestCleanupDependentsTakesOverLegacyLifecycleConfigMap(t *testing.T) {
+	oldAgent := agentAtGeneration(1)
+	agent := agentAtGeneration(2)
+
+	current := dependent(agent, "current", false)
+	legacyClusterRole := dependent(oldAgent, "legacy-cluster-role", true)
+
+	records, err := json.Marshal([]map[string]any{
 		{
-			name:     "Should get ConfigMap successfully and do changes accordingly UpdateLifecycleInfo with it",
-			expected: "",
-			clientGetterFunc: func(
-				_ context.Context,
-				_ types.NamespacedName,
-				config *corev1.ConfigMap,
-			) error {
-				config.TypeMeta = metav1.TypeMeta{
-					APIVersion: "v1",
-					Kind:       "ConfigMap",
-				}
-				config.ObjectMeta = metav1.ObjectMeta{
-					Name:      "asdasd",
-					Namespace: "asdasd",
-				}
-				return nil
-			},
-			instanaAgent: instanav1.InstanaAgent{},
+			"apiVersion": "rbac.authorization.k8s.io/v1",
+			"kind":       "ClusterRole",
+			"metadata":   map[string]any{"name": "legacy-cluster-role"},
 		},
-		{
-			name:     "Should initialize ConfigMap if NotFound error was given and UpdateLifecycleInfo",
-			expected: "",
-			clientGetterFunc: func(
-				_ context.Context,
-				_ types.NamespacedName,
-				config *corev1.ConfigMap,
-			) error {
-				return k8serrors.NewNotFound(schema.GroupResource{}, "asdasd")
-			},
-			instanaAgent: instanav1.InstanaAgent{},
+	})
+	require.NoError(t, err)
+
+	legacyCm := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent-dependents", Namespace: testNamespace},
+		Data: map[string]string{
+			"v0.0.1_1": string(records),
+			"invalid":  "not a list of records",
 		},
-		{
-			name:     "Should initialize empty ConfigMap on error and UpdateLifecycleInfo",
-			expected: "",
-			clientGetterFunc: func(
-				_ context.Context,
-				_ types.NamespacedName,
-				config *corev1.ConfigMap,
-			) error {
-				return errors.New("An expected error occurred")
+	}
+
+	c := newFakeClient(t, interceptor.Funcs{}, current, legacyClusterRole, legacyCm)
+
+	dependentLifecycleManager := NewDependentLifecycleManager(
+		context.Background(),
+		agent,
+		instanaclient.NewInstanaAgentClient(c),
+	)
+
+	require.NoError(t, dependentLifecycleManager.UpdateDependentLifecycleInfo([]client.Object{current}))
+	require.NoError(t, dependentLifecycleManager.CleanupDependents(current))
+
+	requireExists(t, c, current, true)
+	requireExists(t, c, legacyClusterRole, false)
+	requireExists(t, c, legacyCm, false)
+}
+
+func TestCleanupDependentsWithoutCurrentDependentsDeletesInventory(t *testing.T) {
+	agent := agentAtGeneration(1)
+
+	secret := dependent(agent, "secret", false)
+	clusterRole := dependent(agent, "cluster-role", true)
+
+	agent.Status.Inventory = asInventory(secret, clusterRole)
+
+	c := newFakeClient(t, interceptor.Funcs{}, secret, clusterRole)
+
+	dependentLifecycleManager := NewDependentLifecycleManager(
+		context.Background(),
+		agent,
+		instanaclient.NewInstanaAgentClient(c),
+	)
+
+	require.NoError(t, dependentLifecycleManager.CleanupDependents())
+
+	requireExists(t, c, secret, false)
+	requireExists(t, c, clusterRole, false)
+	require.Empty(t, dependentLifecycleManager.Inventory())
+}
+
+func TestCleanupDependentsReturnsListErrorAndKeepsInventory(t *testing.T) {
+	oldAgent := agentAtGeneration(1)
+	agent := agentAtGeneration(2)
+
+	current := dependent(agent, "current", false)
+	stale := dependent(oldAgent, "stale", false)
+	agent.Status.Inventory = asInventory(stale)
+
+	expected := errors.New("list failed")
+	c := newFakeClient(
+		t,
+		interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				return expected
 			},
-			instanaAgent: instanav1.InstanaAgent{},
 		},
-		{
-			name:     "Should run with existing ConfigMap.Data field populated and UpdateLifecycleInfo",
-			expected: "",
-			clientGetterFunc: func(
-				_ context.Context,
-				_ types.NamespacedName,
-				config *corev1.ConfigMap,
+		current,
+		stale,
+	)
+
+	dependentLifecycleManager := NewDependentLifecycleManager(
+		context.Background(),
+		agent,
+		instanaclient.NewInstanaAgentClient(c),
+	)
+
+	require.NoError(t, dependentLifecycleManager.UpdateDependentLifecycleInfo([]client.Object{current}))
+	require.ErrorIs(t, dependentLifecycleManager.CleanupDependents(current), expected)
+
+	requireExists(t, c, stale, true)
+	require.Equal(
+		t,
+		append(asInventory(current), agent.Status.Inventory...),
+		dependentLifecycleManager.Inventory(),
+	)
+}
+
+func TestUpdateDependentLifecycleInfoReturnsLegacyConfigMapError(t *testing.T) {
+	expected := errors.New("get failed")
+	c := newFakeClient(
+		t,
+		interceptor.Funcs{
+			Get: func(
+				ctx context.Context,
+				c client.WithWatch,
+				key types.NamespacedName,
+				obj client.Object,
+				opts ...client.GetOption,
 			) error {
-				config.TypeMeta = metav1.TypeMeta{
-					APIVersion: "v1",
-					Kind:       "ConfigMap",
-				}
-				config.ObjectMeta = metav1.ObjectMeta{
-					Name:      "asdasd",
-					Namespace: "asdasd",
-				}
-				config.Data = make(map[string]string, 1)
-				currentDependentsJson, _ := json.Marshal(asUnstructureds())
-				config.Data["v0.0.1-dev_1234"] = string(currentDependentsJson)
-				return nil
+				return expected
 			},
-			instanaAgent: instanav1.InstanaAgent{ObjectMeta: metav1.ObjectMeta{Generation: 1234}},
 		},
-	} {
-		t.Run(
-			test.name, func(t *testing.T) {
-
-				ctx := t.Context()
-
-				instanaAgentClient := &mocks.MockInstanaAgentClient{}
-				defer instanaAgentClient.AssertExpectations(t)
-				getReturn := test.clientGetterFunc(
-					context.Background(),
-					types.NamespacedName{},
-					&corev1.ConfigMap{})
-				instanaAgentClient.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-					Run(func(args mock.Arguments) {
-						if getReturn == nil {
-							_ = test.clientGetterFunc(
-								args.Get(0).(context.Context),
-								args.Get(1).(types.NamespacedName),
-								args.Get(2).(*corev1.ConfigMap))
-						}
-					}).
-					Return(getReturn)
-				instanaAgentClient.On("Apply", mock.Anything, mock.Anything, mock.Anything).
-					Return(result.Of[client.Object](&unstructured.Unstructured{}, nil))
-
-				dependentLifecycleManager := NewDependentLifecycleManager(
-					ctx,
-					&test.instanaAgent,
-					instanaAgentClient,
-				)
-				err := dependentLifecycleManager.UpdateDependentLifecycleInfo([]client.Object{})
-				assert.Nil(t, err)
-			},
-		)
-	}
+	)
+
+	dependentLifecycleManager := NewDependentLifecycleManager(
+		context.Background(),
+		agentAtGeneration(1),
+		instanaclient.NewInstanaAgentClient(c),
+	)
+
+	require.ErrorIs(t, dependentLifecycleManager.UpdateDependentLifecycleInfo(nil), expected)
 }
@@ -18,31 +18,163 @@ limitations under the License.
 package lifecycle
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
 )
 
-// asUnstructured is a simple utility function to convert controller client.Object to unstructured.Unstructured
-func asUnstructured(obj client.Object) unstructured.Unstructured {
-	res := unstructured.Unstructured{}
+// legacyContentHashAnnotation holds the content hash of a dependent within the records of the legacy lifecycle
+// ConfigMap
+const legacyContentHashAnnotation = "instana.io/content-hash"
 
-	res.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
-	res.SetName(obj.GetName())
-	res.SetNamespace(obj.GetNamespace())
+var secretGroupKind = corev1.SchemeGroupVersion.WithKind("Secret").GroupKind()
 
-	return res
+// contentHash returns the sha256 of the serialized object or an empty string if it can not be serialized. The data of
+// Secrets is left out, so that the hash in the status can not be used to guess the credentials they hold.
+func contentHash(obj client.Object) string {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return ""
+	}
+	if isSecret(obj) {
+		delete(content, "data")
+		delete(content, "stringData")
+	}
+	serialized, err := json.Marshal(content)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(serialized))
 }
 
-// asUnstructureds is a simple utility function to convert a list of client.Object to a list of unstructured.Unstructured
-func asUnstructureds(objects ...client.Object) []unstructured.Unstructured {
-	var unstructureds []unstructured.Unstructured
+func isSecret(obj client.Object) bool {
+	_, ok := obj.(*corev1.Secret)
+	return ok || obj.GetObjectKind().GroupVersionKind().GroupKind() == secretGroupKind
+}
+
+// asInventoryEntry records the identity of an object, its UID once known, and a hash of its content, never the content
+// itself
+func asInventoryEntry(obj client.Object) instanav1.ResourceInventoryEntry {
+	apiVersion, kind := obj.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
+	return instanav1.ResourceInventoryEntry{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		UID:        string(obj.GetUID()),
+		Hash:       contentHash(obj),
+	}
+}
+
+// asInventory is a simple utility function to convert a list of client.Object to their inventory entries
+func asInventory(objects ...client.Object) []instanav1.ResourceInventoryEntry {
+	inventory := make([]instanav1.ResourceInventoryEntry, 0, len(objects))
 	for _, obj := range objects {
-		unstructureds = append(unstructureds, asUnstructured(obj))
+		inventory = append(inventory, asInventoryEntry(obj))
+	}
+	return inventory
+}
+
+// asObject converts an inventory entry to an object that can be used to delete what it identifies
+func asObject(entry instanav1.ResourceInventoryEntry) client.Object {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(entryGroupVersionKind(entry))
+	obj.SetNamespace(entry.Namespace)
+	obj.SetName(entry.Name)
+	return obj
+}
+
+func entryGroupVersionKind(entry instanav1.ResourceInventoryEntry) schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(entry.APIVersion, entry.Kind)
+}
+
+// identityOf returns a key identifying an object by group, kind, namespace and name. The version is left out as the
+// same object may be served by several versions.
+func identityOf(gvk schema.GroupVersionKind, namespace string, name string) string {
+	return gvk.GroupKind().String() + "/" + namespace + "/" + name
+}
+
+func entryIdentity(entry instanav1.ResourceInventoryEntry) string {
+	return identityOf(entryGroupVersionKind(entry), entry.Namespace, entry.Name)
+}
+
+func objectIdentity(obj client.Object) string {
+	return identityOf(obj.GetObjectKind().GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+}
+
+// mergeInventories returns all entries of current followed by the entries of previous that aren't part of current
+func mergeInventories(
+	current []instanav1.ResourceInventoryEntry,
+	previous []instanav1.ResourceInventoryEntry,
+) []instanav1.ResourceInventoryEntry {
+	merged := make([]instanav1.ResourceInventoryEntry, 0, len(current)+len(previous))
+	seen := make(map[string]struct{}, len(current)+len(previous))
+
+	for _, entries := range [][]instanav1.ResourceInventoryEntry{current, previous} {
+		for _, entry := range entries {
+			if _, ok := seen[entryIdentity(entry)]; ok {
+				continue
+			}
+			seen[entryIdentity(entry)] = struct{}{}
+			merged = append(merged, entry)
+		}
 	}
-	return unstructureds
+
+	return merged
 }
 
-// asObject is a simple utility function to convert unstructured.Unstructured to controller client.Object
-func asObject(val unstructured.Unstructured) client.Object {
-	return &val
+// withUIDs sets the UIDs of the applied objects on their inventory entries, the UIDs are only known after the objects
+// have been applied and therefore can't be recorded before
+func withUIDs(
+	inventory []instanav1.ResourceInventoryEntry,
+	applied []client.Object,
+) []instanav1.ResourceInventoryEntry {
+	uids := make(map[string]string, len(applied))
+	for _, obj := range applied {
+		if obj.GetUID() != "" {
+			uids[objectIdentity(obj)] = string(obj.GetUID())
+		}
+	}
+
+	for i := range inventory {
+		if uid, ok := uids[entryIdentity(inventory[i])]; ok {
+			inventory[i].UID = uid
+		}
+	}
+
+	return inventory
+}
+
+// legacyInventory reads the generations recorded in the "<name>-dependents" ConfigMap used by earlier operator
+// versions, entries that can not be parsed are skipped
+func legacyInventory(data map[string]string) []instanav1.ResourceInventoryEntry {
+	var inventory []instanav1.ResourceInventoryEntry
+
+	for _, jsonString := range data {
+		var records []unstructured.Unstructured
+		if err := json.Unmarshal([]byte(jsonString), &records); err != nil {
+			continue
+		}
+		for _, record := range records {
+			apiVersion, kind := record.GroupVersionKind().ToAPIVersionAndKind()
+			inventory = append(inventory, instanav1.ResourceInventoryEntry{
+				APIVersion: apiVersion,
+				Kind:       kind,
+				Namespace:  record.GetNamespace(),
+				Name:       record.GetName(),
+				UID:        string(record.GetUID()),
+				Hash:       record.GetAnnotations()[legacyContentHashAnnotation],
+			})
+		}
+	}
+
+	return mergeInventories(nil, inventory)
 }
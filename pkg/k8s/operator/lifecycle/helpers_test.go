@@ -0,0 +1,126 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+)
+
+func newKeysSecret(key string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", Namespace: "instana-agent"},
+		Data:       map[string][]byte{"key": []byte(key)},
+	}
+}
+
+func TestAsInventoryEntryOnlyRecordsIdentityAndContentHash(t *testing.T) {
+	assertions := require.New(t)
+
+	secret := newKeysSecret("super-secret-agent-key")
+	secret.UID = "uid-1"
+
+	entry := asInventoryEntry(secret)
+
+	assertions.Equal("v1", entry.APIVersion)
+	assertions.Equal("Secret", entry.Kind)
+	assertions.Equal("instana-agent", entry.Namespace)
+	assertions.Equal("instana-agent", entry.Name)
+	assertions.Equal("uid-1", entry.UID)
+	assertions.Len(entry.Hash, 64)
+	assertions.NotContains(entry.Hash, "super-secret-agent-key")
+
+	rotated := newKeysSecret("rotated-agent-key")
+	rotated.UID = "uid-1"
+	rotated.StringData = map[string]string{"key": "super-secret-agent-key"}
+	assertions.Equal(entry.Hash, asInventoryEntry(rotated).Hash)
+
+	relabeled := newKeysSecret("super-secret-agent-key")
+	relabeled.UID = "uid-1"
+	relabeled.Labels = map[string]string{"app": "instana-agent"}
+	assertions.NotEqual(entry.Hash, asInventoryEntry(relabeled).Hash)
+
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetName("instana-agent")
+	hash := asInventoryEntry(configMap).Hash
+	assertions.NoError(unstructured.SetNestedField(configMap.Object, "value", "data", "key"))
+	assertions.NotEqual(hash, asInventoryEntry(configMap).Hash)
+}
+
+func TestMergeInventoriesPrefersCurrentEntries(t *testing.T) {
+	current := []instanav1.ResourceInventoryEntry{
+		{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: "instana-agent", Name: "instana-agent", Hash: "new"},
+	}
+	previous := []instanav1.ResourceInventoryEntry{
+		{APIVersion: "apps/v1beta2", Kind: "DaemonSet", Namespace: "instana-agent", Name: "instana-agent"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "instana-agent", Name: "instana-agent", Hash: "old"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "instana-agent", Name: "instana-agent", Hash: "older"},
+	}
+
+	require.Equal(
+		t,
+		[]instanav1.ResourceInventoryEntry{current[0], previous[1]},
+		mergeInventories(current, previous),
+	)
+}
+
+func TestWithUIDs(t *testing.T) {
+	applied := newKeysSecret("key")
+	applied.UID = "uid-1"
+
+	require.Equal(
+		t,
+		[]instanav1.ResourceInventoryEntry{
+			{APIVersion: "v1", Kind: "Secret", Namespace: "instana-agent", Name: "instana-agent", UID: "uid-1"},
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "instana-agent", Name: "instana-agent"},
+		},
+		withUIDs(
+			[]instanav1.ResourceInventoryEntry{
+				{APIVersion: "v1", Kind: "Secret", Namespace: "instana-agent", Name: "instana-agent"},
+				{APIVersion: "v1", Kind: "ConfigMap", Namespace: "instana-agent", Name: "instana-agent"},
+			},
+			[]client.Object{applied},
+		),
+	)
+}
+
+func TestLegacyInventorySkipsUnparsableGenerations(t *testing.T) {
+	inventory := legacyInventory(map[string]string{
+		"v0.0.1_1": `[{"apiVersion":"v1","kind":"Secret","metadata":{"name":"a","namespace":"ns","uid":"uid-a",` +
+			`"annotations":{"instana.io/content-hash":"hash-a"}}},` +
+			`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"a","namespace":"ns"}}]`,
+		"invalid": "not a list of records",
+	})
+
+	require.Equal(
+		t,
+		[]instanav1.ResourceInventoryEntry{
+			{APIVersion: "v1", Kind: "Secret", Namespace: "ns", Name: "a", UID: "uid-a", Hash: "hash-a"},
+		},
+		inventory,
+	)
+}
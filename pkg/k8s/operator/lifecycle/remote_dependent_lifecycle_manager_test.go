@@ -18,371 +18,61 @@ package lifecycle
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
-	"strconv"
 	"testing"
 
-	instanav1 "github.com/instana/instana-agent-operator/api/v1"
-	"github.com/instana/instana-agent-operator/internal/mocks"
-	"github.com/instana/instana-agent-operator/pkg/multierror"
-	"github.com/instana/instana-agent-operator/pkg/result"
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	instanaclient "github.com/instana/instana-agent-operator/pkg/k8s/client"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
 )
 
-func genMockObjsRemote(amount int) []client.Object {
-	objects := []client.Object{}
-	for i := range amount {
-		unstrctrd := &unstructured.Unstructured{}
-		unstrctrd.SetName("i" + strconv.Itoa(i))
-		unstrctrd.SetKind("remote-agent-data")
-		objects = append(objects, unstrctrd)
+func remoteDependent(agent *instanav1.InstanaAgentRemote, name string) client.Object {
+	obj := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
 	}
-	return objects
-}
-
-func TestAsObjectConversionRemote(t *testing.T) {
-	conversion := asObject(unstructured.Unstructured{})
-	var expectedType client.Object = conversion
-	assert.IsType(t, expectedType, conversion)
-}
-
-// TestClenupDependentsDeletesUnmatchedData - In an instance where ConfigMap.Data
-// contains more than the current generated key to hold data in, the code will
-// remove that field from the array
-func TestCleanupDependentsDeletesUnmatchedDataRemote(t *testing.T) {
-	ctx := t.Context()
-
-	instanaAgentClient := &mocks.MockInstanaAgentClient{}
-	defer instanaAgentClient.AssertExpectations(t)
-
-	// Create two client.Object arrays which have some overlap for comparisons and deletion calls
-	oldDependentsJson := genMockObjsRemote(10)
-	currentDependentsJson := genMockObjsRemote(5)
-	currentDependentsJson = append(currentDependentsJson, oldDependentsJson[:5]...)
-
-	instanaAgentClient.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Run(func(args mock.Arguments) {
-			config := args[2].(*corev1.ConfigMap)
-			config.TypeMeta = metav1.TypeMeta{
-				APIVersion: "v1",
-				Kind:       "ConfigMap",
-			}
-			config.ObjectMeta = metav1.ObjectMeta{
-				Name:      "asdasd",
-				Namespace: "asdasd",
-			}
-
-			config.Data = make(map[string]string, 1)
-			olderDependentsJsonString, _ := json.Marshal(asUnstructureds(oldDependentsJson...))
-			config.Data["v0.0.1-dev"] = string(olderDependentsJsonString)
-			config.Data["v0.0.1-dev-to-be-deleted"] = string(olderDependentsJsonString)
-		}).
-		Return(nil).
-		Once()
-
-	instanaAgentClient.On("DeleteAllInTimeLimit",
-		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(result.Of(genMockObjsRemote(1), nil)).
-		Times(2)
-
-	var obj client.Object = &unstructured.Unstructured{}
-	instanaAgentClient.On("Apply", mock.Anything, mock.Anything, mock.Anything).
-		Return(result.Of(obj, nil)).
-		Once()
-
-	dependentLifecycleManager := NewRemoteDependentLifecycleManager(
-		ctx,
-		&instanav1.InstanaAgentRemote{},
-		instanaAgentClient,
-	)
-
-	err := dependentLifecycleManager.CleanupDependents(currentDependentsJson...)
-	assert.Nil(t, err)
-}
-
-// TestCleanupDependentsDeleteAllReturnsError - returns an error from the function
-// delete all and returns that correctly back to the caller
-func TestCleanupDependentsDeleteAllReturnsErrorRemote(t *testing.T) {
-
-	ctx := t.Context()
-
-	instanaAgentClient := &mocks.MockInstanaAgentClient{}
-	defer instanaAgentClient.AssertExpectations(t)
-
-	instanaAgentClient.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Run(func(args mock.Arguments) {
-			config := args[2].(*corev1.ConfigMap)
-			config.TypeMeta = metav1.TypeMeta{
-				APIVersion: "v1",
-				Kind:       "ConfigMap",
-			}
-			config.ObjectMeta = metav1.ObjectMeta{
-				Name:      "asdasd",
-				Namespace: "asdasd",
-			}
-
-			config.Data = make(map[string]string, 1)
-			currentDependentsJson, _ := json.Marshal(asUnstructureds(genMockObjsRemote(12)...))
-			config.Data["v0.0.1-dev_1234"] = string(currentDependentsJson)
-		}).
-		Return(nil)
-
-	errBuilder := multierror.NewMultiErrorBuilder()
-	expected := errors.New("Error returned from d.deleteAll")
-	errBuilder.Add(expected)
-
-	instanaAgentClient.On("DeleteAllInTimeLimit",
-		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(result.Of(genMockObjsRemote(1), expected)).
-		Once()
 
-	var obj client.Object = &unstructured.Unstructured{}
-	instanaAgentClient.On("Apply", mock.Anything, mock.Anything, mock.Anything).
-		Return(result.Of(obj, nil)).
-		Once()
-
-	dependentLifecycleManager := NewRemoteDependentLifecycleManager(
-		ctx,
-		&instanav1.InstanaAgentRemote{},
-		instanaAgentClient,
-	)
-
-	err := dependentLifecycleManager.CleanupDependents(genMockObjsRemote(10)...)
-
-	assert.True(t, errors.Is(errBuilder.Build(), err))
+	t := transformations.NewTransformationsRemote(agent)
+	t.AddCommonLabels(obj, "instana-agent-remote")
+	t.AddOwnerReference(obj)
+	return obj
 }
 
-func TestCleanupDependentsRemote(t *testing.T) {
-	for _, test := range []struct {
-		name             string
-		expected         string
-		instanaAgent     instanav1.InstanaAgentRemote
-		generatedObjects int
-		clientGetterFunc func(
-			_ context.Context,
-			_ types.NamespacedName,
-			config *corev1.ConfigMap,
-			opts ...client.GetOption,
-		) error
-	}{
-		{
-			name:             "Completely successsful run of CleanupDependents without ConfigMap.Data containing any data",
-			expected:         "",
-			generatedObjects: 0,
-			clientGetterFunc: func(
-				_ context.Context,
-				_ types.NamespacedName,
-				config *corev1.ConfigMap,
-				opts ...client.GetOption,
-			) error {
-				config.TypeMeta = metav1.TypeMeta{
-					APIVersion: "v1",
-					Kind:       "ConfigMap",
-				}
-				config.ObjectMeta = metav1.ObjectMeta{
-					Name:      "asdasd",
-					Namespace: "asdasd",
-				}
-
-				config.Data = make(map[string]string, 1)
-				currentDependentsJson, _ := json.Marshal(asUnstructureds(genMockObjsRemote(0)...))
-				config.Data["v0.0.1-dev_1234"] = string(currentDependentsJson)
-
-				return nil
+func TestRemoteCleanupDependentsDeletesDependentsOfEarlierGenerations(t *testing.T) {
+	agentAt := func(generation int64) *instanav1.InstanaAgentRemote {
+		return &instanav1.InstanaAgentRemote{
+			TypeMeta: metav1.TypeMeta{APIVersion: "instana.io/v1", Kind: "InstanaAgentRemote"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "remote-agent",
+				Namespace:  testNamespace,
+				UID:        "remote-agent-uid",
+				Generation: generation,
 			},
-			instanaAgent: instanav1.InstanaAgentRemote{ObjectMeta: metav1.ObjectMeta{Generation: 1234}},
-		},
-		{
-			name:             "Completely successful run of CleanupDependents with ConfigMap.Data containing already data",
-			expected:         "",
-			generatedObjects: 10,
-			clientGetterFunc: func(
-				_ context.Context,
-				_ types.NamespacedName,
-				config *corev1.ConfigMap,
-				opts ...client.GetOption,
-			) error {
-				config.TypeMeta = metav1.TypeMeta{
-					APIVersion: "v1",
-					Kind:       "ConfigMap",
-				}
-				config.ObjectMeta = metav1.ObjectMeta{
-					Name:      "asdasd",
-					Namespace: "asdasd",
-				}
-
-				config.Data = make(map[string]string, 1)
-				currentDependentsJson, _ := json.Marshal(asUnstructureds(genMockObjsRemote(12)...))
-				config.Data["v0.0.1-dev_1234"] = string(currentDependentsJson)
-
-				return nil
-			},
-			instanaAgent: instanav1.InstanaAgentRemote{ObjectMeta: metav1.ObjectMeta{Generation: 1234}},
-		},
-	} {
-		t.Run(
-			test.name, func(t *testing.T) {
-				assertions := require.New(t)
-				ctx := t.Context()
-
-				instanaAgentClient := &mocks.MockInstanaAgentClient{}
-				defer instanaAgentClient.AssertExpectations(t)
-
-				instanaAgentClient.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-					Run(func(args mock.Arguments) {
-						config := args[2].(*corev1.ConfigMap)
-						_ = test.clientGetterFunc(
-							args[0].(context.Context),
-							args[1].(types.NamespacedName),
-							config, nil)
-					}).
-					Return(nil).
-					Once()
-				instanaAgentClient.On("DeleteAllInTimeLimit",
-					mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-					Return(result.Of(genMockObjsRemote(test.generatedObjects), nil)).
-					Once()
-				var obj client.Object = &unstructured.Unstructured{}
-				instanaAgentClient.On("Apply", mock.Anything, mock.Anything, mock.Anything).
-					Return(result.Of(obj, nil)).
-					Once()
-
-				dependentLifecycleManager := NewRemoteDependentLifecycleManager(
-					ctx,
-					&test.instanaAgent,
-					instanaAgentClient,
-				)
-
-				err := dependentLifecycleManager.CleanupDependents(genMockObjsRemote(test.generatedObjects)...)
-				assertions.Nil(err)
-			},
-		)
+		}
 	}
+	oldAgent := agentAt(1)
+	agent := agentAt(2)
 
-}
-
-func TestUpdateLifecycleInfoRemote(t *testing.T) {
-	for _, test := range []struct {
-		name             string
-		expected         string
-		instanaAgent     instanav1.InstanaAgentRemote
-		clientGetterFunc func(
-			_ context.Context,
-			_ types.NamespacedName,
-			config *corev1.ConfigMap,
-			opts ...client.GetOption,
-		) error
-	}{
-		{
-			name:     "Should get ConfigMap successfully and do changes accordingly UpdateLifecycleInfo with it",
-			expected: "",
-			clientGetterFunc: func(
-				_ context.Context,
-				_ types.NamespacedName,
-				config *corev1.ConfigMap,
-				opts ...client.GetOption,
-			) error {
-				config.TypeMeta = metav1.TypeMeta{
-					APIVersion: "v1",
-					Kind:       "ConfigMap",
-				}
-				config.ObjectMeta = metav1.ObjectMeta{
-					Name:      "asdasd",
-					Namespace: "asdasd",
-				}
-				return nil
-			},
-			instanaAgent: instanav1.InstanaAgentRemote{},
-		},
-		{
-			name:     "Should initialize ConfigMap if NotFound error was given and UpdateLifecycleInfo",
-			expected: "",
-			clientGetterFunc: func(
-				_ context.Context,
-				_ types.NamespacedName,
-				config *corev1.ConfigMap,
-				opts ...client.GetOption,
-			) error {
-				return k8serrors.NewNotFound(schema.GroupResource{}, "asdasd")
-			},
-			instanaAgent: instanav1.InstanaAgentRemote{},
-		},
-		{
-			name:     "Should initialize empty ConfigMap on error and UpdateLifecycleInfo",
-			expected: "",
-			clientGetterFunc: func(
-				_ context.Context,
-				_ types.NamespacedName,
-				config *corev1.ConfigMap,
-				opts ...client.GetOption,
-			) error {
-				return errors.New("An expected error occurred")
-			},
-			instanaAgent: instanav1.InstanaAgentRemote{},
-		},
-		{
-			name:     "Should run with existing ConfigMap.Data field populated and UpdateLifecycleInfo",
-			expected: "",
-			clientGetterFunc: func(
-				_ context.Context,
-				_ types.NamespacedName,
-				config *corev1.ConfigMap,
-				opts ...client.GetOption,
-			) error {
-				config.TypeMeta = metav1.TypeMeta{
-					APIVersion: "v1",
-					Kind:       "ConfigMap",
-				}
-				config.ObjectMeta = metav1.ObjectMeta{
-					Name:      "asdasd",
-					Namespace: "asdasd",
-				}
-				config.Data = make(map[string]string, 1)
-				currentDependentsJson, _ := json.Marshal(asUnstructureds())
-				config.Data["v0.0.1-dev_1234"] = string(currentDependentsJson)
-				return nil
-			},
-			instanaAgent: instanav1.InstanaAgentRemote{ObjectMeta: metav1.ObjectMeta{Generation: 1234}},
-		},
-	} {
-		t.Run(
-			test.name, func(t *testing.T) {
+	current := remoteDependent(agent, "current")
+	stale := remoteDependent(oldAgent, "stale")
 
-				ctx := t.Context()
+	c := newFakeClient(t, interceptor.Funcs{}, current, stale)
 
-				instanaAgentClient := &mocks.MockInstanaAgentClient{}
-				defer instanaAgentClient.AssertExpectations(t)
+	dependentLifecycleManager := NewRemoteDependentLifecycleManager(
+		context.Background(),
+		agent,
+		instanaclient.NewInstanaAgentClient(c),
+	)
 
-				instanaAgentClient.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-					Run(func(args mock.Arguments) {
-						config := args[2].(*corev1.ConfigMap)
-						_ = test.clientGetterFunc(
-							args[0].(context.Context),
-							args[1].(types.NamespacedName),
-							config, nil)
-					}).
-					Return(nil)
-				instanaAgentClient.On("Apply", mock.Anything, mock.Anything, mock.Anything).
-					Return(result.Of[client.Object](&unstructured.Unstructured{}, nil))
+	require.NoError(t, dependentLifecycleManager.UpdateDependentLifecycleInfo([]client.Object{current}))
+	require.NoError(t, dependentLifecycleManager.CleanupDependents(current))
 
-				dependentLifecycleManager := NewRemoteDependentLifecycleManager(
-					ctx,
-					&test.instanaAgent,
-					instanaAgentClient,
-				)
-				err := dependentLifecycleManager.UpdateDependentLifecycleInfo([]client.Object{})
-				assert.Nil(t, err)
-			},
-		)
-	}
+	requireExists(t, c, current, true)
+	requireExists(t, c, stale, false)
+	require.Equal(t, asInventory(current), dependentLifecycleManager.Inventory())
 }
@@ -0,0 +1,229 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics holds the operator specific metrics served on the metrics endpoint of the manager next to the
+// default metrics of controller-runtime. All of them are labelled with the namespace and name of the agent CR.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
+)
+
+const (
+	namespaceLabel = "namespace"
+	nameLabel      = "name"
+	zoneLabel      = "zone"
+	endpointLabel  = "endpoint"
+)
+
+const metricsPrefix = "instana_agent_operator_"
+
+var (
+	agentsDesired = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: metricsPrefix + "agents_desired",
+			Help: "Number of nodes that should run an Instana agent, per agent CR and zone",
+		},
+		[]string{namespaceLabel, nameLabel, zoneLabel},
+	)
+	agentsReady = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: metricsPrefix + "agents_ready",
+			Help: "Number of nodes running a ready Instana agent, per agent CR and zone",
+		},
+		[]string{namespaceLabel, nameLabel, zoneLabel},
+	)
+	k8sSensorReplicasReady = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: metricsPrefix + "k8sensor_replicas_ready",
+			Help: "Number of ready k8sensor replicas, per agent CR and backend endpoint",
+		},
+		[]string{namespaceLabel, nameLabel, endpointLabel},
+	)
+	backends = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: metricsPrefix + "backends",
+			Help: "Number of backends configured for the agent CR",
+		},
+		[]string{namespaceLabel, nameLabel},
+	)
+	etcdTargetsDiscovered = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: metricsPrefix + "etcd_targets_discovered",
+			Help: "Number of ETCD targets monitored by the k8sensor of the agent CR, 0 while ETCD monitoring is disabled",
+		},
+		[]string{namespaceLabel, nameLabel},
+	)
+	lifecycleCleanupFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: metricsPrefix + "lifecycle_cleanup_failures_total",
+			Help: "Number of times cleaning up the dependents of the agent CR failed",
+		},
+		[]string{namespaceLabel, nameLabel},
+	)
+	dryRunFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: metricsPrefix + "dry_run_failures_total",
+			Help: "Number of times dry-running the apply of the dependents of the agent CR failed",
+		},
+		[]string{namespaceLabel, nameLabel},
+	)
+	lastSuccessfulReconcile = newReconcileAgeCollector()
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		agentsDesired,
+		agentsReady,
+		k8sSensorReplicasReady,
+		backends,
+		etcdTargetsDiscovered,
+		lifecycleCleanupFailures,
+		dryRunFailures,
+		lastSuccessfulReconcile,
+	)
+}
+
+func crLabels(cr client.Object) prometheus.Labels {
+	return prometheus.Labels{namespaceLabel: cr.GetNamespace(), nameLabel: cr.GetName()}
+}
+
+// RecordAgents reports the desired and ready agents of every DaemonSet of the agent CR, labelled with the zone of the
+// DaemonSet. DaemonSets of zones that no longer exist are no longer reported.
+func RecordAgents(cr client.Object, daemonsets []appsv1.DaemonSet) {
+	agentsDesired.DeletePartialMatch(crLabels(cr))
+	agentsReady.DeletePartialMatch(crLabels(cr))
+
+	for _, ds := range daemonsets {
+		zone := ds.Spec.Template.Labels[transformations.ZoneLabel]
+		agentsDesired.WithLabelValues(cr.GetNamespace(), cr.GetName(), zone).Set(float64(ds.Status.DesiredNumberScheduled))
+		agentsReady.WithLabelValues(cr.GetNamespace(), cr.GetName(), zone).Set(float64(ds.Status.NumberReady))
+	}
+}
+
+// RecordK8sSensors reports the ready k8sensor replicas of every backend of the agent CR
+func RecordK8sSensors(cr client.Object, k8sSensors []instanav1.K8sSensorStatus) {
+	k8sSensorReplicasReady.DeletePartialMatch(crLabels(cr))
+
+	for _, k8sSensor := range k8sSensors {
+		k8sSensorReplicasReady.WithLabelValues(cr.GetNamespace(), cr.GetName(), k8sSensor.Endpoint).
+			Set(float64(k8sSensor.Ready))
+	}
+}
+
+// RecordBackends reports the number of backends configured for the agent CR
+func RecordBackends(cr client.Object, count int) {
+	backends.With(crLabels(cr)).Set(float64(count))
+}
+
+// RecordETCDTargets reports the number of ETCD targets monitored for the agent CR, whether they were discovered,
+// configured in the spec or provided by OpenShift
+func RecordETCDTargets(cr client.Object, count int) {
+	etcdTargetsDiscovered.With(crLabels(cr)).Set(float64(count))
+}
+
+// IncLifecycleCleanupFailures counts a failed cleanup of the dependents of the agent CR
+func IncLifecycleCleanupFailures(cr client.Object) {
+	lifecycleCleanupFailures.With(crLabels(cr)).Inc()
+}
+
+// IncDryRunFailures counts a failed dry-run of the dependents of the agent CR
+func IncDryRunFailures(cr client.Object) {
+	dryRunFailures.With(crLabels(cr)).Inc()
+}
+
+// RecordReconcileSucceeded marks the agent CR as reconciled successfully just now
+func RecordReconcileSucceeded(cr client.Object) {
+	lastSuccessfulReconcile.set(types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}, time.Now())
+}
+
+// Forget stops reporting all metrics of the agent CR with the given key, to be called once the CR is gone
+func Forget(key types.NamespacedName) {
+	labels := prometheus.Labels{namespaceLabel: key.Namespace, nameLabel: key.Name}
+	for _, vec := range []*prometheus.MetricVec{
+		agentsDesired.MetricVec,
+		agentsReady.MetricVec,
+		k8sSensorReplicasReady.MetricVec,
+		backends.MetricVec,
+		etcdTargetsDiscovered.MetricVec,
+		lifecycleCleanupFailures.MetricVec,
+		dryRunFailures.MetricVec,
+	} {
+		vec.DeletePartialMatch(labels)
+	}
+	lastSuccessfulReconcile.delete(key)
+}
+
+// reconcileAgeCollector reports the time passed since the last successful reconcile of every agent CR as of the
+// moment the metrics are scraped
+type reconcileAgeCollector struct {
+	desc        *prometheus.Desc
+	mu          sync.Mutex
+	lastSuccess map[types.NamespacedName]time.Time
+}
+
+func newReconcileAgeCollector() *reconcileAgeCollector {
+	return &reconcileAgeCollector{
+		desc: prometheus.NewDesc(
+			metricsPrefix+"seconds_since_last_successful_reconcile",
+			"Seconds passed since the last successful reconcile of the agent CR",
+			[]string{namespaceLabel, nameLabel},
+			nil,
+		),
+		lastSuccess: make(map[types.NamespacedName]time.Time),
+	}
+}
+
+func (c *reconcileAgeCollector) set(key types.NamespacedName, t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastSuccess[key] = t
+}
+
+func (c *reconcileAgeCollector) delete(key types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.lastSuccess, key)
+}
+
+func (c *reconcileAgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *reconcileAgeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, t := range c.lastSuccess {
+		ch <- prometheus.MustNewConstMetric(
+			c.desc,
+			prometheus.GaugeValue,
+			time.Since(t).Seconds(),
+			key.Namespace,
+			key.Name,
+		)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
+)
+
+func zoneDaemonSet(zone string, desired int32, ready int32) appsv1.DaemonSet {
+	return appsv1.DaemonSet{
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{transformations.ZoneLabel: zone}},
+			},
+		},
+		Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: desired, NumberReady: ready},
+	}
+}
+
+func TestRecordAgentsReportsZonesAndDropsRemovedZones(t *testing.T) {
+	assertions := require.New(t)
+
+	agent := &instanav1.InstanaAgent{ObjectMeta: metav1.ObjectMeta{Name: "zones", Namespace: "instana-agent"}}
+	defer Forget(types.NamespacedName{Namespace: "instana-agent", Name: "zones"})
+
+	RecordAgents(agent, []appsv1.DaemonSet{zoneDaemonSet("east", 3, 2), zoneDaemonSet("west", 2, 2)})
+	assertions.Equal(3.0, testutil.ToFloat64(agentsDesired.WithLabelValues("instana-agent", "zones", "east")))
+	assertions.Equal(2.0, testutil.ToFloat64(agentsReady.WithLabelValues("instana-agent", "zones", "east")))
+	assertions.Equal(2.0, testutil.ToFloat64(agentsReady.WithLabelValues("instana-agent", "zones", "west")))
+
+	RecordAgents(agent, []appsv1.DaemonSet{zoneDaemonSet("east", 3, 3)})
+	assertions.Equal(1, testutil.CollectAndCount(agentsDesired))
+	assertions.Equal(3.0, testutil.ToFloat64(agentsReady.WithLabelValues("instana-agent", "zones", "east")))
+}
+
+func TestRecordK8sSensors(t *testing.T) {
+	assertions := require.New(t)
+
+	agent := &instanav1.InstanaAgent{ObjectMeta: metav1.ObjectMeta{Name: "k8sensors", Namespace: "instana-agent"}}
+	defer Forget(types.NamespacedName{Namespace: "instana-agent", Name: "k8sensors"})
+
+	RecordK8sSensors(
+		agent,
+		[]instanav1.K8sSensorStatus{
+			{Endpoint: "ingress-red.instana.io:443", Ready: 3},
+			{Endpoint: "ingress-blue.instana.io:443", Ready: 1},
+		},
+	)
+	blue := k8sSensorReplicasReady.WithLabelValues("instana-agent", "k8sensors", "ingress-blue.instana.io:443")
+	assertions.Equal(1.0, testutil.ToFloat64(blue))
+
+	RecordK8sSensors(agent, nil)
+	assertions.Equal(0, testutil.CollectAndCount(k8sSensorReplicasReady))
+}
+
+func TestForgetRemovesAllMetricsOfTheCR(t *testing.T) {
+	assertions := require.New(t)
+
+	agent := &instanav1.InstanaAgent{ObjectMeta: metav1.ObjectMeta{Name: "forgotten", Namespace: "instana-agent"}}
+	other := &instanav1.InstanaAgent{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "instana-agent"}}
+	defer Forget(types.NamespacedName{Namespace: "instana-agent", Name: "other"})
+
+	for _, cr := range []*instanav1.InstanaAgent{agent, other} {
+		RecordBackends(cr, 2)
+		RecordETCDTargets(cr, 3)
+		IncLifecycleCleanupFailures(cr)
+		IncDryRunFailures(cr)
+		RecordReconcileSucceeded(cr)
+	}
+	assertions.Equal(2.0, testutil.ToFloat64(backends.WithLabelValues("instana-agent", "forgotten")))
+	assertions.Equal(1.0, testutil.ToFloat64(dryRunFailures.WithLabelValues("instana-agent", "forgotten")))
+	assertions.Equal(2, testutil.CollectAndCount(lastSuccessfulReconcile))
+
+	Forget(types.NamespacedName{Namespace: "instana-agent", Name: "forgotten"})
+
+	assertions.Equal(1, testutil.CollectAndCount(backends))
+	assertions.Equal(1, testutil.CollectAndCount(etcdTargetsDiscovered))
+	assertions.Equal(1, testutil.CollectAndCount(lifecycleCleanupFailures))
+	assertions.Equal(1, testutil.CollectAndCount(dryRunFailures))
+	assertions.Equal(1, testutil.CollectAndCount(lastSuccessfulReconcile))
+}
+
+func TestReconcileAgeCollectorReportsSecondsSinceLastSuccess(t *testing.T) {
+	assertions := require.New(t)
+
+	collector := newReconcileAgeCollector()
+	collector.set(types.NamespacedName{Namespace: "instana-agent", Name: "instana-agent"}, time.Now().Add(-time.Minute))
+
+	age := testutil.ToFloat64(collector)
+	assertions.GreaterOrEqual(age, 60.0)
+	assertions.Less(age, 120.0)
+}
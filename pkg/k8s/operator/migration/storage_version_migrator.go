@@ -0,0 +1,151 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var crdGVK = schema.GroupVersionKind{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1",
+	Kind:    "CustomResourceDefinition",
+}
+
+// StorageVersionMigrator rewrites all stored objects of a CRD in the current storage version of the CRD and afterwards
+// drops all other versions from the CRD's status.storedVersions, so that older versions can be removed safely.
+type StorageVersionMigrator struct {
+	client  client.Client
+	reader  client.Reader
+	crdName string
+}
+
+var _ manager.LeaderElectionRunnable = &StorageVersionMigrator{}
+
+// NewStorageVersionMigrator creates a migrator for the CRD with the given name, e.g. agents.instana.io. The reader should
+// not be backed by the manager cache, as CRDs and all versions of the custom resources are read only once.
+func NewStorageVersionMigrator(client client.Client, reader client.Reader, crdName string) *StorageVersionMigrator {
+	return &StorageVersionMigrator{
+		client:  client,
+		reader:  reader,
+		crdName: crdName,
+	}
+}
+
+// NeedLeaderElection ensures only the active operator instance rewrites the stored objects
+func (m *StorageVersionMigrator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the migration once, failures are logged and retried on the next operator start
+func (m *StorageVersionMigrator) Start(ctx context.Context) error {
+	if err := m.migrate(ctx); err != nil {
+		logf.FromContext(ctx).
+			WithName("storage-version-migrator").
+			Error(err, "failed to migrate stored objects to the storage version", "crd", m.crdName)
+	}
+
+	return nil
+}
+
+func (m *StorageVersionMigrator) migrate(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("storage-version-migrator").WithValues("crd", m.crdName)
+
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdGVK)
+	if err := m.reader.Get(ctx, types.NamespacedName{Name: m.crdName}, crd); err != nil {
+		return fmt.Errorf("failed to get CRD: %w", err)
+	}
+
+	storageVersion, err := getStorageVersion(crd)
+	if err != nil {
+		return err
+	}
+
+	storedVersions, _, err := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+	if err != nil {
+		return fmt.Errorf("failed to read stored versions of CRD: %w", err)
+	}
+	if slices.Equal(storedVersions, []string{storageVersion}) {
+		log.V(1).Info("all objects are stored in the storage version", "version", storageVersion)
+		return nil
+	}
+
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	listKind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "listKind")
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: group, Version: storageVersion, Kind: listKind})
+	if err := m.reader.List(ctx, list); err != nil {
+		return fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	log.Info(
+		"rewriting objects in the storage version",
+		"storedVersions", storedVersions,
+		"version", storageVersion,
+		"count", len(list.Items),
+	)
+
+	for i := range list.Items {
+		obj := &list.Items[i]
+		// an empty patch makes the API server persist the object again, encoded in the current storage version
+		if err := m.client.Patch(ctx, obj, client.RawPatch(types.MergePatchType, []byte("{}"))); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to rewrite %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		}
+	}
+
+	if err := unstructured.SetNestedStringSlice(crd.Object, []string{storageVersion}, "status", "storedVersions"); err != nil {
+		return fmt.Errorf("failed to set stored versions of CRD: %w", err)
+	}
+	if err := m.client.Status().Update(ctx, crd); err != nil {
+		return fmt.Errorf("failed to update stored versions of CRD: %w", err)
+	}
+
+	log.Info("migrated all objects to the storage version", "version", storageVersion)
+
+	return nil
+}
+
+func getStorageVersion(crd *unstructured.Unstructured) (string, error) {
+	versions, _, err := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	if err != nil {
+		return "", fmt.Errorf("failed to read versions of CRD: %w", err)
+	}
+
+	for _, version := range versions {
+		versionMap, ok := version.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if storage, _, _ := unstructured.NestedBool(versionMap, "storage"); storage {
+			name, _, _ := unstructured.NestedString(versionMap, "name")
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("CRD has no storage version")
+}
@@ -0,0 +1,143 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var agentGVK = schema.GroupVersionKind{Group: "instana.io", Version: "v2", Kind: "InstanaAgent"}
+
+func newCRD(storedVersions ...string) *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "agents.instana.io"},
+			"spec": map[string]interface{}{
+				"group": "instana.io",
+				"names": map[string]interface{}{
+					"kind":     "InstanaAgent",
+					"listKind": "InstanaAgentList",
+				},
+				"versions": []interface{}{
+					map[string]interface{}{"name": "v1", "served": true, "storage": false},
+					map[string]interface{}{"name": "v2", "served": true, "storage": true},
+				},
+			},
+		},
+	}
+	crd.SetGroupVersionKind(crdGVK)
+	_ = unstructured.SetNestedStringSlice(crd.Object, storedVersions, "status", "storedVersions")
+	return crd
+}
+
+func newAgent(name string) *unstructured.Unstructured {
+	agent := &unstructured.Unstructured{}
+	agent.SetGroupVersionKind(agentGVK)
+	agent.SetNamespace("instana-agent")
+	agent.SetName(name)
+	return agent
+}
+
+func newFakeClient(objs ...client.Object) client.WithWatch {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(crdGVK, meta.RESTScopeRoot)
+	mapper.Add(agentGVK, meta.RESTScopeNamespace)
+
+	return fake.NewClientBuilder().
+		WithScheme(runtime.NewScheme()).
+		WithRESTMapper(mapper).
+		WithObjects(objs...).
+		WithStatusSubresource(objs[0]).
+		Build()
+}
+
+func TestStorageVersionMigrator(t *testing.T) {
+	tests := []struct {
+		name                   string
+		storedVersions         []string
+		expectedPatched        []string
+		expectedStoredVersions []string
+	}{
+		{
+			name:                   "already_migrated",
+			storedVersions:         []string{"v2"},
+			expectedStoredVersions: []string{"v2"},
+		},
+		{
+			name:                   "objects_stored_in_previous_version",
+			storedVersions:         []string{"v1", "v2"},
+			expectedPatched:        []string{"agent-a", "agent-b"},
+			expectedStoredVersions: []string{"v2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var patched []string
+			c := interceptor.NewClient(
+				newFakeClient(newCRD(tt.storedVersions...), newAgent("agent-a"), newAgent("agent-b")),
+				interceptor.Funcs{
+					Patch: func(
+						ctx context.Context,
+						c client.WithWatch,
+						obj client.Object,
+						patch client.Patch,
+						opts ...client.PatchOption,
+					) error {
+						data, err := patch.Data(obj)
+						require.NoError(t, err)
+						require.Equal(t, "{}", string(data))
+						patched = append(patched, obj.GetName())
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				},
+			)
+
+			migrator := NewStorageVersionMigrator(c, c, "agents.instana.io")
+			require.True(t, migrator.NeedLeaderElection())
+			require.NoError(t, migrator.migrate(ctx))
+			require.ElementsMatch(t, tt.expectedPatched, patched)
+
+			crd := &unstructured.Unstructured{}
+			crd.SetGroupVersionKind(crdGVK)
+			require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "agents.instana.io"}, crd))
+			storedVersions, _, err := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedStoredVersions, storedVersions)
+		})
+	}
+}
+
+func TestStorageVersionMigratorMissingCRD(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	migrator := NewStorageVersionMigrator(c, c, "agents.instana.io")
+	require.Error(t, migrator.migrate(context.Background()))
+	require.NoError(t, migrator.Start(context.Background()))
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/builder"
 	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
 	"github.com/instana/instana-agent-operator/pkg/k8s/operator/lifecycle"
+	"github.com/instana/instana-agent-operator/pkg/k8s/operator/metrics"
 	"github.com/instana/instana-agent-operator/pkg/multierror"
 	"github.com/instana/instana-agent-operator/pkg/optional"
 )
@@ -36,7 +37,9 @@ import (
 type OperatorUtils interface {
 	ClusterIsOpenShift() (bool, error)
 	ApplyAll(builders ...builder.ObjectBuilder) error
+	PreviewAll(builders ...builder.ObjectBuilder) ([]instanav1.PreviewEntry, error)
 	DeleteAll() error
+	Inventory() []instanav1.ResourceInventoryEntry
 }
 
 type operatorUtils struct {
@@ -64,6 +67,7 @@ func NewOperatorUtils(
 
 func (o *operatorUtils) ApplyAll(builders ...builder.ObjectBuilder) error {
 	if err := o.applyAll(o.buildObjects(builders...), k8sclient.DryRunAll); err != nil {
+		metrics.IncDryRunFailures(o.instanaAgent)
 		return err
 	}
 
@@ -77,11 +81,24 @@ func (o *operatorUtils) ApplyAll(builders ...builder.ObjectBuilder) error {
 		return err
 	}
 
-	return o.dependentLifecycleManager.CleanupDependents(objects...)
+	return o.cleanupDependents(objects...)
 }
 
 func (o *operatorUtils) DeleteAll() error {
-	return o.dependentLifecycleManager.CleanupDependents()
+	return o.cleanupDependents()
+}
+
+func (o *operatorUtils) cleanupDependents(currentDependents ...k8sclient.Object) error {
+	err := o.dependentLifecycleManager.CleanupDependents(currentDependents...)
+	if err != nil {
+		metrics.IncLifecycleCleanupFailures(o.instanaAgent)
+	}
+	return err
+}
+
+// Inventory returns the dependents applied for the CR, to be recorded in its status
+func (o *operatorUtils) Inventory() []instanav1.ResourceInventoryEntry {
+	return o.dependentLifecycleManager.Inventory()
 }
 
 // ClusterIsOpenShift returns a boolean if the cluster has been defined as OpenShift
@@ -0,0 +1,182 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator_utils
+
+import (
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/builder"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
+	"github.com/instana/instana-agent-operator/pkg/k8s/operator/metrics"
+	"github.com/instana/instana-agent-operator/pkg/multierror"
+)
+
+// previewedMetadataFields are the fields of the metadata the operator manages, all others are maintained by the server
+var previewedMetadataFields = []string{"labels", "annotations", "ownerReferences"}
+
+// PreviewAll dry-runs the objects of all builders against the cluster and reports the ones that would be created or
+// updated, as well as the dependents lifecycle cleanup would delete. Objects that would be left unchanged are not
+// reported. Nothing is applied or deleted.
+func (o *operatorUtils) PreviewAll(builders ...builder.ObjectBuilder) ([]instanav1.PreviewEntry, error) {
+	errBuilder := multierror.NewMultiErrorBuilder()
+
+	objects := o.buildObjects(builders...)
+	entries := make([]instanav1.PreviewEntry, 0, len(objects))
+
+	for _, obj := range objects {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+
+		exists := true
+		if err := o.instanaAgentClient.Get(o.ctx, k8sclient.ObjectKeyFromObject(obj), live); k8serrors.IsNotFound(err) {
+			exists = false
+		} else if err != nil {
+			errBuilder.AddSingle(err)
+			continue
+		}
+
+		dryRun, err := o.instanaAgentClient.Apply(o.ctx, obj, k8sclient.DryRunAll).Get()
+		if err != nil {
+			metrics.IncDryRunFailures(o.instanaAgent)
+			errBuilder.AddSingle(err)
+			continue
+		}
+
+		entry := newPreviewEntry(obj, instanav1.PreviewActionCreate)
+		if exists {
+			changedFields, err := getChangedFields(live, dryRun)
+			if err != nil {
+				errBuilder.AddSingle(err)
+				continue
+			}
+			if len(changedFields) == 0 {
+				continue
+			}
+
+			entry.Action = instanav1.PreviewActionUpdate
+			entry.ChangedFields = changedFields
+		}
+		entries = append(entries, entry)
+	}
+
+	orphans, err := o.dependentLifecycleManager.PendingCleanup(objects...)
+	if err != nil {
+		errBuilder.AddSingle(err)
+	}
+	for _, orphan := range orphans {
+		entries = append(entries, newPreviewEntry(orphan, instanav1.PreviewActionDelete))
+	}
+
+	return entries, errBuilder.Build()
+}
+
+func newPreviewEntry(obj k8sclient.Object, action instanav1.PreviewAction) instanav1.PreviewEntry {
+	apiVersion, kind := obj.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
+	return instanav1.PreviewEntry{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Action:     action,
+	}
+}
+
+// getChangedFields returns the paths of all fields that differ between the live object and the result of the dry-run.
+// Lists are compared as a whole and status as well as metadata maintained by the server are left out. The generation
+// label is left out as well, as it changes with every generation of the CR without changing the object otherwise.
+func getChangedFields(live *unstructured.Unstructured, dryRun k8sclient.Object) ([]string, error) {
+	desired, err := runtime.DefaultUnstructuredConverter.ToUnstructured(dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	liveContent := live.UnstructuredContent()
+	changedFields := make([]string, 0)
+
+	for _, key := range sortedKeys(liveContent, desired) {
+		switch key {
+		case "apiVersion", "kind", "status":
+		case "metadata":
+			liveMetadata, _ := liveContent[key].(map[string]any)
+			desiredMetadata, _ := desired[key].(map[string]any)
+			for _, field := range previewedMetadataFields {
+				liveField, desiredField := liveMetadata[field], desiredMetadata[field]
+				if field == "labels" {
+					liveField, desiredField = withoutGenerationLabel(liveField), withoutGenerationLabel(desiredField)
+				}
+				if !equality.Semantic.DeepEqual(liveField, desiredField) {
+					changedFields = append(changedFields, "metadata."+field)
+				}
+			}
+		default:
+			changedFields = appendChangedFields(changedFields, key, liveContent[key], desired[key])
+		}
+	}
+
+	return changedFields, nil
+}
+
+// withoutGenerationLabel returns a copy of the labels without the generation label, labels without any other entries
+// are returned as nil so that they compare equal to missing labels
+func withoutGenerationLabel(labels any) any {
+	labelMap, ok := labels.(map[string]any)
+	if !ok {
+		return labels
+	}
+
+	filtered := maps.Clone(labelMap)
+	delete(filtered, transformations.GenerationLabel)
+	if len(filtered) == 0 {
+		return nil
+	}
+	return filtered
+}
+
+func appendChangedFields(changedFields []string, path string, live any, desired any) []string {
+	liveMap, liveIsMap := live.(map[string]any)
+	desiredMap, desiredIsMap := desired.(map[string]any)
+
+	if !liveIsMap || !desiredIsMap {
+		if !equality.Semantic.DeepEqual(live, desired) {
+			changedFields = append(changedFields, path)
+		}
+		return changedFields
+	}
+
+	for _, key := range sortedKeys(liveMap, desiredMap) {
+		changedFields = appendChangedFields(changedFields, path+"."+key, liveMap[key], desiredMap[key])
+	}
+	return changedFields
+}
+
+func sortedKeys(objects ...map[string]any) []string {
+	keys := make(map[string]struct{})
+	for _, object := range objects {
+		for key := range maps.Keys(object) {
+			keys[key] = struct{}{}
+		}
+	}
+	return slices.Sorted(maps.Keys(keys))
+}
@@ -0,0 +1,231 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator_utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/internal/mocks"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/builder"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
+	"github.com/instana/instana-agent-operator/pkg/optional"
+	"github.com/instana/instana-agent-operator/pkg/result"
+)
+
+type previewConfigMapBuilder struct {
+	name string
+	data map[string]string
+}
+
+func (b *previewConfigMapBuilder) Build() builder.OptionalObject {
+	return optional.Of[client.Object](
+		&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Name: b.name, Namespace: "instana-agent"},
+			Data:       b.data,
+		},
+	)
+}
+
+func (b *previewConfigMapBuilder) ComponentName() string {
+	return "preview"
+}
+
+func (b *previewConfigMapBuilder) IsNamespaced() bool {
+	return true
+}
+
+// liveConfigMap returns the dry-run result of the builder as it would be stored in the cluster
+func liveConfigMap(agent *instanav1.InstanaAgent, bldr *previewConfigMapBuilder) *corev1.ConfigMap {
+	obj := NewOperatorUtils(context.Background(), nil, agent, nil).(*operatorUtils).buildObjects(bldr)[0]
+	return obj.(*corev1.ConfigMap)
+}
+
+func TestPreviewAllReportsActionPerObject(t *testing.T) {
+	assertions := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	defer instanaAgentClient.AssertExpectations(t)
+	dependentLifecycleManager := &mocks.MockDependentLifecycleManager{}
+	defer dependentLifecycleManager.AssertExpectations(t)
+
+	agent := &instanav1.InstanaAgent{ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", Namespace: "instana-agent"}}
+
+	created := &previewConfigMapBuilder{name: "created", data: map[string]string{"a": "1"}}
+	updated := &previewConfigMapBuilder{name: "updated", data: map[string]string{"a": "2"}}
+	unchanged := &previewConfigMapBuilder{name: "unchanged", data: map[string]string{"a": "1"}}
+
+	instanaAgentClient.On("Get", ctx, client.ObjectKey{Namespace: "instana-agent", Name: "created"}, mock.Anything, mock.Anything).
+		Return(k8serrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "created"))
+	for _, bldr := range []*previewConfigMapBuilder{updated, unchanged} {
+		live := liveConfigMap(agent, bldr)
+		live.Data = map[string]string{"a": "1"}
+		live.ResourceVersion = "1"
+		instanaAgentClient.On("Get", ctx, client.ObjectKeyFromObject(live), mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+				assertions.NoError(err)
+				args.Get(2).(*unstructured.Unstructured).SetUnstructuredContent(content)
+			}).
+			Return(nil)
+	}
+
+	for _, bldr := range []*previewConfigMapBuilder{created, updated, unchanged} {
+		dryRun := liveConfigMap(agent, bldr)
+		dryRun.ResourceVersion = "2"
+		instanaAgentClient.On(
+			"Apply",
+			ctx,
+			mock.MatchedBy(func(obj client.Object) bool { return obj.GetName() == bldr.name }),
+			[]client.PatchOption{client.DryRunAll},
+		).Return(result.OfSuccess[client.Object](dryRun))
+	}
+
+	orphan := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: "orphan", Namespace: "instana-agent"},
+	}
+	dependentLifecycleManager.On("PendingCleanup", mock.Anything).Return([]client.Object{orphan}, nil)
+
+	entries, err := NewOperatorUtils(ctx, instanaAgentClient, agent, dependentLifecycleManager).
+		PreviewAll(created, updated, unchanged)
+	assertions.NoError(err)
+
+	assertions.Equal(
+		[]instanav1.PreviewEntry{
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "instana-agent", Name: "created", Action: instanav1.PreviewActionCreate},
+			{
+				APIVersion:    "v1",
+				Kind:          "ConfigMap",
+				Namespace:     "instana-agent",
+				Name:          "updated",
+				Action:        instanav1.PreviewActionUpdate,
+				ChangedFields: []string{"data.a"},
+			},
+			{APIVersion: "v1", Kind: "Secret", Namespace: "instana-agent", Name: "orphan", Action: instanav1.PreviewActionDelete},
+		},
+		entries,
+	)
+}
+
+func TestPreviewAllReturnsErrors(t *testing.T) {
+	assertions := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	dependentLifecycleManager := &mocks.MockDependentLifecycleManager{}
+	agent := &instanav1.InstanaAgent{ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", Namespace: "instana-agent"}}
+
+	getErr := errors.New("get failed")
+	cleanupErr := errors.New("pending cleanup failed")
+	instanaAgentClient.On("Get", ctx, mock.Anything, mock.Anything, mock.Anything).Return(getErr)
+	dependentLifecycleManager.On("PendingCleanup", mock.Anything).Return(nil, cleanupErr)
+
+	entries, err := NewOperatorUtils(ctx, instanaAgentClient, agent, dependentLifecycleManager).
+		PreviewAll(&previewConfigMapBuilder{name: "cm"})
+	assertions.Empty(entries)
+	assertions.ErrorIs(err, getErr)
+	assertions.ErrorIs(err, cleanupErr)
+}
+
+func TestGetChangedFields(t *testing.T) {
+	assertions := require.New(t)
+
+	live := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":            "deployment",
+			"resourceVersion": "1",
+			"labels":          map[string]any{"app": "agent"},
+		},
+		"spec": map[string]any{
+			"replicas": int64(1),
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{map[string]any{"name": "agent", "image": "agent:1"}},
+				},
+			},
+		},
+		"status": map[string]any{"readyReplicas": int64(1)},
+	}}
+
+	dryRun := live.DeepCopy()
+	dryRun.SetResourceVersion("2")
+	dryRun.SetAnnotations(map[string]string{"checksum": "abc"})
+	assertions.NoError(unstructured.SetNestedField(dryRun.Object, int64(2), "spec", "replicas"))
+	assertions.NoError(unstructured.SetNestedField(dryRun.Object, "paused", "spec", "strategy"))
+	assertions.NoError(
+		unstructured.SetNestedSlice(
+			dryRun.Object,
+			[]any{map[string]any{"name": "agent", "image": "agent:2"}},
+			"spec", "template", "spec", "containers",
+		),
+	)
+	assertions.NoError(unstructured.SetNestedField(dryRun.Object, int64(0), "status", "readyReplicas"))
+
+	changedFields, err := getChangedFields(live, dryRun)
+	assertions.NoError(err)
+	assertions.Equal(
+		[]string{"metadata.annotations", "spec.replicas", "spec.strategy", "spec.template.spec.containers"},
+		changedFields,
+	)
+}
+
+func TestGetChangedFieldsIgnoresGenerationLabel(t *testing.T) {
+	assertions := require.New(t)
+
+	live := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":   "config",
+			"labels": map[string]any{"app": "agent", transformations.GenerationLabel: "1"},
+		},
+	}}
+
+	dryRun := live.DeepCopy()
+	dryRun.SetLabels(map[string]string{"app": "agent", transformations.GenerationLabel: "2"})
+
+	changedFields, err := getChangedFields(live, dryRun)
+	assertions.NoError(err)
+	assertions.Empty(changedFields)
+
+	dryRun.SetLabels(map[string]string{"app": "k8sensor", transformations.GenerationLabel: "2"})
+
+	changedFields, err = getChangedFields(live, dryRun)
+	assertions.NoError(err)
+	assertions.Equal([]string{"metadata.labels"}, changedFields)
+}
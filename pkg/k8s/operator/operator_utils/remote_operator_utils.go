@@ -34,6 +34,7 @@ type RemoteOperatorUtils interface {
 	//ClusterIsOpenShift() (bool, error)
 	ApplyAll(builders ...builder.ObjectBuilder) error
 	DeleteAll() error
+	Inventory() []instanav1.ResourceInventoryEntry
 }
 
 type remoteOperatorUtils struct {
@@ -81,6 +82,11 @@ func (o *remoteOperatorUtils) DeleteAll() error {
 	return o.dependentLifecycleManager.CleanupDependents()
 }
 
+// Inventory returns the dependents applied for the CR, to be recorded in its status
+func (o *remoteOperatorUtils) Inventory() []instanav1.ResourceInventoryEntry {
+	return o.dependentLifecycleManager.Inventory()
+}
+
 func (o *remoteOperatorUtils) applyAll(objects []k8sclient.Object, opts ...k8sclient.PatchOption) error {
 	errBuilder := multierror.NewMultiErrorBuilder()
 
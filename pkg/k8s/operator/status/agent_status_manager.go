@@ -7,6 +7,7 @@ package status
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -15,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -24,6 +26,8 @@ import (
 	"github.com/instana/instana-agent-operator/pkg/collections/list"
 	"github.com/instana/instana-agent-operator/pkg/env"
 	instanaclient "github.com/instana/instana-agent-operator/pkg/k8s/client"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
+	"github.com/instana/instana-agent-operator/pkg/k8s/operator/metrics"
 	"github.com/instana/instana-agent-operator/pkg/multierror"
 	"github.com/instana/instana-agent-operator/pkg/optional"
 	"github.com/instana/instana-agent-operator/pkg/pointer"
@@ -34,10 +38,23 @@ import (
 type AgentStatusManager interface {
 	AddAgentDaemonset(agentDaemonset client.ObjectKey)
 	SetAgentOld(agent *instanav1.InstanaAgent)
-	SetK8sSensorDeployment(k8sSensorDeployment client.ObjectKey)
+	AddK8sSensorDeployment(k8sSensorDeployment client.ObjectKey, endpoint string)
 	SetAgentSecretConfig(agentSecretConfig client.ObjectKey)
 	SetAgentNamespacesConfigMap(agentNamespacesConfigmap client.ObjectKey)
+	SetConfigurationValidation(configurationErrs field.ErrorList)
+	SetInventory(inventory []instanav1.ResourceInventoryEntry)
+	SetPreview(preview *instanav1.PreviewStatus)
+	SetReconcilePaused(paused bool)
+	SetETCDMonitoring(etcdMonitoring *instanav1.ETCDMonitoringStatus)
+	SetTLS(tls *instanav1.TLSStatus)
 	UpdateAgentStatus(ctx context.Context, reconcileErr error) error
+	UpdateAvailability(ctx context.Context) error
+}
+
+// k8sSensorDeployment is the k8sensor Deployment of the backend with the given endpoint
+type k8sSensorDeployment struct {
+	key      client.ObjectKey
+	endpoint string
 }
 
 type agentStatusManager struct {
@@ -45,16 +62,26 @@ type agentStatusManager struct {
 	eventRecorder            record.EventRecorder
 	agentOld                 *instanav1.InstanaAgent
 	agentDaemonsets          []client.ObjectKey
-	k8sSensorDeployment      client.ObjectKey
+	k8sSensorDeployments     []k8sSensorDeployment
 	agentSecretConfig        client.ObjectKey
 	agentNamespacesConfigmap client.ObjectKey
+	configurationErrs        field.ErrorList
+	configurationValidated   bool
+	inventory                []instanav1.ResourceInventoryEntry
+	preview                  *instanav1.PreviewStatus
+	reconcilePaused          bool
+	etcdMonitoring           *instanav1.ETCDMonitoringStatus
+	tls                      *instanav1.TLSStatus
+	// reconcileStarted is when the reconcile the status manager was created for started
+	reconcileStarted time.Time
 }
 
 func NewAgentStatusManager(instAgentClient instanaclient.InstanaAgentClient, eventRecorder record.EventRecorder) AgentStatusManager {
 	return &agentStatusManager{
-		instAgentClient: instAgentClient,
-		eventRecorder:   eventRecorder,
-		agentDaemonsets: make([]client.ObjectKey, 0, 1),
+		instAgentClient:  instAgentClient,
+		eventRecorder:    eventRecorder,
+		agentDaemonsets:  make([]client.ObjectKey, 0, 1),
+		reconcileStarted: time.Now(),
 	}
 }
 
@@ -68,8 +95,15 @@ func (a *agentStatusManager) SetAgentOld(agent *instanav1.InstanaAgent) {
 	a.agentOld = agent.DeepCopy()
 }
 
-func (a *agentStatusManager) SetK8sSensorDeployment(k8sSensorDeployment client.ObjectKey) {
-	a.k8sSensorDeployment = k8sSensorDeployment
+// AddK8sSensorDeployment records the k8sensor Deployment of a backend, identified by its host:port endpoint
+func (a *agentStatusManager) AddK8sSensorDeployment(deployment client.ObjectKey, endpoint string) {
+	for i := range a.k8sSensorDeployments {
+		if a.k8sSensorDeployments[i].key == deployment {
+			a.k8sSensorDeployments[i].endpoint = endpoint
+			return
+		}
+	}
+	a.k8sSensorDeployments = append(a.k8sSensorDeployments, k8sSensorDeployment{key: deployment, endpoint: endpoint})
 }
 
 func (a *agentStatusManager) SetAgentSecretConfig(agentSecretConfig types.NamespacedName) {
@@ -80,6 +114,42 @@ func (a *agentStatusManager) SetAgentNamespacesConfigMap(agentNamespacesConfigma
 	a.agentNamespacesConfigmap = agentNamespacesConfigmap
 }
 
+// SetConfigurationValidation records the result of validating the spec, to be reported as the ConfigurationValid
+// condition
+func (a *agentStatusManager) SetConfigurationValidation(configurationErrs field.ErrorList) {
+	a.configurationErrs = configurationErrs
+	a.configurationValidated = true
+}
+
+// SetInventory records the dependents applied for the CR, to be reported in its status
+func (a *agentStatusManager) SetInventory(inventory []instanav1.ResourceInventoryEntry) {
+	a.inventory = inventory
+}
+
+// SetPreview records the result of dry-running the spec instead of applying it. Without a preview, any earlier
+// preview is removed from the status.
+func (a *agentStatusManager) SetPreview(preview *instanav1.PreviewStatus) {
+	a.preview = preview
+}
+
+// SetReconcilePaused records whether applying the spec was skipped because reconciliation is paused, to be reported
+// as the ReconcilePaused condition
+func (a *agentStatusManager) SetReconcilePaused(paused bool) {
+	a.reconcilePaused = paused
+}
+
+// SetETCDMonitoring records how the k8sensor monitors ETCD, to be reported as the ETCDMonitoring condition. Without it,
+// e.g. when the spec was not applied, the ETCD monitoring reported earlier is kept.
+func (a *agentStatusManager) SetETCDMonitoring(etcdMonitoring *instanav1.ETCDMonitoringStatus) {
+	a.etcdMonitoring = etcdMonitoring
+}
+
+// SetTLS records when the certificates generated for the agent expire. Without it, e.g. when the spec was not
+// applied, the expiry reported earlier is kept as long as spec.agent.tls.autoGenerate is set.
+func (a *agentStatusManager) SetTLS(tls *instanav1.TLSStatus) {
+	a.tls = tls
+}
+
 func (a *agentStatusManager) UpdateAgentStatus(ctx context.Context, reconcileErr error) (finalErr error) {
 	defer recovery.Catch(&finalErr)
 
@@ -94,6 +164,10 @@ func (a *agentStatusManager) UpdateAgentStatus(ctx context.Context, reconcileErr
 			OnFailure(errBuilder.AddSingle).
 			Get()
 
+	if !statusChanged(a.agentOld.Status, agentNew.Status) {
+		return errBuilder.Build()
+	}
+
 	if err := a.instAgentClient.Status().Patch(
 		ctx,
 		agentNew,
@@ -106,6 +180,38 @@ func (a *agentStatusManager) UpdateAgentStatus(ctx context.Context, reconcileErr
 	return errBuilder.Build()
 }
 
+// UpdateAvailability refreshes only the availability reported in the status of the agent CR, i.e. the zones, the
+// k8sensors, the node coverage and their conditions, without touching the outcome of the last reconcile
+func (a *agentStatusManager) UpdateAvailability(ctx context.Context) (finalErr error) {
+	defer recovery.Catch(&finalErr)
+
+	if a.agentOld == nil {
+		return nil
+	}
+
+	errBuilder := multierror.NewMultiErrorBuilder()
+
+	agentNew := a.agentOld.DeepCopy()
+	errBuilder.AddSingle(a.setAvailability(ctx, agentNew))
+
+	if !statusChanged(a.agentOld.Status, agentNew.Status) {
+		return errBuilder.Build()
+	}
+	agentNew.Status.LastUpdate = metav1.Time{Time: time.Now()}
+
+	// The optimistic lock keeps a concurrent full reconcile from being overwritten with stale conditions
+	if err := a.instAgentClient.Status().Patch(
+		ctx,
+		agentNew,
+		client.MergeFromWithOptions(a.agentOld, client.MergeFromWithOptimisticLock{}),
+		client.FieldOwner(instanaclient.FieldOwnerName),
+	); err != nil && !k8serrors.IsNotFound(err) {
+		errBuilder.AddSingle(err)
+	}
+
+	return errBuilder.Build()
+}
+
 func (a *agentStatusManager) getDaemonSet(ctx context.Context) result.Result[instanav1.ResourceInfo] {
 	if len(a.agentDaemonsets) != 1 {
 		return result.OfSuccess(instanav1.ResourceInfo{})
@@ -136,9 +242,84 @@ func (a *agentStatusManager) getNamespacesConfigMap(ctx context.Context) result.
 	return result.Map(cm, toResourceInfo)
 }
 
+// getZones reports the DaemonSet of every zone configured in the spec, in the order of the spec
+func (a *agentStatusManager) getZones(ctx context.Context) result.Result[[]instanav1.ZoneStatus] {
+	if len(a.agentOld.Spec.Zones) == 0 {
+		return result.OfSuccess[[]instanav1.ZoneStatus](nil)
+	}
+
+	errBuilder := multierror.NewMultiErrorBuilder()
+
+	daemonsetsByZone := make(map[string]appsv1.DaemonSet, len(a.agentDaemonsets))
+	for _, key := range a.agentDaemonsets {
+		var ds appsv1.DaemonSet
+		if res := a.instAgentClient.GetAsResult(ctx, key, &ds); res.IsFailure() {
+			_, err := res.Get()
+			errBuilder.AddSingle(err)
+			continue
+		}
+		daemonsetsByZone[ds.Spec.Template.Labels[transformations.ZoneLabel]] = ds
+	}
+
+	zones := make([]instanav1.ZoneStatus, 0, len(a.agentOld.Spec.Zones))
+	for _, zone := range a.agentOld.Spec.Zones {
+		ds, ok := daemonsetsByZone[zone.Name.Name]
+		if !ok {
+			continue
+		}
+		zones = append(zones, toZoneStatus(zone, ds))
+	}
+
+	return result.Of(zones, errBuilder.Build())
+}
+
+// getAgentDaemonsets fetches the agent DaemonSets of all zones
+func (a *agentStatusManager) getAgentDaemonsets(ctx context.Context) result.Result[[]appsv1.DaemonSet] {
+	errBuilder := multierror.NewMultiErrorBuilder()
+
+	daemonsets := make([]appsv1.DaemonSet, 0, len(a.agentDaemonsets))
+	for _, key := range a.agentDaemonsets {
+		var ds appsv1.DaemonSet
+		if res := a.instAgentClient.GetAsResult(ctx, key, &ds); res.IsFailure() {
+			_, err := res.Get()
+			errBuilder.AddSingle(err)
+			continue
+		}
+		daemonsets = append(daemonsets, ds)
+	}
+
+	return result.Of(daemonsets, errBuilder.Build())
+}
+
+// getK8sSensors reports the k8sensor Deployment of every backend, in the order of the backends
+func (a *agentStatusManager) getK8sSensors(ctx context.Context) result.Result[[]instanav1.K8sSensorStatus] {
+	if !pointer.DerefOrDefault(a.agentOld.Spec.K8sSensor.DeploymentSpec.Enabled.Enabled, true) {
+		return result.OfSuccess[[]instanav1.K8sSensorStatus](nil)
+	}
+
+	errBuilder := multierror.NewMultiErrorBuilder()
+
+	k8sSensors := make([]instanav1.K8sSensorStatus, 0, len(a.k8sSensorDeployments))
+	for _, k8sSensor := range a.k8sSensorDeployments {
+		var deployment appsv1.Deployment
+		if res := a.instAgentClient.GetAsResult(ctx, k8sSensor.key, &deployment); res.IsFailure() {
+			_, err := res.Get()
+			errBuilder.AddSingle(err)
+			continue
+		}
+		k8sSensors = append(k8sSensors, toK8sSensorStatus(k8sSensor.endpoint, deployment))
+	}
+
+	return result.Of(k8sSensors, errBuilder.Build())
+}
+
+// setConditionAndFireEvent sets the condition and records an event for it if its status or reason changed
 func (a *agentStatusManager) setConditionAndFireEvent(agentNew *instanav1.InstanaAgent, condition metav1.Condition) {
+	changed := conditionChanged(agentNew.Status.Conditions, condition)
 	meta.SetStatusCondition(&agentNew.Status.Conditions, condition)
-	a.eventRecorder.Event(agentNew, eventTypeFromCondition(condition), condition.Reason, condition.Message)
+	if changed {
+		a.eventRecorder.Event(agentNew, eventTypeFromCondition(condition), condition.Reason, condition.Message)
+	}
 }
 
 func (a *agentStatusManager) getReconcileSucceededCondition(reconcileErr error) metav1.Condition {
@@ -165,7 +346,11 @@ func (a *agentStatusManager) getReconcileSucceededCondition(reconcileErr error)
 	return res
 }
 
-func (a *agentStatusManager) getAllAgentsAvailableCondition(ctx context.Context) result.Result[metav1.Condition] {
+// getAllAgentsAvailableCondition reports whether the agent DaemonSets fetched by getAgentDaemonsets are available
+func (a *agentStatusManager) getAllAgentsAvailableCondition(
+	dameonsets []appsv1.DaemonSet,
+	daemonsetsErr error,
+) metav1.Condition {
 	condition := metav1.Condition{
 		Type:               ConditionTypeAllAgentsAvailable,
 		Status:             "",
@@ -174,28 +359,13 @@ func (a *agentStatusManager) getAllAgentsAvailableCondition(ctx context.Context)
 		Message:            "",
 	}
 
-	dameonsets := make([]appsv1.DaemonSet, 0, len(a.agentDaemonsets))
-
-	for _, key := range a.agentDaemonsets {
-		var ds appsv1.DaemonSet
-		switch res := a.instAgentClient.GetAsResult(ctx, key, &ds); {
-		case res.IsSuccess():
-			dameonsets = append(dameonsets, ds)
-		case res.IsFailure():
-			_, err := res.Get()
-
-			condition.Status = metav1.ConditionUnknown
-			condition.Reason = "AgentDaemonsetInfoUnavailable"
-			msg := fmt.Sprintf(
-				"failed to retrieve status of Agent Daemonset: %s due to error: %s",
-				key.Name,
-				err.Error(),
-			)
-			truncatedMsg := truncateMessage(msg)
-			condition.Message = truncatedMsg
-
-			return result.Of(condition, err)
-		}
+	if daemonsetsErr != nil {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "AgentDaemonsetInfoUnavailable"
+		condition.Message = truncateMessage(
+			fmt.Sprintf("failed to retrieve status of Agent Daemonsets due to error: %s", daemonsetsErr.Error()),
+		)
+		return condition
 	}
 
 	// TODO: Implement a robust readiness endpoint in the agent for the readiness probe?
@@ -208,9 +378,23 @@ func (a *agentStatusManager) getAllAgentsAvailableCondition(ctx context.Context)
 		condition.Status = metav1.ConditionFalse
 		condition.Reason = "NotAllDesiredAgentsAvailable"
 		condition.Message = "Not all desired Instana agents are available or some Agents are not using up-to-date configuration"
+		if zones := unavailableZones(dameonsets); len(zones) > 0 {
+			condition.Message += " in zones: " + strings.Join(zones, ", ")
+		}
 	}
 
-	return result.OfSuccess(condition)
+	return condition
+}
+
+// unavailableZones returns the zones of all DaemonSets whose agents are not all available
+func unavailableZones(daemonsets []appsv1.DaemonSet) []string {
+	zones := make([]string, 0, len(daemonsets))
+	for _, ds := range daemonsets {
+		if zone := ds.Spec.Template.Labels[transformations.ZoneLabel]; zone != "" && !daemonsetIsAvailable(ds) {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
 }
 
 func (a *agentStatusManager) updateWasPerformed() bool {
@@ -246,33 +430,41 @@ func (a *agentStatusManager) getAllK8sSensorsAvailableCondition(ctx context.Cont
 		return result.OfFailure[metav1.Condition](fmt.Errorf("k8sensor is disabled"))
 	}
 
-	if objectKeyHasNoName(a.k8sSensorDeployment) {
+	if len(a.k8sSensorDeployments) == 0 {
 		condition.Status = metav1.ConditionUnknown
 		condition.Reason = "K8sensorDeploymentNotConfigured"
 		condition.Message = "k8sensor deployment has not been configured for this agent"
 		return result.OfSuccess(condition)
 	}
 
-	var deployment appsv1.Deployment
+	unavailableEndpoints := make([]string, 0, len(a.k8sSensorDeployments))
 
-	if res := a.instAgentClient.GetAsResult(ctx, a.k8sSensorDeployment, &deployment); res.IsFailure() {
-		_, err := res.Get()
+	for _, k8sSensor := range a.k8sSensorDeployments {
+		var deployment appsv1.Deployment
 
-		condition.Status = metav1.ConditionUnknown
-		condition.Reason = "K8sensorDeploymentInfoUnavailable"
-		msg := fmt.Sprintf(
-			"failed to retrieve status of k8sensor deployment: %s due to error: %s",
-			a.k8sSensorDeployment.Name,
-			err.Error(),
-		)
-		truncatedMsg := truncateMessage(msg)
-		condition.Message = truncatedMsg
+		if res := a.instAgentClient.GetAsResult(ctx, k8sSensor.key, &deployment); res.IsFailure() {
+			_, err := res.Get()
 
-		return result.Of(condition, err)
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = "K8sensorDeploymentInfoUnavailable"
+			msg := fmt.Sprintf(
+				"failed to retrieve status of k8sensor deployment: %s due to error: %s",
+				k8sSensor.key.Name,
+				err.Error(),
+			)
+			truncatedMsg := truncateMessage(msg)
+			condition.Message = truncatedMsg
+
+			return result.Of(condition, err)
+		}
+
+		if !deploymentIsAvailableAndComplete(deployment) {
+			unavailableEndpoints = append(unavailableEndpoints, k8sSensor.endpoint)
+		}
 	}
 
-	switch deploymentIsAvailableAndComplete(deployment) {
-	case true:
+	switch len(unavailableEndpoints) {
+	case 0:
 		condition.Status = metav1.ConditionTrue
 		condition.Reason = "AllDesiredK8sensorsAvailable"
 		condition.Message = "All desired k8sensors are available and using up-to-date configuration"
@@ -280,6 +472,9 @@ func (a *agentStatusManager) getAllK8sSensorsAvailableCondition(ctx context.Cont
 		condition.Status = metav1.ConditionFalse
 		condition.Reason = "NotAllDesiredK8sensorsAvailable"
 		condition.Message = "Not all desired k8sensors are available or some k8sensors are not using up-to-date configuration"
+		if len(a.k8sSensorDeployments) > 1 {
+			condition.Message += " for backends: " + strings.Join(unavailableEndpoints, ", ")
+		}
 	}
 
 	return result.OfSuccess(condition)
@@ -302,7 +497,6 @@ func (a *agentStatusManager) agentWithUpdatedStatus(
 
 	agentNew.Status.Status = getAgentPhase(reconcileErr)
 	agentNew.Status.Reason = getReason(reconcileErr)
-	agentNew.Status.LastUpdate = metav1.Time{Time: time.Now()}
 
 	a.getDaemonSet(ctx).
 		OnSuccess(setStatusDotDaemonset(agentNew)).
@@ -322,7 +516,23 @@ func (a *agentStatusManager) agentWithUpdatedStatus(
 
 	// Handle New Status Fields
 
-	agentNew.Status.ObservedGeneration = pointer.To(a.agentOld.GetGeneration())
+	agentNew.Status.ReconcileState = getReconcileState(reconcileErr, a.reconcilePaused, a.preview != nil)
+	agentNew.Status.ReconcileHistory = recordReconcileAttempt(
+		agentNew.Status.ReconcileHistory,
+		newReconcileAttempt(a.reconcileStarted, a.agentOld.GetGeneration(), agentNew.Status.ReconcileState, reconcileErr),
+	)
+	agentNew.Status.Cluster = getClusterName(a.agentOld)
+
+	if a.inventory != nil {
+		agentNew.Status.Inventory = a.inventory
+	}
+
+	agentNew.Status.Preview = a.preview
+
+	// A previewed generation or one reconciled while paused has not been applied yet
+	if a.preview == nil && !a.reconcilePaused {
+		agentNew.Status.ObservedGeneration = pointer.To(a.agentOld.GetGeneration())
+	}
 
 	result.Of(semver.NewVersion(env.GetOperatorVersion())).
 		OnSuccess(setStatusDotOperatorVersion(agentNew)).
@@ -335,20 +545,86 @@ func (a *agentStatusManager) agentWithUpdatedStatus(
 	reconcileSucceededCondition := a.getReconcileSucceededCondition(reconcileErr)
 	a.setConditionAndFireEvent(agentNew, reconcileSucceededCondition)
 
-	allAgentsAvailableCondition, _ :=
-		a.getAllAgentsAvailableCondition(ctx).
-			OnFailure(errBuilder.AddSingle).
-			Get()
-	a.setConditionAndFireEvent(agentNew, allAgentsAvailableCondition)
+	if a.configurationValidated {
+		a.setConditionAndFireEvent(
+			agentNew,
+			getConfigurationValidCondition(a.configurationErrs, a.agentOld.GetGeneration()),
+		)
+	}
+
+	switch a.reconcilePaused {
+	case true:
+		a.setConditionAndFireEvent(agentNew, getReconcilePausedCondition(a.agentOld.GetGeneration()))
+	default:
+		meta.RemoveStatusCondition(&agentNew.Status.Conditions, ConditionTypeReconcilePaused)
+	}
+
+	if a.etcdMonitoring != nil {
+		agentNew.Status.ETCDMonitoring = a.etcdMonitoring
+		a.setConditionAndFireEvent(
+			agentNew,
+			getETCDMonitoringCondition(a.etcdMonitoring, a.agentOld.GetGeneration()),
+		)
+	}
 
-	allK8sSensorsAvailableConditionResult := a.getAllK8sSensorsAvailableCondition(ctx).
+	switch {
+	case !a.agentOld.Spec.Agent.TlsSpec.AutoGenerate:
+		agentNew.Status.TLS = nil
+	case a.tls != nil:
+		agentNew.Status.TLS = a.tls
+	}
+
+	errBuilder.AddSingle(a.setAvailability(ctx, agentNew))
+
+	// LastUpdate only moves when the status actually changed, so that unchanged status is not patched again
+	if statusChanged(a.agentOld.Status, agentNew.Status) {
+		agentNew.Status.LastUpdate = metav1.Time{Time: time.Now()}
+	}
+
+	return result.Of(agentNew, errBuilder.Build())
+}
+
+// setAvailability reports the rollout and readiness of the agent DaemonSets and k8sensor Deployments
+func (a *agentStatusManager) setAvailability(ctx context.Context, agentNew *instanav1.InstanaAgent) error {
+	errBuilder := multierror.NewMultiErrorBuilder()
+
+	a.getZones(ctx).
+		OnSuccess(setStatusDotZones(agentNew)).
 		OnFailure(errBuilder.AddSingle)
 
-	// Only set the condition if k8sensor is enabled (result is successful)
-	if allK8sSensorsAvailableConditionResult.IsSuccess() {
-		allK8sSensorsAvailableCondition, _ := allK8sSensorsAvailableConditionResult.Get()
+	daemonsets, daemonsetsErr := a.getAgentDaemonsets(ctx).
+		OnSuccess(setStatusDotAgentsSummary(agentNew)).
+		OnSuccess(func(daemonsets []appsv1.DaemonSet) { metrics.RecordAgents(a.agentOld, daemonsets) }).
+		OnFailure(errBuilder.AddSingle).
+		Get()
+
+	a.getK8sSensors(ctx).
+		OnSuccess(setStatusDotK8sSensors(agentNew)).
+		OnSuccess(setStatusDotK8sSensorSummary(agentNew)).
+		OnSuccess(func(k8sSensors []instanav1.K8sSensorStatus) { metrics.RecordK8sSensors(a.agentOld, k8sSensors) }).
+		OnFailure(errBuilder.AddSingle)
+
+	a.setConditionAndFireEvent(agentNew, a.getAllAgentsAvailableCondition(daemonsets, daemonsetsErr))
+
+	switch nodeCoverage, err := a.getNodeCoverage(ctx).OnFailure(errBuilder.AddSingle).Get(); {
+	case err != nil:
+		a.setConditionAndFireEvent(agentNew, getNodeCoverageUnknownCondition(err, a.agentOld.GetGeneration()))
+	case nodeCoverage == nil:
+		agentNew.Status.NodeCoverage = nil
+		meta.RemoveStatusCondition(&agentNew.Status.Conditions, ConditionTypeAllNodesCovered)
+	default:
+		agentNew.Status.NodeCoverage = nodeCoverage
+		a.setConditionAndFireEvent(agentNew, getAllNodesCoveredCondition(nodeCoverage, a.agentOld.GetGeneration()))
+	}
+
+	// Only set the condition if k8sensor is enabled, a disabled k8sensor is not an error
+	if pointer.DerefOrDefault(a.agentOld.Spec.K8sSensor.DeploymentSpec.Enabled.Enabled, true) {
+		allK8sSensorsAvailableCondition, _ :=
+			a.getAllK8sSensorsAvailableCondition(ctx).
+				OnFailure(errBuilder.AddSingle).
+				Get()
 		a.setConditionAndFireEvent(agentNew, allK8sSensorsAvailableCondition)
 	}
 
-	return result.Of(agentNew, errBuilder.Build())
+	return errBuilder.Build()
 }
@@ -6,18 +6,26 @@ package status
 
 import (
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/go-errors/errors"
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
 	"github.com/instana/instana-agent-operator/internal/mocks"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
+	"github.com/instana/instana-agent-operator/pkg/pointer"
 
 	"github.com/instana/instana-agent-operator/pkg/result"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/record"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -36,6 +44,7 @@ func TestUpdateAgentStatusReturnsErrorOnPatchFailure(t *testing.T) {
 	defer instanaAgentClient.AssertExpectations(t)
 	instanaAgentClient.On("GetAsResult", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(result.Of[k8sclient.Object](&unstructured.Unstructured{}, nil))
+	instanaAgentClient.On("List", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	instanaAgentClient.On("Status").
 		Return(writer)
 
@@ -94,7 +103,7 @@ func TestUpdateAgentStatus(t *testing.T) {
 	}{
 		{
 			name:                 "Should not return errors with full configuration",
-			getAsResultErrors:    []error{nil, nil, nil, nil, nil},
+			getAsResultErrors:    []error{nil, nil, nil, nil, nil, nil},
 			agent:                &instanaAgent,
 			configSecret:         &configSecret,
 			daemonsets:           daemonsets,
@@ -105,7 +114,7 @@ func TestUpdateAgentStatus(t *testing.T) {
 		},
 		{
 			name:              "AgentStatusManager.updateWasPerformed observed-generation-does-not-match-generation",
-			getAsResultErrors: []error{nil, nil, nil, nil, nil},
+			getAsResultErrors: []error{nil, nil, nil, nil, nil, nil},
 			agent: &instanav1.InstanaAgent{
 				Status: instanav1.InstanaAgentStatus{
 					ObservedGeneration: &num,
@@ -120,7 +129,7 @@ func TestUpdateAgentStatus(t *testing.T) {
 		},
 		{
 			name:              "AgentStatusManager.updateWasPerformed operator-versions-do-not-match",
-			getAsResultErrors: []error{nil, nil, nil, nil, nil},
+			getAsResultErrors: []error{nil, nil, nil, nil, nil, nil},
 			agent: &instanav1.InstanaAgent{
 				Status: instanav1.InstanaAgentStatus{
 					ObservedGeneration: &num,
@@ -139,7 +148,7 @@ func TestUpdateAgentStatus(t *testing.T) {
 		},
 		{
 			name:              "AgentStatusManager.updateWasPerformed operator-version-is-nil",
-			getAsResultErrors: []error{nil, nil, nil, nil, nil},
+			getAsResultErrors: []error{nil, nil, nil, nil, nil, nil},
 			agent: &instanav1.InstanaAgent{
 				Status: instanav1.InstanaAgentStatus{
 					ObservedGeneration: &num,
@@ -158,7 +167,7 @@ func TestUpdateAgentStatus(t *testing.T) {
 		},
 		{
 			name:              "AgentStatusManager.updateWasPerformed broken-operator-version-environment-variable",
-			getAsResultErrors: []error{nil, nil, nil, nil, nil},
+			getAsResultErrors: []error{nil, nil, nil, nil, nil, nil},
 			agent: &instanav1.InstanaAgent{
 				Status: instanav1.InstanaAgentStatus{
 					ObservedGeneration: &num,
@@ -189,7 +198,7 @@ func TestUpdateAgentStatus(t *testing.T) {
 		},
 		{
 			name:                 "Return empty when ConfigSecret is nil",
-			getAsResultErrors:    []error{nil, nil, nil, nil},
+			getAsResultErrors:    []error{nil, nil, nil, nil, nil},
 			agent:                &instanaAgent,
 			configSecret:         nil,
 			daemonsets:           daemonsets,
@@ -200,7 +209,7 @@ func TestUpdateAgentStatus(t *testing.T) {
 		},
 		{
 			name:                 "Return empty when DaemonSets are nil",
-			getAsResultErrors:    []error{nil, nil, nil},
+			getAsResultErrors:    []error{nil, nil, nil, nil},
 			agent:                &instanaAgent,
 			configSecret:         &configSecret,
 			daemonsets:           nil,
@@ -222,7 +231,7 @@ func TestUpdateAgentStatus(t *testing.T) {
 		},
 		{
 			name:                 "InstanaAgentClient.GetAsResult returns-error-#1",
-			getAsResultErrors:    []error{errors.New("first_call_errors"), nil, nil, nil, nil},
+			getAsResultErrors:    []error{errors.New("first_call_errors"), nil, nil, nil, nil, nil},
 			agent:                &instanaAgent,
 			configSecret:         &configSecret,
 			daemonsets:           daemonsets,
@@ -233,7 +242,7 @@ func TestUpdateAgentStatus(t *testing.T) {
 		},
 		{
 			name:                 "InstanaAgentClient.GetAsResult returns-error-#2",
-			getAsResultErrors:    []error{nil, errors.New("second_call_errors"), nil, nil, nil},
+			getAsResultErrors:    []error{nil, errors.New("second_call_errors"), nil, nil, nil, nil},
 			agent:                &instanaAgent,
 			configSecret:         &configSecret,
 			daemonsets:           daemonsets,
@@ -244,7 +253,7 @@ func TestUpdateAgentStatus(t *testing.T) {
 		},
 		{
 			name:                 "InstanaAgentClient.GetAsResult returns-error-#3",
-			getAsResultErrors:    []error{nil, nil, errors.New("third_call_errors"), nil, nil},
+			getAsResultErrors:    []error{nil, nil, errors.New("third_call_errors"), nil, nil, nil},
 			agent:                &instanaAgent,
 			configSecret:         &configSecret,
 			daemonsets:           daemonsets,
@@ -255,7 +264,7 @@ func TestUpdateAgentStatus(t *testing.T) {
 		},
 		{
 			name:                 "InstanaAgentClient.GetAsResult returns-error-#4",
-			getAsResultErrors:    []error{nil, nil, nil, errors.New("fourth_call_errors"), nil},
+			getAsResultErrors:    []error{nil, nil, nil, errors.New("fourth_call_errors"), nil, nil},
 			agent:                &instanaAgent,
 			configSecret:         &configSecret,
 			daemonsets:           daemonsets,
@@ -266,7 +275,7 @@ func TestUpdateAgentStatus(t *testing.T) {
 		},
 		{
 			name:                 "Reconciliation error does not affect returning errors",
-			getAsResultErrors:    []error{nil, nil, nil, nil, nil},
+			getAsResultErrors:    []error{nil, nil, nil, nil, nil, nil},
 			agent:                &instanaAgent,
 			configSecret:         &configSecret,
 			daemonsets:           daemonsets,
@@ -301,6 +310,8 @@ func TestUpdateAgentStatus(t *testing.T) {
 						Once()
 				}
 
+				instanaAgentClient.On("List", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
 				// Set up Status and Patch mocks if we expect them to be called
 				// Status and Patch are called whenever there are GetAsResult calls, regardless of errors
 				if len(test.getAsResultErrors) > 0 {
@@ -322,7 +333,7 @@ func TestUpdateAgentStatus(t *testing.T) {
 					agentStatusManager.SetAgentSecretConfig(*test.configSecret)
 				}
 				if test.k8sSensorDeployment != nil {
-					agentStatusManager.SetK8sSensorDeployment(*test.k8sSensorDeployment)
+					agentStatusManager.AddK8sSensorDeployment(*test.k8sSensorDeployment, "ingress-red-saas.instana.io:443")
 				}
 				if test.namespacesConfigmap != nil {
 					agentStatusManager.SetAgentNamespacesConfigMap(*test.namespacesConfigmap)
@@ -461,3 +472,498 @@ func TestGetAllK8sSensorsAvailableCondition_WhenEnabledNil(t *testing.T) {
 		condition.Message,
 	)
 }
+
+func TestAgentWithUpdatedStatusSetsConfigurationValidCondition(t *testing.T) {
+	assertions := require.New(t)
+	ctx := t.Context()
+
+	agent := &instanav1.InstanaAgent{
+		Spec: instanav1.InstanaAgentSpec{
+			K8sSensor: instanav1.K8sSpec{
+				DeploymentSpec: instanav1.KubernetesDeploymentSpec{
+					Enabled: instanav1.Enabled{
+						Enabled: func() *bool { b := false; return &b }(),
+					},
+				},
+			},
+		},
+	}
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	defer instanaAgentClient.AssertExpectations(t)
+
+	recorder := record.NewFakeRecorder(10)
+	agentStatusManager := NewAgentStatusManager(instanaAgentClient, recorder).(*agentStatusManager)
+	agentStatusManager.SetAgentOld(agent)
+
+	// Without a validation result the condition is left untouched
+	agentNew, _ := agentStatusManager.agentWithUpdatedStatus(ctx, nil).Get()
+	assertions.Nil(meta.FindStatusCondition(agentNew.Status.Conditions, ConditionTypeConfigurationValid))
+
+	agentStatusManager.SetConfigurationValidation(field.ErrorList{
+		field.Invalid(field.NewPath("spec", "agent", "endpointPort"), "https", "must be numeric"),
+	})
+	agentNew, _ = agentStatusManager.agentWithUpdatedStatus(ctx, nil).Get()
+
+	condition := meta.FindStatusCondition(agentNew.Status.Conditions, ConditionTypeConfigurationValid)
+	assertions.NotNil(condition)
+	assertions.Equal(metav1.ConditionFalse, condition.Status)
+	assertions.Equal("InvalidConfiguration", condition.Reason)
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assertions.Contains(
+		events,
+		"Warning InvalidConfiguration spec.agent.endpointPort: Invalid value: \"https\": must be numeric",
+	)
+}
+
+func TestAgentWithUpdatedStatusSetsInventory(t *testing.T) {
+	assertions := require.New(t)
+	ctx := t.Context()
+
+	previous := []instanav1.ResourceInventoryEntry{
+		{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: "instana-agent", Name: "instana-agent", Hash: "old"},
+	}
+	agent := &instanav1.InstanaAgent{
+		Spec: instanav1.InstanaAgentSpec{
+			K8sSensor: instanav1.K8sSpec{
+				DeploymentSpec: instanav1.KubernetesDeploymentSpec{
+					Enabled: instanav1.Enabled{
+						Enabled: func() *bool { b := false; return &b }(),
+					},
+				},
+			},
+		},
+		Status: instanav1.InstanaAgentStatus{Inventory: previous},
+	}
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	defer instanaAgentClient.AssertExpectations(t)
+
+	agentStatusManager := NewAgentStatusManager(instanaAgentClient, record.NewFakeRecorder(10)).(*agentStatusManager)
+	agentStatusManager.SetAgentOld(agent)
+
+	// Without an inventory the previous one is kept
+	agentNew, _ := agentStatusManager.agentWithUpdatedStatus(ctx, nil).Get()
+	assertions.Equal(previous, agentNew.Status.Inventory)
+
+	current := []instanav1.ResourceInventoryEntry{
+		{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: "instana-agent", Name: "instana-agent", Hash: "new"},
+	}
+	agentStatusManager.SetInventory(current)
+
+	agentNew, _ = agentStatusManager.agentWithUpdatedStatus(ctx, nil).Get()
+	assertions.Equal(current, agentNew.Status.Inventory)
+}
+
+func TestAgentWithUpdatedStatusSetsPreviewWithoutObservingGeneration(t *testing.T) {
+	assertions := require.New(t)
+	ctx := t.Context()
+
+	agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec: instanav1.InstanaAgentSpec{
+			K8sSensor: instanav1.K8sSpec{
+				DeploymentSpec: instanav1.KubernetesDeploymentSpec{
+					Enabled: instanav1.Enabled{
+						Enabled: func() *bool { b := false; return &b }(),
+					},
+				},
+			},
+		},
+		Status: instanav1.InstanaAgentStatus{ObservedGeneration: pointer.To(int64(1))},
+	}
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	defer instanaAgentClient.AssertExpectations(t)
+
+	agentStatusManager := NewAgentStatusManager(instanaAgentClient, record.NewFakeRecorder(10)).(*agentStatusManager)
+	agentStatusManager.SetAgentOld(agent)
+
+	preview := &instanav1.PreviewStatus{
+		ObservedGeneration: 2,
+		Objects: []instanav1.PreviewEntry{
+			{APIVersion: "v1", Kind: "Secret", Name: "instana-agent", Action: instanav1.PreviewActionCreate},
+		},
+	}
+	agentStatusManager.SetPreview(preview)
+
+	agentNew, _ := agentStatusManager.agentWithUpdatedStatus(ctx, nil).Get()
+	assertions.Equal(preview, agentNew.Status.Preview)
+	assertions.Equal(int64(1), *agentNew.Status.ObservedGeneration)
+
+	// Applying the spec removes the preview again
+	agentStatusManager.SetPreview(nil)
+
+	agentNew, _ = agentStatusManager.agentWithUpdatedStatus(ctx, nil).Get()
+	assertions.Nil(agentNew.Status.Preview)
+	assertions.Equal(int64(2), *agentNew.Status.ObservedGeneration)
+}
+
+func TestAgentWithUpdatedStatusReportsReconcilePaused(t *testing.T) {
+	assertions := require.New(t)
+	ctx := t.Context()
+
+	agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec: instanav1.InstanaAgentSpec{
+			K8sSensor: instanav1.K8sSpec{
+				DeploymentSpec: instanav1.KubernetesDeploymentSpec{
+					Enabled: instanav1.Enabled{
+						Enabled: func() *bool { b := false; return &b }(),
+					},
+				},
+			},
+		},
+		Status: instanav1.InstanaAgentStatus{ObservedGeneration: pointer.To(int64(1))},
+	}
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	defer instanaAgentClient.AssertExpectations(t)
+
+	agentStatusManager := NewAgentStatusManager(instanaAgentClient, record.NewFakeRecorder(10)).(*agentStatusManager)
+	agentStatusManager.SetAgentOld(agent)
+	agentStatusManager.SetReconcilePaused(true)
+
+	agentNew, _ := agentStatusManager.agentWithUpdatedStatus(ctx, nil).Get()
+	condition := meta.FindStatusCondition(agentNew.Status.Conditions, ConditionTypeReconcilePaused)
+	assertions.NotNil(condition)
+	assertions.Equal(metav1.ConditionTrue, condition.Status)
+	assertions.Equal(int64(1), *agentNew.Status.ObservedGeneration)
+
+	// Resuming reconciliation removes the condition
+	agentStatusManager.SetAgentOld(agentNew)
+	agentStatusManager.SetReconcilePaused(false)
+
+	agentNew, _ = agentStatusManager.agentWithUpdatedStatus(ctx, nil).Get()
+	assertions.Nil(meta.FindStatusCondition(agentNew.Status.Conditions, ConditionTypeReconcilePaused))
+	assertions.Equal(int64(2), *agentNew.Status.ObservedGeneration)
+}
+
+func TestAgentWithUpdatedStatusReportsZones(t *testing.T) {
+	assertions := require.New(t)
+	ctx := t.Context()
+
+	agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", Namespace: "instana-agent"},
+		Spec: instanav1.InstanaAgentSpec{
+			Agent: instanav1.BaseAgentSpec{Mode: instanav1.INFRASTRUCTURE},
+			K8sSensor: instanav1.K8sSpec{
+				DeploymentSpec: instanav1.KubernetesDeploymentSpec{
+					Enabled: instanav1.Enabled{
+						Enabled: func() *bool { b := false; return &b }(),
+					},
+				},
+			},
+			Zones: []instanav1.Zone{
+				{Name: instanav1.Name{Name: "zone-a"}, Mode: instanav1.KUBERNETES},
+				{Name: instanav1.Name{Name: "zone-b"}},
+			},
+		},
+	}
+
+	daemonsets := map[string]appsv1.DaemonSet{
+		"instana-agent-zone-a": {
+			ObjectMeta: metav1.ObjectMeta{Name: "instana-agent-zone-a", UID: "uid-a"},
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{transformations.ZoneLabel: "zone-a"}},
+				},
+			},
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: 3,
+				NumberReady:            3,
+				UpdatedNumberScheduled: 3,
+				NumberAvailable:        3,
+			},
+		},
+		"instana-agent-zone-b": {
+			ObjectMeta: metav1.ObjectMeta{Name: "instana-agent-zone-b", UID: "uid-b"},
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{transformations.ZoneLabel: "zone-b"}},
+				},
+			},
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: 2,
+				NumberReady:            1,
+				UpdatedNumberScheduled: 2,
+				NumberAvailable:        1,
+			},
+		},
+	}
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	instanaAgentClient.On("GetAsResult", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*appsv1.DaemonSet) = daemonsets[args.Get(1).(k8sclient.ObjectKey).Name]
+		}).
+		Return(result.OfSuccess[k8sclient.Object](&appsv1.DaemonSet{}))
+	instanaAgentClient.On("List", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	agentStatusManager := NewAgentStatusManager(instanaAgentClient, record.NewFakeRecorder(10)).(*agentStatusManager)
+	agentStatusManager.SetAgentOld(agent)
+	agentStatusManager.AddAgentDaemonset(k8sclient.ObjectKey{Namespace: "instana-agent", Name: "instana-agent-zone-b"})
+	agentStatusManager.AddAgentDaemonset(k8sclient.ObjectKey{Namespace: "instana-agent", Name: "instana-agent-zone-a"})
+
+	agentNew, _ := agentStatusManager.agentWithUpdatedStatus(ctx, nil).Get()
+	assertions.Equal(
+		[]instanav1.ZoneStatus{
+			{
+				Name:      "zone-a",
+				Mode:      instanav1.KUBERNETES,
+				DaemonSet: instanav1.ResourceInfo{Name: "instana-agent-zone-a", UID: "uid-a"},
+				Desired:   3,
+				Ready:     3,
+				Updated:   3,
+				Available: 3,
+			},
+			{
+				Name:      "zone-b",
+				Mode:      instanav1.APM,
+				DaemonSet: instanav1.ResourceInfo{Name: "instana-agent-zone-b", UID: "uid-b"},
+				Desired:   2,
+				Ready:     1,
+				Updated:   2,
+				Available: 1,
+			},
+		},
+		agentNew.Status.Zones,
+	)
+
+	condition := meta.FindStatusCondition(agentNew.Status.Conditions, ConditionTypeAllAgentsAvailable)
+	assertions.NotNil(condition)
+	assertions.Equal(metav1.ConditionFalse, condition.Status)
+	assertions.True(strings.HasSuffix(condition.Message, " in zones: zone-b"), condition.Message)
+}
+
+func TestAgentWithUpdatedStatusReportsK8sSensorsPerBackend(t *testing.T) {
+	assertions := require.New(t)
+	ctx := t.Context()
+
+	availableConditions := []appsv1.DeploymentCondition{
+		{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+		{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "NewReplicaSetAvailable"},
+	}
+	deployments := map[string]appsv1.Deployment{
+		"instana-agent-k8sensor": {
+			ObjectMeta: metav1.ObjectMeta{Name: "instana-agent-k8sensor", UID: "uid-0"},
+			Spec:       appsv1.DeploymentSpec{Replicas: pointer.To[int32](3)},
+			Status: appsv1.DeploymentStatus{
+				ReadyReplicas:     3,
+				UpdatedReplicas:   3,
+				AvailableReplicas: 3,
+				Conditions:        availableConditions,
+			},
+		},
+		"instana-agent-k8sensor-1": {
+			ObjectMeta: metav1.ObjectMeta{Name: "instana-agent-k8sensor-1", UID: "uid-1"},
+			Spec:       appsv1.DeploymentSpec{Replicas: pointer.To[int32](3)},
+			Status: appsv1.DeploymentStatus{
+				ReadyReplicas:   0,
+				UpdatedReplicas: 3,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse},
+				},
+			},
+		},
+	}
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	instanaAgentClient.On("GetAsResult", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*appsv1.Deployment) = deployments[args.Get(1).(k8sclient.ObjectKey).Name]
+		}).
+		Return(result.OfSuccess[k8sclient.Object](&appsv1.Deployment{}))
+
+	agentStatusManager := NewAgentStatusManager(instanaAgentClient, record.NewFakeRecorder(10)).(*agentStatusManager)
+	agentStatusManager.SetAgentOld(&instanav1.InstanaAgent{})
+	agentStatusManager.AddK8sSensorDeployment(
+		k8sclient.ObjectKey{Namespace: "instana-agent", Name: "instana-agent-k8sensor"},
+		"ingress-red-saas.instana.io:443",
+	)
+	agentStatusManager.AddK8sSensorDeployment(
+		k8sclient.ObjectKey{Namespace: "instana-agent", Name: "instana-agent-k8sensor-1"},
+		"ingress-blue-saas.instana.io:443",
+	)
+	// Building the same Deployment again does not add another backend
+	agentStatusManager.AddK8sSensorDeployment(
+		k8sclient.ObjectKey{Namespace: "instana-agent", Name: "instana-agent-k8sensor-1"},
+		"ingress-blue-saas.instana.io:443",
+	)
+
+	agentNew, err := agentStatusManager.agentWithUpdatedStatus(ctx, nil).Get()
+	assertions.NoError(err)
+	assertions.Equal(
+		[]instanav1.K8sSensorStatus{
+			{
+				Endpoint:   "ingress-red-saas.instana.io:443",
+				Deployment: instanav1.ResourceInfo{Name: "instana-agent-k8sensor", UID: "uid-0"},
+				Desired:    3,
+				Ready:      3,
+				Updated:    3,
+				Available:  3,
+			},
+			{
+				Endpoint:   "ingress-blue-saas.instana.io:443",
+				Deployment: instanav1.ResourceInfo{Name: "instana-agent-k8sensor-1", UID: "uid-1"},
+				Desired:    3,
+				Ready:      0,
+				Updated:    3,
+				Available:  0,
+			},
+		},
+		agentNew.Status.K8sSensors,
+	)
+
+	condition := meta.FindStatusCondition(agentNew.Status.Conditions, CondtionTypeAllK8sSensorsAvailable)
+	assertions.NotNil(condition)
+	assertions.Equal(metav1.ConditionFalse, condition.Status)
+	assertions.True(
+		strings.HasSuffix(condition.Message, " for backends: ingress-blue-saas.instana.io:443"),
+		condition.Message,
+	)
+}
+
+func TestAgentWithUpdatedStatusFiresEventsOnlyOnTransitions(t *testing.T) {
+	assertions := require.New(t)
+	ctx := t.Context()
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	recorder := record.NewFakeRecorder(10)
+	agentStatusManager := NewAgentStatusManager(instanaAgentClient, recorder).(*agentStatusManager)
+
+	reconcile := func(agent *instanav1.InstanaAgent, reconcileErr error) (*instanav1.InstanaAgent, []string) {
+		agentStatusManager.SetAgentOld(agent)
+		agentNew, _ := agentStatusManager.agentWithUpdatedStatus(ctx, reconcileErr).Get()
+
+		events := make([]string, 0, len(recorder.Events))
+		for len(recorder.Events) > 0 {
+			events = append(events, <-recorder.Events)
+		}
+		return agentNew, events
+	}
+
+	agentNew, events := reconcile(&instanav1.InstanaAgent{}, nil)
+	assertions.Contains(events, "Normal ReconcileSucceeded most recent reconcile of agent CR completed without issue")
+	lastUpdate := agentNew.Status.LastUpdate
+	assertions.False(lastUpdate.IsZero())
+
+	// Nothing changed, neither events nor a new LastUpdate
+	agentNew, events = reconcile(agentNew, nil)
+	assertions.Empty(events)
+	assertions.Equal(lastUpdate, agentNew.Status.LastUpdate)
+
+	agentNew, events = reconcile(agentNew, errors.New("apply failed"))
+	assertions.Equal([]string{"Warning ReconcileFailed apply failed"}, events)
+
+	// A different error is not a transition of the condition
+	agentNew, events = reconcile(agentNew, errors.New("apply failed again"))
+	assertions.Empty(events)
+	assertions.Equal(
+		"apply failed again",
+		meta.FindStatusCondition(agentNew.Status.Conditions, ConditionTypeReconcileSucceeded).Message,
+	)
+}
+
+func TestUpdateAgentStatusSkipsPatchWhenStatusIsUnchanged(t *testing.T) {
+	assertions := require.New(t)
+	ctx := t.Context()
+
+	os.Setenv("OPERATOR_VERSION", "v2.1.0")
+	defer os.Setenv("OPERATOR_VERSION", "")
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	defer instanaAgentClient.AssertExpectations(t)
+
+	agentStatusManager := NewAgentStatusManager(instanaAgentClient, record.NewFakeRecorder(10)).(*agentStatusManager)
+	agentStatusManager.SetAgentOld(&instanav1.InstanaAgent{ObjectMeta: metav1.ObjectMeta{Generation: 1}})
+	agentNew, _ := agentStatusManager.agentWithUpdatedStatus(ctx, nil).Get()
+
+	// The status read back from the cluster holds the normalized operator version
+	version, err := semver.NewVersion(agentNew.Status.OperatorVersion.String())
+	assertions.NoError(err)
+	agentNew.Status.OperatorVersion = &instanav1.SemanticVersion{Version: *version}
+
+	agentStatusManager.SetAgentOld(agentNew)
+	assertions.NoError(agentStatusManager.UpdateAgentStatus(ctx, nil))
+	instanaAgentClient.AssertNotCalled(t, "Status")
+}
+
+func TestAgentWithUpdatedStatusReportsSummary(t *testing.T) {
+	assertions := require.New(t)
+	ctx := t.Context()
+
+	agentDaemonset := func(name string, ready int32, desired int32) appsv1.DaemonSet {
+		return appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "instana-agent", Image: "icr.io/instana/agent:latest"}},
+					},
+				},
+			},
+			Status: appsv1.DaemonSetStatus{NumberReady: ready, DesiredNumberScheduled: desired},
+		}
+	}
+	k8sSensor := func(name string, ready int32) appsv1.Deployment {
+		return appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       appsv1.DeploymentSpec{Replicas: pointer.To[int32](3)},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: ready},
+		}
+	}
+	daemonsets := map[string]appsv1.DaemonSet{
+		"instana-agent-east": agentDaemonset("instana-agent-east", 2, 3),
+		"instana-agent-west": agentDaemonset("instana-agent-west", 4, 4),
+	}
+	deployments := map[string]appsv1.Deployment{
+		"instana-agent-k8sensor":   k8sSensor("instana-agent-k8sensor", 3),
+		"instana-agent-k8sensor-1": k8sSensor("instana-agent-k8sensor-1", 1),
+	}
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	instanaAgentClient.On("GetAsResult", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			name := args.Get(1).(k8sclient.ObjectKey).Name
+			switch obj := args.Get(2).(type) {
+			case *appsv1.DaemonSet:
+				*obj = daemonsets[name]
+			case *appsv1.Deployment:
+				*obj = deployments[name]
+			}
+		}).
+		Return(result.OfSuccess[k8sclient.Object](&appsv1.Deployment{}))
+	instanaAgentClient.On("List", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	agentStatusManager := NewAgentStatusManager(instanaAgentClient, record.NewFakeRecorder(10)).(*agentStatusManager)
+	agentStatusManager.SetAgentOld(
+		&instanav1.InstanaAgent{Spec: instanav1.InstanaAgentSpec{Zone: instanav1.Name{Name: "east"}}},
+	)
+	agentStatusManager.AddAgentDaemonset(k8sclient.ObjectKey{Name: "instana-agent-east"})
+	agentStatusManager.AddAgentDaemonset(k8sclient.ObjectKey{Name: "instana-agent-west"})
+	agentStatusManager.AddK8sSensorDeployment(
+		k8sclient.ObjectKey{Name: "instana-agent-k8sensor"},
+		"ingress-red-saas.instana.io:443",
+	)
+	agentStatusManager.AddK8sSensorDeployment(
+		k8sclient.ObjectKey{Name: "instana-agent-k8sensor-1"},
+		"ingress-blue-saas.instana.io:443",
+	)
+	agentStatusManager.SetReconcilePaused(true)
+
+	agentNew, err := agentStatusManager.agentWithUpdatedStatus(ctx, nil).Get()
+	assertions.NoError(err)
+	assertions.Equal("6/7", agentNew.Status.Agents)
+	assertions.Equal("icr.io/instana/agent:latest", agentNew.Status.AgentImage)
+	assertions.Equal("4/6", agentNew.Status.K8sSensor)
+	assertions.Equal(instanav1.ReconcileStatePaused, agentNew.Status.ReconcileState)
+	assertions.Equal("east", agentNew.Status.Cluster)
+
+	agentNew, _ = agentStatusManager.agentWithUpdatedStatus(ctx, errors.New("FAILURE")).Get()
+	assertions.Equal(instanav1.ReconcileStateFailed, agentNew.Status.ReconcileState)
+}
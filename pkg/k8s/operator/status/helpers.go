@@ -5,16 +5,24 @@
 package status
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	"github.com/Masterminds/semver/v3"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
 	"github.com/instana/instana-agent-operator/pkg/env"
 	"github.com/instana/instana-agent-operator/pkg/optional"
+	"github.com/instana/instana-agent-operator/pkg/pointer"
 	"github.com/instana/instana-agent-operator/pkg/result"
 )
 
@@ -23,6 +31,11 @@ const (
 	ConditionTypeReconcileSucceeded    = "ReconcileSucceeded"
 	ConditionTypeAllAgentsAvailable    = "AllAgentsAvailable"
 	CondtionTypeAllK8sSensorsAvailable = "AllK8sSensorsAvailable"
+	ConditionTypeConfigurationValid    = "ConfigurationValid"
+	ConditionTypeReconcilePaused       = "ReconcilePaused"
+	ConditionTypeAllNodesCovered       = "AllNodesCovered"
+	ConditionTypeETCDMonitoring        = "ETCDMonitoring"
+	ConditionTypeAgentAvailable        = "AgentAvailable"
 )
 
 func getAgentPhase(reconcileErr error) instanav1.AgentOperatorState {
@@ -60,6 +73,93 @@ func truncateMessage(message string) string {
 	}
 }
 
+func getConfigurationValidCondition(configurationErrs field.ErrorList, generation int64) metav1.Condition {
+	res := metav1.Condition{
+		Type:               ConditionTypeConfigurationValid,
+		Status:             "",
+		ObservedGeneration: generation,
+		Reason:             "",
+		Message:            "",
+	}
+
+	switch len(configurationErrs) {
+	case 0:
+		res.Status = metav1.ConditionTrue
+		res.Reason = "ConfigurationValid"
+		res.Message = "no conflicting or invalid settings were found in the spec"
+	default:
+		res.Status = metav1.ConditionFalse
+		res.Reason = "InvalidConfiguration"
+		res.Message = truncateMessage(configurationErrs.ToAggregate().Error())
+	}
+
+	return res
+}
+
+func getReconcilePausedCondition(generation int64) metav1.Condition {
+	return metav1.Condition{
+		Type:               ConditionTypeReconcilePaused,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "ReconcilePaused",
+		Message: fmt.Sprintf(
+			"reconciliation is paused by the %s annotation, dependents are neither applied nor cleaned up",
+			instanav1.ReconcilePausedAnnotation,
+		),
+	}
+}
+
+// etcdMonitoringReasons are the reasons of the ETCDMonitoring condition per source of the ETCD targets
+var etcdMonitoringReasons = map[instanav1.ETCDMonitoringSource]string{
+	instanav1.ETCDMonitoringSourceSpec:       "ETCDTargetsConfigured",
+	instanav1.ETCDMonitoringSourceDiscovered: "ETCDTargetsDiscovered",
+	instanav1.ETCDMonitoringSourceOpenShift:  "OpenShiftETCDResourcesCopied",
+}
+
+func getETCDMonitoringCondition(etcdMonitoring *instanav1.ETCDMonitoringStatus, generation int64) metav1.Condition {
+	res := metav1.Condition{
+		Type:               ConditionTypeETCDMonitoring,
+		Status:             "",
+		ObservedGeneration: generation,
+		Reason:             "",
+		Message:            "",
+	}
+
+	switch reason, enabled := etcdMonitoringReasons[etcdMonitoring.Source]; enabled {
+	case true:
+		res.Status = metav1.ConditionTrue
+		res.Reason = reason
+		res.Message = truncateMessage(
+			fmt.Sprintf(
+				"k8sensor monitors ETCD at %s (CA found: %t)",
+				strings.Join(etcdMonitoring.Targets, ", "),
+				etcdMonitoring.CAFound,
+			),
+		)
+	default:
+		res.Status = metav1.ConditionFalse
+		res.Reason = "ETCDMonitoringDisabled"
+		res.Message = truncateMessage(etcdMonitoring.DisabledReason)
+	}
+
+	return res
+}
+
+// conditionChanged checks whether setting the condition changes the status or reason of the existing condition of its
+// type. Changes to the message alone, e.g. a different error of a failing reconcile, are not considered a transition.
+func conditionChanged(conditions []metav1.Condition, condition metav1.Condition) bool {
+	existing := meta.FindStatusCondition(conditions, condition.Type)
+	return existing == nil || existing.Status != condition.Status || existing.Reason != condition.Reason
+}
+
+// statusChanged compares the status as it is serialized into the CR, which for instance normalizes the operator
+// version and truncates times to seconds
+func statusChanged(statusOld any, statusNew any) bool {
+	jsonOld, errOld := json.Marshal(statusOld)
+	jsonNew, errNew := json.Marshal(statusNew)
+	return errOld != nil || errNew != nil || !bytes.Equal(jsonOld, jsonNew)
+}
+
 func eventTypeFromCondition(condition metav1.Condition) string {
 	if condition.Status == metav1.ConditionTrue {
 		return corev1.EventTypeNormal
@@ -84,6 +184,64 @@ func daemonsetIsAvailable(ds appsv1.DaemonSet) bool {
 	}
 }
 
+func getReconcileState(reconcileErr error, paused bool, previewed bool) instanav1.ReconcileState {
+	switch {
+	case reconcileErr != nil:
+		return instanav1.ReconcileStateFailed
+	case paused:
+		return instanav1.ReconcileStatePaused
+	case previewed:
+		return instanav1.ReconcileStatePreview
+	default:
+		return instanav1.ReconcileStateSucceeded
+	}
+}
+
+// getClusterName is the cluster name the agents report, falling back to the zone name like the agents themselves
+func getClusterName(agent *instanav1.InstanaAgent) string {
+	return optional.Of(agent.Spec.Cluster.Name).GetOrDefault(agent.Spec.Zone.Name)
+}
+
+// toZoneStatus counts the agents of a zone from the status of its DaemonSet. The agents of a zone run in the mode of
+// the zone, the agent mode of the spec does not apply to them.
+func toZoneStatus(zone instanav1.Zone, ds appsv1.DaemonSet) instanav1.ZoneStatus {
+	return instanav1.ZoneStatus{
+		Name:      zone.Name.Name,
+		Mode:      optional.Of(zone.Mode).GetOrDefault(instanav1.APM),
+		DaemonSet: instanav1.ResourceInfo{Name: ds.Name, UID: string(ds.UID)},
+		Desired:   ds.Status.DesiredNumberScheduled,
+		Ready:     ds.Status.NumberReady,
+		Updated:   ds.Status.UpdatedNumberScheduled,
+		Available: ds.Status.NumberAvailable,
+	}
+}
+
+// toK8sSensorStatus counts the k8sensors of a backend from the status of its Deployment
+func toK8sSensorStatus(endpoint string, deployment appsv1.Deployment) instanav1.K8sSensorStatus {
+	return instanav1.K8sSensorStatus{
+		Endpoint:   endpoint,
+		Deployment: instanav1.ResourceInfo{Name: deployment.Name, UID: string(deployment.UID)},
+		Desired:    pointer.DerefOrDefault(deployment.Spec.Replicas, 1),
+		Ready:      deployment.Status.ReadyReplicas,
+		Updated:    deployment.Status.UpdatedReplicas,
+		Available:  deployment.Status.AvailableReplicas,
+	}
+}
+
+func toRemoteAgentDeploymentStatus(
+	endpoint string,
+	deployment appsv1.Deployment,
+) instanav1.RemoteAgentDeploymentStatus {
+	return instanav1.RemoteAgentDeploymentStatus{
+		Endpoint:   endpoint,
+		Deployment: instanav1.ResourceInfo{Name: deployment.Name, UID: string(deployment.UID)},
+		Desired:    pointer.DerefOrDefault(deployment.Spec.Replicas, 1),
+		Ready:      deployment.Status.ReadyReplicas,
+		Updated:    deployment.Status.UpdatedReplicas,
+		Available:  deployment.Status.AvailableReplicas,
+	}
+}
+
 type deploymentConditionsMap map[appsv1.DeploymentConditionType]appsv1.DeploymentCondition
 
 func deploymentConditionsAsMap(conditions []appsv1.DeploymentCondition) deploymentConditionsMap {
@@ -146,10 +304,54 @@ func setStatusDotDaemonset(agentNew *instanav1.InstanaAgent) func(ds instanav1.R
 	}
 }
 
-func setStatusDotDeployment(agentNew *instanav1.InstanaAgentRemote) func(deployment instanav1.ResourceInfo) {
-	return func(deployment instanav1.ResourceInfo) {
-		// Set the status of the agent based on the Deployment status
-		agentNew.Status.Deployment = deployment
+func setStatusDotZones(agentNew *instanav1.InstanaAgent) func(zones []instanav1.ZoneStatus) {
+	return func(zones []instanav1.ZoneStatus) {
+		agentNew.Status.Zones = zones
+	}
+}
+
+func setStatusDotK8sSensors(agentNew *instanav1.InstanaAgent) func(k8sSensors []instanav1.K8sSensorStatus) {
+	return func(k8sSensors []instanav1.K8sSensorStatus) {
+		agentNew.Status.K8sSensors = k8sSensors
+	}
+}
+
+// setStatusDotAgentsSummary sums up the ready and desired agents of all DaemonSets and reports the image they run
+func setStatusDotAgentsSummary(agentNew *instanav1.InstanaAgent) func(daemonsets []appsv1.DaemonSet) {
+	return func(daemonsets []appsv1.DaemonSet) {
+		agentNew.Status.Agents = ""
+		agentNew.Status.AgentImage = ""
+		if len(daemonsets) == 0 {
+			return
+		}
+
+		var ready, desired int32
+		for _, ds := range daemonsets {
+			ready += ds.Status.NumberReady
+			desired += ds.Status.DesiredNumberScheduled
+		}
+		agentNew.Status.Agents = fmt.Sprintf("%d/%d", ready, desired)
+
+		if containers := daemonsets[0].Spec.Template.Spec.Containers; len(containers) > 0 {
+			agentNew.Status.AgentImage = containers[0].Image
+		}
+	}
+}
+
+// setStatusDotK8sSensorSummary sums up the ready and desired k8sensor replicas of all backends
+func setStatusDotK8sSensorSummary(agentNew *instanav1.InstanaAgent) func(k8sSensors []instanav1.K8sSensorStatus) {
+	return func(k8sSensors []instanav1.K8sSensorStatus) {
+		agentNew.Status.K8sSensor = ""
+		if len(k8sSensors) == 0 {
+			return
+		}
+
+		var ready, desired int32
+		for _, k8sSensor := range k8sSensors {
+			ready += k8sSensor.Ready
+			desired += k8sSensor.Desired
+		}
+		agentNew.Status.K8sSensor = fmt.Sprintf("%d/%d", ready, desired)
 	}
 }
 
@@ -25,6 +25,9 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
 )
 
 func TestDaemonSetIsAvailable(t *testing.T) {
@@ -302,3 +305,96 @@ func TestTruncateMessageWhenLengthIsTooLong(t *testing.T) {
 	assertions.NotEqual(len(longString), 32768)
 	assertions.Equal(len(response), 32768)
 }
+
+func TestGetConfigurationValidCondition(t *testing.T) {
+	for _, test := range []struct {
+		name              string
+		configurationErrs field.ErrorList
+		expected          metav1.Condition
+	}{
+		{
+			name: "Should be true when there are no configuration errors",
+			expected: metav1.Condition{
+				Type:               ConditionTypeConfigurationValid,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: 3,
+				Reason:             "ConfigurationValid",
+				Message:            "no conflicting or invalid settings were found in the spec",
+			},
+		},
+		{
+			name: "Should be false and list all configuration errors",
+			configurationErrs: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "agent", "endpointPort"), "https", "must be numeric"),
+				field.Invalid(field.NewPath("spec", "agent", "keysSecret"), "keys", "missing key-1"),
+			},
+			expected: metav1.Condition{
+				Type:               ConditionTypeConfigurationValid,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: 3,
+				Reason:             "InvalidConfiguration",
+				Message: "[spec.agent.endpointPort: Invalid value: \"https\": must be numeric, " +
+					"spec.agent.keysSecret: Invalid value: \"keys\": missing key-1]",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, getConfigurationValidCondition(test.configurationErrs, 3))
+		})
+	}
+}
+
+func TestGetETCDMonitoringCondition(t *testing.T) {
+	for _, test := range []struct {
+		name           string
+		etcdMonitoring instanav1.ETCDMonitoringStatus
+		expected       metav1.Condition
+	}{
+		{
+			name: "Should be true and name the targets when ETCD targets were discovered",
+			etcdMonitoring: instanav1.ETCDMonitoringStatus{
+				Source:  instanav1.ETCDMonitoringSourceDiscovered,
+				Targets: []string{"https://10.0.0.1:2379/metrics", "https://10.0.0.2:2379/metrics"},
+				CAFound: true,
+			},
+			expected: metav1.Condition{
+				Type:               ConditionTypeETCDMonitoring,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: 3,
+				Reason:             "ETCDTargetsDiscovered",
+				Message: "k8sensor monitors ETCD at https://10.0.0.1:2379/metrics, https://10.0.0.2:2379/metrics " +
+					"(CA found: true)",
+			},
+		},
+		{
+			name: "Should be true for ETCD resources copied on OpenShift",
+			etcdMonitoring: instanav1.ETCDMonitoringStatus{
+				Source:  instanav1.ETCDMonitoringSourceOpenShift,
+				Targets: []string{"https://etcd.openshift-etcd.svc.cluster.local:9979/metrics"},
+				CAFound: true,
+			},
+			expected: metav1.Condition{
+				Type:               ConditionTypeETCDMonitoring,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: 3,
+				Reason:             "OpenShiftETCDResourcesCopied",
+				Message:            "k8sensor monitors ETCD at https://etcd.openshift-etcd.svc.cluster.local:9979/metrics (CA found: true)",
+			},
+		},
+		{
+			name:           "Should be false with the reason ETCD monitoring is disabled",
+			etcdMonitoring: instanav1.ETCDMonitoringStatus{DisabledReason: "no ETCD service was found in the kube-system namespace"},
+			expected: metav1.Condition{
+				Type:               ConditionTypeETCDMonitoring,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: 3,
+				Reason:             "ETCDMonitoringDisabled",
+				Message:            "no ETCD service was found in the kube-system namespace",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, getETCDMonitoringCondition(&test.etcdMonitoring, 3))
+		})
+	}
+}
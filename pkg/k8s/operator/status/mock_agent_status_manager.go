@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
@@ -28,10 +29,16 @@ import (
 // MockAgentStatusManager is a mock implementation of the AgentStatusManager interface for testing
 type MockAgentStatusManager struct {
 	AgentDaemonsets          []client.ObjectKey
-	K8sSensorDeployment      client.ObjectKey
+	K8sSensorDeployments     map[string]client.ObjectKey
 	AgentSecretConfig        client.ObjectKey
 	AgentNamespacesConfigMap client.ObjectKey
 	AgentOld                 *instanav1.InstanaAgent
+	ConfigurationErrs        field.ErrorList
+	Inventory                []instanav1.ResourceInventoryEntry
+	Preview                  *instanav1.PreviewStatus
+	ReconcilePaused          bool
+	ETCDMonitoring           *instanav1.ETCDMonitoringStatus
+	TLS                      *instanav1.TLSStatus
 }
 
 // AddAgentDaemonset implements AgentStatusManager
@@ -44,9 +51,12 @@ func (m *MockAgentStatusManager) SetAgentOld(agent *instanav1.InstanaAgent) {
 	m.AgentOld = agent
 }
 
-// SetK8sSensorDeployment implements AgentStatusManager
-func (m *MockAgentStatusManager) SetK8sSensorDeployment(k8sSensorDeployment client.ObjectKey) {
-	m.K8sSensorDeployment = k8sSensorDeployment
+// AddK8sSensorDeployment implements AgentStatusManager
+func (m *MockAgentStatusManager) AddK8sSensorDeployment(k8sSensorDeployment client.ObjectKey, endpoint string) {
+	if m.K8sSensorDeployments == nil {
+		m.K8sSensorDeployments = make(map[string]client.ObjectKey)
+	}
+	m.K8sSensorDeployments[endpoint] = k8sSensorDeployment
 }
 
 // SetAgentSecretConfig implements AgentStatusManager
@@ -61,7 +71,42 @@ func (m *MockAgentStatusManager) SetAgentNamespacesConfigMap(
 	m.AgentNamespacesConfigMap = agentNamespacesConfigmap
 }
 
+// SetConfigurationValidation implements AgentStatusManager
+func (m *MockAgentStatusManager) SetConfigurationValidation(configurationErrs field.ErrorList) {
+	m.ConfigurationErrs = configurationErrs
+}
+
+// SetInventory implements AgentStatusManager
+func (m *MockAgentStatusManager) SetInventory(inventory []instanav1.ResourceInventoryEntry) {
+	m.Inventory = inventory
+}
+
+// SetPreview implements AgentStatusManager
+func (m *MockAgentStatusManager) SetPreview(preview *instanav1.PreviewStatus) {
+	m.Preview = preview
+}
+
+// SetReconcilePaused implements AgentStatusManager
+func (m *MockAgentStatusManager) SetReconcilePaused(paused bool) {
+	m.ReconcilePaused = paused
+}
+
+// SetETCDMonitoring implements AgentStatusManager
+func (m *MockAgentStatusManager) SetETCDMonitoring(etcdMonitoring *instanav1.ETCDMonitoringStatus) {
+	m.ETCDMonitoring = etcdMonitoring
+}
+
+// SetTLS implements AgentStatusManager
+func (m *MockAgentStatusManager) SetTLS(tls *instanav1.TLSStatus) {
+	m.TLS = tls
+}
+
 // UpdateAgentStatus implements AgentStatusManager
 func (m *MockAgentStatusManager) UpdateAgentStatus(ctx context.Context, reconcileErr error) error {
 	return nil
 }
+
+// UpdateAvailability implements AgentStatusManager
+func (m *MockAgentStatusManager) UpdateAvailability(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,359 @@
+/*
+(c) Copyright IBM Corp. 2026
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/multierror"
+	"github.com/instana/instana-agent-operator/pkg/result"
+)
+
+// maxUncoveredNodes bounds the number of uncovered nodes reported in the status
+const maxUncoveredNodes = 50
+
+// daemonSetTolerations are the tolerations the DaemonSet controller adds to the pods of every DaemonSet
+var daemonSetTolerations = []corev1.Toleration{
+	{Key: corev1.TaintNodeNotReady, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	{Key: corev1.TaintNodeUnreachable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	{Key: corev1.TaintNodeDiskPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodeMemoryPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodePIDPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodeUnschedulable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodeNetworkUnavailable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+}
+
+// nodeSelectorOperators maps the operators of node selector requirements to those of label selectors
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+// getNodeCoverage compares the nodes of the cluster with the pods of the agent DaemonSets
+func (a *agentStatusManager) getNodeCoverage(ctx context.Context) result.Result[*instanav1.NodeCoverageStatus] {
+	if len(a.agentDaemonsets) == 0 {
+		return result.OfSuccess[*instanav1.NodeCoverageStatus](nil)
+	}
+
+	var nodes corev1.NodeList
+	if err := a.instAgentClient.List(ctx, &nodes); err != nil {
+		return result.OfFailure[*instanav1.NodeCoverageStatus](err)
+	}
+	if len(nodes.Items) == 0 {
+		return result.OfSuccess(&instanav1.NodeCoverageStatus{})
+	}
+
+	errBuilder := multierror.NewMultiErrorBuilder()
+
+	daemonsets := make([]appsv1.DaemonSet, 0, len(a.agentDaemonsets))
+	pods := make([]corev1.Pod, 0, len(nodes.Items))
+	for _, key := range a.agentDaemonsets {
+		var ds appsv1.DaemonSet
+		if res := a.instAgentClient.GetAsResult(ctx, key, &ds); res.IsFailure() {
+			_, err := res.Get()
+			errBuilder.AddSingle(err)
+			continue
+		}
+		daemonsets = append(daemonsets, ds)
+
+		if ds.Spec.Selector == nil {
+			continue
+		}
+		var dsPods corev1.PodList
+		if err := a.instAgentClient.List(
+			ctx,
+			&dsPods,
+			client.InNamespace(ds.Namespace),
+			client.MatchingLabels(ds.Spec.Selector.MatchLabels),
+		); err != nil {
+			errBuilder.AddSingle(err)
+			continue
+		}
+		pods = append(pods, dsPods.Items...)
+	}
+
+	if err := errBuilder.Build(); err != nil {
+		return result.OfFailure[*instanav1.NodeCoverageStatus](err)
+	}
+
+	return result.OfSuccess(toNodeCoverage(log.FromContext(ctx), nodes.Items, daemonsets, pods))
+}
+
+// toNodeCoverage reports every node without a ready agent pod together with the reason for it
+func toNodeCoverage(
+	logger logr.Logger,
+	nodes []corev1.Node,
+	daemonsets []appsv1.DaemonSet,
+	pods []corev1.Pod,
+) *instanav1.NodeCoverageStatus {
+	podsByNode := make(map[string][]corev1.Pod, len(pods))
+	for _, pod := range pods {
+		if nodeName := podNodeName(pod); nodeName != "" {
+			podsByNode[nodeName] = append(podsByNode[nodeName], pod)
+		}
+	}
+
+	coverage := &instanav1.NodeCoverageStatus{}
+	uncovered := make([]instanav1.UncoveredNode, 0)
+
+	for _, node := range nodes {
+		// cordoned nodes are counted as well, the agent pods tolerate them like the pods of any other DaemonSet
+		coverage.Nodes++
+
+		nodePods := podsByNode[node.Name]
+		if slices.ContainsFunc(nodePods, podIsReady) {
+			coverage.Covered++
+			continue
+		}
+
+		entry := instanav1.UncoveredNode{Name: node.Name}
+		switch len(nodePods) {
+		case 0:
+			entry.Reason, entry.Message = nodeWithoutPodReason(logger, node, daemonsets)
+		default:
+			entry.Reason, entry.Message = podNotReadyReason(nodePods[0])
+		}
+		uncovered = append(uncovered, entry)
+	}
+
+	slices.SortFunc(
+		uncovered, func(a, b instanav1.UncoveredNode) int {
+			return strings.Compare(a.Name, b.Name)
+		},
+	)
+	if len(uncovered) > maxUncoveredNodes {
+		uncovered = uncovered[:maxUncoveredNodes]
+	}
+	if len(uncovered) > 0 {
+		coverage.Uncovered = uncovered
+	}
+
+	return coverage
+}
+
+// podNodeName returns the node a pod runs on or, while it is not scheduled yet, the node the DaemonSet controller
+// created it for
+func podNodeName(pod corev1.Pod) string {
+	if pod.Spec.NodeName != "" {
+		return pod.Spec.NodeName
+	}
+
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil {
+		return ""
+	}
+	required := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil {
+		return ""
+	}
+	for _, term := range required.NodeSelectorTerms {
+		for _, field := range term.MatchFields {
+			if field.Key == metav1.ObjectNameField && field.Operator == corev1.NodeSelectorOpIn && len(field.Values) == 1 {
+				return field.Values[0]
+			}
+		}
+	}
+
+	return ""
+}
+
+func podIsReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podNotReadyReason explains why the agent pod of a node is not ready
+func podNotReadyReason(pod corev1.Pod) (instanav1.NodeCoverageReason, string) {
+	for _, status := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		if status.State.Waiting == nil || status.State.Waiting.Reason != "CrashLoopBackOff" {
+			continue
+		}
+		msg := fmt.Sprintf("container %s of pod %s restarted %d times", status.Name, pod.Name, status.RestartCount)
+		if terminated := status.LastTerminationState.Terminated; terminated != nil {
+			msg += fmt.Sprintf(", last terminated with %s (exit code %d)", terminated.Reason, terminated.ExitCode)
+		}
+		return instanav1.NodeCoverageReasonCrashLoopBackOff, msg
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != corev1.PodScheduled || condition.Status != corev1.ConditionFalse {
+			continue
+		}
+		if strings.Contains(condition.Message, "Insufficient") {
+			return instanav1.NodeCoverageReasonInsufficientResources, condition.Message
+		}
+		return instanav1.NodeCoverageReasonUnschedulable, condition.Message
+	}
+
+	msg := fmt.Sprintf("pod %s is %s", pod.Name, pod.Status.Phase)
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+			msg += fmt.Sprintf(", container %s is waiting with %s", status.Name, status.State.Waiting.Reason)
+			break
+		}
+	}
+	return instanav1.NodeCoverageReasonNotReady, msg
+}
+
+// nodeWithoutPodReason explains why no agent DaemonSet created a pod for a node
+func nodeWithoutPodReason(
+	logger logr.Logger,
+	node corev1.Node,
+	daemonsets []appsv1.DaemonSet,
+) (instanav1.NodeCoverageReason, string) {
+	var untolerated *corev1.Taint
+
+	for _, ds := range daemonsets {
+		podSpec := ds.Spec.Template.Spec
+		if !nodeMatchesPodSpec(node, podSpec) {
+			continue
+		}
+
+		taint := untoleratedTaint(logger, node, slices.Concat(podSpec.Tolerations, daemonSetTolerations))
+		if taint == nil {
+			return instanav1.NodeCoverageReasonNoAgentPod, fmt.Sprintf(
+				"DaemonSet %s targets the node, but has not created an agent pod for it",
+				ds.Name,
+			)
+		}
+		untolerated = taint
+	}
+
+	if untolerated != nil {
+		return instanav1.NodeCoverageReasonUntoleratedTaint, fmt.Sprintf(
+			"the agent pods do not tolerate the taint %s of the node, add a toleration to agent.pod.tolerations "+
+				"or to the tolerations of the zone",
+			untolerated.ToString(),
+		)
+	}
+
+	return instanav1.NodeCoverageReasonNodeSelectorMismatch,
+		"the node does not match the node selector or affinity of any agent DaemonSet, e.g. because it is not part " +
+			"of any zone"
+}
+
+// untoleratedTaint returns the first taint of the node that prevents pods with the given tolerations from being
+// scheduled or running on it
+func untoleratedTaint(logger logr.Logger, node corev1.Node, tolerations []corev1.Toleration) *corev1.Taint {
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		if !slices.ContainsFunc(
+			tolerations, func(toleration corev1.Toleration) bool {
+				return toleration.ToleratesTaint(logger, taint, true)
+			},
+		) {
+			return taint
+		}
+	}
+	return nil
+}
+
+// nodeMatchesPodSpec checks whether the node satisfies the node selector and the required node affinity of a pod
+func nodeMatchesPodSpec(node corev1.Node, podSpec corev1.PodSpec) bool {
+	if !labels.SelectorFromSet(podSpec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+
+	if podSpec.Affinity == nil || podSpec.Affinity.NodeAffinity == nil {
+		return true
+	}
+	required := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil {
+		return true
+	}
+
+	// The terms are ORed, the requirements of a term are ANDed
+	return slices.ContainsFunc(
+		required.NodeSelectorTerms, func(term corev1.NodeSelectorTerm) bool {
+			if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+				return false
+			}
+			return requirementsMatch(term.MatchExpressions, labels.Set(node.Labels)) &&
+				requirementsMatch(term.MatchFields, labels.Set{metav1.ObjectNameField: node.Name})
+		},
+	)
+}
+
+func requirementsMatch(requirements []corev1.NodeSelectorRequirement, set labels.Set) bool {
+	for _, requirement := range requirements {
+		operator, ok := nodeSelectorOperators[requirement.Operator]
+		if !ok {
+			return false
+		}
+		req, err := labels.NewRequirement(requirement.Key, operator, requirement.Values)
+		if err != nil || !req.Matches(set) {
+			return false
+		}
+	}
+	return true
+}
+
+func getAllNodesCoveredCondition(coverage *instanav1.NodeCoverageStatus, generation int64) metav1.Condition {
+	res := metav1.Condition{
+		Type:               ConditionTypeAllNodesCovered,
+		Status:             "",
+		ObservedGeneration: generation,
+		Reason:             "",
+		Message:            "",
+	}
+
+	switch coverage.Covered {
+	case coverage.Nodes:
+		res.Status = metav1.ConditionTrue
+		res.Reason = "AllNodesCovered"
+		res.Message = fmt.Sprintf("all %d nodes run a ready Instana agent", coverage.Nodes)
+	default:
+		uncovered := make([]string, 0, len(coverage.Uncovered))
+		for _, node := range coverage.Uncovered {
+			uncovered = append(uncovered, fmt.Sprintf("%s (%s)", node.Name, node.Reason))
+		}
+
+		res.Status = metav1.ConditionFalse
+		res.Reason = "NodesNotCovered"
+		res.Message = truncateMessage(
+			fmt.Sprintf(
+				"%d of %d nodes do not run a ready Instana agent: %s",
+				coverage.Nodes-coverage.Covered,
+				coverage.Nodes,
+				strings.Join(uncovered, ", "),
+			),
+		)
+	}
+
+	return res
+}
+
+func getNodeCoverageUnknownCondition(err error, generation int64) metav1.Condition {
+	return metav1.Condition{
+		Type:               ConditionTypeAllNodesCovered,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: generation,
+		Reason:             "NodeCoverageUnavailable",
+		Message:            truncateMessage(fmt.Sprintf("failed to compare nodes with agent pods due to error: %s", err)),
+	}
+}
@@ -0,0 +1,386 @@
+/*
+(c) Copyright IBM Corp. 2026
+*/
+
+package status
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/internal/mocks"
+	"github.com/instana/instana-agent-operator/pkg/result"
+)
+
+func coverageNode(name string, labels map[string]string, taints ...corev1.Taint) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       corev1.NodeSpec{Taints: taints},
+	}
+}
+
+func coveragePod(nodeName string, ready bool) corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent-" + nodeName},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func zoneDaemonSet(zone string, tolerations ...corev1.Toleration) appsv1.DaemonSet {
+	return appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent-" + zone, Namespace: "instana-agent"},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"zone": zone}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Tolerations: tolerations,
+					Affinity: &corev1.Affinity{
+						NodeAffinity: &corev1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+								NodeSelectorTerms: []corev1.NodeSelectorTerm{
+									{
+										MatchExpressions: []corev1.NodeSelectorRequirement{
+											{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{zone}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestToNodeCoverage(t *testing.T) {
+	assertions := require.New(t)
+
+	gpuTaint := corev1.Taint{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule}
+	controlPlaneTaint := corev1.Taint{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule}
+	preferTaint := corev1.Taint{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule}
+
+	cordoned := coverageNode(
+		"cordoned",
+		map[string]string{"zone": "a"},
+		corev1.Taint{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule},
+	)
+	cordoned.Spec.Unschedulable = true
+
+	nodes := []corev1.Node{
+		coverageNode("ready", map[string]string{"zone": "a"}, preferTaint),
+		coverageNode("crashing", map[string]string{"zone": "a"}),
+		coverageNode("pending", map[string]string{"zone": "a"}),
+		coverageNode("starting", map[string]string{"zone": "a"}),
+		coverageNode("gpu", map[string]string{"zone": "a"}, gpuTaint),
+		coverageNode("control-plane", map[string]string{"zone": "b"}, controlPlaneTaint),
+		coverageNode("no-zone", nil),
+		coverageNode("no-pod", map[string]string{"zone": "b"}, gpuTaint),
+		cordoned,
+	}
+
+	daemonsets := []appsv1.DaemonSet{
+		zoneDaemonSet("a"),
+		zoneDaemonSet("b", corev1.Toleration{Key: "gpu", Operator: corev1.TolerationOpExists}),
+	}
+
+	crashing := coveragePod("crashing", false)
+	crashing.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name:         "instana-agent",
+			RestartCount: 7,
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+			},
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137},
+			},
+		},
+	}
+
+	// Pods of a DaemonSet are bound to their node by affinity until they are scheduled
+	pending := coveragePod("", false)
+	pending.Name = "instana-agent-pending"
+	pending.Status.Phase = corev1.PodPending
+	pending.Spec.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchFields: []corev1.NodeSelectorRequirement{
+							{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"pending"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	pending.Status.Conditions = append(
+		pending.Status.Conditions, corev1.PodCondition{
+			Type:    corev1.PodScheduled,
+			Status:  corev1.ConditionFalse,
+			Reason:  corev1.PodReasonUnschedulable,
+			Message: "0/9 nodes are available: 1 Insufficient memory.",
+		},
+	)
+
+	starting := coveragePod("starting", false)
+	starting.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "instana-agent", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+	}
+
+	pods := []corev1.Pod{coveragePod("ready", true), coveragePod("cordoned", true), crashing, pending, starting}
+
+	coverage := toNodeCoverage(logr.Discard(), nodes, daemonsets, pods)
+
+	assertions.Equal(int32(9), coverage.Nodes)
+	assertions.Equal(int32(2), coverage.Covered)
+	assertions.Equal(
+		[]instanav1.UncoveredNode{
+			{
+				Name:   "control-plane",
+				Reason: instanav1.NodeCoverageReasonUntoleratedTaint,
+				Message: "the agent pods do not tolerate the taint node-role.kubernetes.io/control-plane:NoSchedule " +
+					"of the node, add a toleration to agent.pod.tolerations or to the tolerations of the zone",
+			},
+			{
+				Name:    "crashing",
+				Reason:  instanav1.NodeCoverageReasonCrashLoopBackOff,
+				Message: "container instana-agent of pod instana-agent-crashing restarted 7 times, last terminated with OOMKilled (exit code 137)",
+			},
+			{
+				Name:   "gpu",
+				Reason: instanav1.NodeCoverageReasonUntoleratedTaint,
+				Message: "the agent pods do not tolerate the taint gpu=true:NoSchedule of the node, add a toleration " +
+					"to agent.pod.tolerations or to the tolerations of the zone",
+			},
+			{
+				Name:    "no-pod",
+				Reason:  instanav1.NodeCoverageReasonNoAgentPod,
+				Message: "DaemonSet instana-agent-b targets the node, but has not created an agent pod for it",
+			},
+			{
+				Name:   "no-zone",
+				Reason: instanav1.NodeCoverageReasonNodeSelectorMismatch,
+				Message: "the node does not match the node selector or affinity of any agent DaemonSet, e.g. because " +
+					"it is not part of any zone",
+			},
+			{
+				Name:    "pending",
+				Reason:  instanav1.NodeCoverageReasonInsufficientResources,
+				Message: "0/9 nodes are available: 1 Insufficient memory.",
+			},
+			{
+				Name:    "starting",
+				Reason:  instanav1.NodeCoverageReasonNotReady,
+				Message: "pod instana-agent-starting is Running, container instana-agent is waiting with ImagePullBackOff",
+			},
+		},
+		coverage.Uncovered,
+	)
+}
+
+func TestToNodeCoverageBoundsUncoveredNodes(t *testing.T) {
+	nodes := make([]corev1.Node, 0, maxUncoveredNodes+10)
+	for i := range maxUncoveredNodes + 10 {
+		nodes = append(nodes, coverageNode(fmt.Sprintf("node-%03d", i), nil))
+	}
+
+	coverage := toNodeCoverage(logr.Discard(), nodes, nil, nil)
+
+	require.Equal(t, int32(maxUncoveredNodes+10), coverage.Nodes)
+	require.Len(t, coverage.Uncovered, maxUncoveredNodes)
+	require.Equal(t, "node-000", coverage.Uncovered[0].Name)
+}
+
+func TestNodeMatchesPodSpec(t *testing.T) {
+	node := coverageNode("node", map[string]string{"zone": "a", "cores": "8"})
+
+	for _, test := range []struct {
+		name     string
+		podSpec  corev1.PodSpec
+		expected bool
+	}{
+		{name: "no constraints", expected: true},
+		{name: "matching node selector", podSpec: corev1.PodSpec{NodeSelector: map[string]string{"zone": "a"}}, expected: true},
+		{name: "other node selector", podSpec: corev1.PodSpec{NodeSelector: map[string]string{"zone": "b"}}},
+		{
+			name: "any matching term",
+			podSpec: nodeAffinityPodSpec(
+				corev1.NodeSelectorTerm{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: "zone", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"a"}},
+					},
+				},
+				corev1.NodeSelectorTerm{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: "cores", Operator: corev1.NodeSelectorOpGt, Values: []string{"4"}},
+						{Key: "gpu", Operator: corev1.NodeSelectorOpDoesNotExist},
+					},
+				},
+			),
+			expected: true,
+		},
+		{
+			name: "all requirements of a term",
+			podSpec: nodeAffinityPodSpec(
+				corev1.NodeSelectorTerm{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: "zone", Operator: corev1.NodeSelectorOpExists},
+						{Key: "cores", Operator: corev1.NodeSelectorOpLt, Values: []string{"4"}},
+					},
+				},
+			),
+		},
+		{
+			name: "node name field",
+			podSpec: nodeAffinityPodSpec(
+				corev1.NodeSelectorTerm{
+					MatchFields: []corev1.NodeSelectorRequirement{
+						{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node"}},
+					},
+				},
+			),
+			expected: true,
+		},
+		{name: "empty term", podSpec: nodeAffinityPodSpec(corev1.NodeSelectorTerm{})},
+	} {
+		t.Run(
+			test.name, func(t *testing.T) {
+				require.Equal(t, test.expected, nodeMatchesPodSpec(node, test.podSpec))
+			},
+		)
+	}
+}
+
+func nodeAffinityPodSpec(terms ...corev1.NodeSelectorTerm) corev1.PodSpec {
+	return corev1.PodSpec{
+		Affinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+			},
+		},
+	}
+}
+
+func TestAgentWithUpdatedStatusReportsNodeCoverage(t *testing.T) {
+	assertions := require.New(t)
+	ctx := t.Context()
+
+	agent := &instanav1.InstanaAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "instana-agent", Namespace: "instana-agent", Generation: 2},
+		Spec: instanav1.InstanaAgentSpec{
+			K8sSensor: instanav1.K8sSpec{
+				DeploymentSpec: instanav1.KubernetesDeploymentSpec{
+					Enabled: instanav1.Enabled{
+						Enabled: func() *bool { b := false; return &b }(),
+					},
+				},
+			},
+		},
+	}
+	ds := zoneDaemonSet("a")
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	instanaAgentClient.On("GetAsResult", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*appsv1.DaemonSet) = ds
+		}).
+		Return(result.OfSuccess[k8sclient.Object](&ds))
+	instanaAgentClient.On("List", mock.Anything, mock.AnythingOfType("*v1.NodeList"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*corev1.NodeList).Items = []corev1.Node{
+				coverageNode("node-1", map[string]string{"zone": "a"}),
+				coverageNode("node-2", map[string]string{"zone": "b"}),
+			}
+		}).
+		Return(nil)
+	instanaAgentClient.On("List", mock.Anything, mock.AnythingOfType("*v1.PodList"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*corev1.PodList).Items = []corev1.Pod{coveragePod("node-1", true)}
+		}).
+		Return(nil)
+
+	recorder := record.NewFakeRecorder(10)
+	agentStatusManager := NewAgentStatusManager(instanaAgentClient, recorder).(*agentStatusManager)
+	agentStatusManager.SetAgentOld(agent)
+	agentStatusManager.AddAgentDaemonset(k8sclient.ObjectKeyFromObject(&ds))
+
+	agentNew, _ := agentStatusManager.agentWithUpdatedStatus(ctx, nil).Get()
+	assertions.Equal(int32(2), agentNew.Status.NodeCoverage.Nodes)
+	assertions.Equal(int32(1), agentNew.Status.NodeCoverage.Covered)
+	assertions.Len(agentNew.Status.NodeCoverage.Uncovered, 1)
+
+	condition := meta.FindStatusCondition(agentNew.Status.Conditions, ConditionTypeAllNodesCovered)
+	assertions.NotNil(condition)
+	assertions.Equal(metav1.ConditionFalse, condition.Status)
+	assertions.Equal("NodesNotCovered", condition.Reason)
+	assertions.Equal(
+		"1 of 2 nodes do not run a ready Instana agent: node-2 (NodeSelectorMismatch)",
+		condition.Message,
+	)
+
+	events := make([]string, 0, len(recorder.Events))
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assertions.True(
+		strings.Contains(strings.Join(events, "\n"), "Warning NodesNotCovered 1 of 2 nodes"),
+		events,
+	)
+}
+
+func TestAgentWithUpdatedStatusReportsUnknownNodeCoverageOnError(t *testing.T) {
+	assertions := require.New(t)
+
+	listErr := fmt.Errorf("nodes is forbidden")
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	instanaAgentClient.On("GetAsResult", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(result.OfSuccess[k8sclient.Object](&appsv1.DaemonSet{}))
+	instanaAgentClient.On("List", mock.Anything, mock.Anything, mock.Anything).Return(listErr)
+
+	agentStatusManager := NewAgentStatusManager(instanaAgentClient, record.NewFakeRecorder(10)).(*agentStatusManager)
+	agentStatusManager.SetAgentOld(
+		&instanav1.InstanaAgent{
+			Spec: instanav1.InstanaAgentSpec{
+				K8sSensor: instanav1.K8sSpec{
+					DeploymentSpec: instanav1.KubernetesDeploymentSpec{
+						Enabled: instanav1.Enabled{
+							Enabled: func() *bool { b := false; return &b }(),
+						},
+					},
+				},
+			},
+		},
+	)
+	agentStatusManager.AddAgentDaemonset(k8sclient.ObjectKey{Namespace: "instana-agent", Name: "instana-agent"})
+
+	agentNew, err := agentStatusManager.agentWithUpdatedStatus(t.Context(), nil).Get()
+	assertions.ErrorIs(err, listErr)
+
+	condition := meta.FindStatusCondition(agentNew.Status.Conditions, ConditionTypeAllNodesCovered)
+	assertions.NotNil(condition)
+	assertions.Equal(metav1.ConditionUnknown, condition.Status)
+	assertions.Equal("NodeCoverageUnavailable", condition.Reason)
+}
@@ -0,0 +1,87 @@
+/*
+(c) Copyright IBM Corp. 2026
+*/
+
+package status
+
+import (
+	"errors"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+)
+
+// reconcileHistoryLimit bounds the number of reconciles recorded in the status
+const reconcileHistoryLimit = 10
+
+// reconcileHistoryErrorLimit bounds the length of the error recorded for a reconcile, so that the history stays small
+const reconcileHistoryErrorLimit = 1024
+
+// newReconcileAttempt records the outcome of a reconcile that started at the given time
+func newReconcileAttempt(
+	started time.Time,
+	generation int64,
+	result instanav1.ReconcileState,
+	reconcileErr error,
+) instanav1.ReconcileAttempt {
+	attempt := instanav1.ReconcileAttempt{
+		Time:       metav1.Time{Time: started},
+		Generation: generation,
+		Duration:   metav1.Duration{Duration: time.Since(started).Round(time.Millisecond)},
+		Result:     result,
+	}
+
+	if reconcileErr != nil {
+		attempt.Object = failedObject(reconcileErr)
+		attempt.Error = reconcileErr.Error()
+		if len(attempt.Error) > reconcileHistoryErrorLimit {
+			attempt.Error = attempt.Error[:reconcileHistoryErrorLimit]
+		}
+	}
+
+	return attempt
+}
+
+// failedObject names the object the API server rejected as kind/name, if the error came from a request for it
+func failedObject(err error) string {
+	var apiStatus apierrors.APIStatus
+	if !errors.As(err, &apiStatus) {
+		return ""
+	}
+
+	details := apiStatus.Status().Details
+	if details == nil || details.Name == "" {
+		return ""
+	}
+
+	return details.Kind + "/" + details.Name
+}
+
+// recordReconcileAttempt appends the attempt to the history and drops the oldest attempts beyond the limit. An attempt
+// with the same result, generation and error as the newest attempt is not recorded again, so that reconciles that
+// change nothing, e.g. while paused or previewing or when failing the same way over and over, do not change the status
+// either.
+func recordReconcileAttempt(
+	history []instanav1.ReconcileAttempt,
+	attempt instanav1.ReconcileAttempt,
+) []instanav1.ReconcileAttempt {
+	if len(history) > 0 {
+		newest := history[len(history)-1]
+		if newest.Result == attempt.Result &&
+			newest.Generation == attempt.Generation &&
+			newest.Object == attempt.Object &&
+			newest.Error == attempt.Error {
+			return history
+		}
+	}
+
+	history = append(history, attempt)
+	if len(history) > reconcileHistoryLimit {
+		history = history[len(history)-reconcileHistoryLimit:]
+	}
+
+	return history
+}
@@ -0,0 +1,124 @@
+/*
+(c) Copyright IBM Corp. 2026
+*/
+
+package status
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	instanav1 "github.com/instana/instana-agent-operator/api/v1"
+	"github.com/instana/instana-agent-operator/pkg/multierror"
+)
+
+func TestNewReconcileAttemptNamesTheRejectedObject(t *testing.T) {
+	assertions := require.New(t)
+
+	conflict := apierrors.NewConflict(
+		schema.GroupResource{Group: "apps", Resource: "daemonsets"},
+		"instana-agent",
+		errors.New("the object has been modified"),
+	)
+	reconcileErr := multierror.NewMultiErrorBuilder(errors.New("unrelated"), conflict).Build()
+
+	attempt := newReconcileAttempt(
+		time.Now().Add(-time.Second),
+		3,
+		instanav1.ReconcileStateFailed,
+		reconcileErr,
+	)
+	assertions.Equal(int64(3), attempt.Generation)
+	assertions.Equal(instanav1.ReconcileStateFailed, attempt.Result)
+	assertions.Equal("daemonsets/instana-agent", attempt.Object)
+	assertions.Equal(reconcileErr.Error(), attempt.Error)
+	assertions.GreaterOrEqual(attempt.Duration.Duration, time.Second)
+
+	attempt = newReconcileAttempt(time.Now(), 3, instanav1.ReconcileStateFailed, errors.New(strings.Repeat("x", 2000)))
+	assertions.Empty(attempt.Object)
+	assertions.Len(attempt.Error, reconcileHistoryErrorLimit)
+}
+
+func TestRecordReconcileAttempt(t *testing.T) {
+	succeeded := func(generation int64) instanav1.ReconcileAttempt {
+		return instanav1.ReconcileAttempt{Generation: generation, Result: instanav1.ReconcileStateSucceeded}
+	}
+	failed := func(generation int64, err string) instanav1.ReconcileAttempt {
+		return instanav1.ReconcileAttempt{Generation: generation, Result: instanav1.ReconcileStateFailed, Error: err}
+	}
+
+	paused := func(generation int64) instanav1.ReconcileAttempt {
+		return instanav1.ReconcileAttempt{Generation: generation, Result: instanav1.ReconcileStatePaused}
+	}
+
+	failures := make([]instanav1.ReconcileAttempt, 0, reconcileHistoryLimit)
+	for i := range reconcileHistoryLimit {
+		failures = append(failures, failed(1, fmt.Sprintf("failure %d", i)))
+	}
+
+	for _, test := range []struct {
+		name     string
+		history  []instanav1.ReconcileAttempt
+		attempt  instanav1.ReconcileAttempt
+		expected []instanav1.ReconcileAttempt
+	}{
+		{
+			name:     "Should record the first attempt",
+			attempt:  succeeded(1),
+			expected: []instanav1.ReconcileAttempt{succeeded(1)},
+		},
+		{
+			name:     "Should not record another success of the same generation",
+			history:  []instanav1.ReconcileAttempt{failed(1, "conflict"), succeeded(1)},
+			attempt:  succeeded(1),
+			expected: []instanav1.ReconcileAttempt{failed(1, "conflict"), succeeded(1)},
+		},
+		{
+			name:     "Should record a success of a new generation",
+			history:  []instanav1.ReconcileAttempt{succeeded(1)},
+			attempt:  succeeded(2),
+			expected: []instanav1.ReconcileAttempt{succeeded(1), succeeded(2)},
+		},
+		{
+			name:     "Should not record the same failure again",
+			history:  []instanav1.ReconcileAttempt{failed(1, "conflict")},
+			attempt:  failed(1, "conflict"),
+			expected: []instanav1.ReconcileAttempt{failed(1, "conflict")},
+		},
+		{
+			name:     "Should record a different failure",
+			history:  []instanav1.ReconcileAttempt{failed(1, "conflict")},
+			attempt:  failed(1, "forbidden"),
+			expected: []instanav1.ReconcileAttempt{failed(1, "conflict"), failed(1, "forbidden")},
+		},
+		{
+			name:     "Should not record another paused reconcile of the same generation",
+			history:  []instanav1.ReconcileAttempt{paused(1)},
+			attempt:  paused(1),
+			expected: []instanav1.ReconcileAttempt{paused(1)},
+		},
+		{
+			name:     "Should record a paused reconcile after a success",
+			history:  []instanav1.ReconcileAttempt{succeeded(1)},
+			attempt:  paused(1),
+			expected: []instanav1.ReconcileAttempt{succeeded(1), paused(1)},
+		},
+		{
+			name:     "Should drop the oldest attempt beyond the limit",
+			history:  failures,
+			attempt:  succeeded(1),
+			expected: append(append([]instanav1.ReconcileAttempt{}, failures[1:]...), succeeded(1)),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, recordReconcileAttempt(test.history, test.attempt))
+		})
+	}
+}
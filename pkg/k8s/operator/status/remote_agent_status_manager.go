@@ -19,19 +19,20 @@ package status
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/Masterminds/semver/v3"
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
-	"github.com/instana/instana-agent-operator/pkg/collections/list"
 	"github.com/instana/instana-agent-operator/pkg/env"
 	instanaclient "github.com/instana/instana-agent-operator/pkg/k8s/client"
 	"github.com/instana/instana-agent-operator/pkg/multierror"
@@ -42,33 +43,50 @@ import (
 )
 
 type InstanaAgentRemoteStatusManager interface {
-	AddAgentDeployment(agentDeployment client.ObjectKey) // Added method for Deployment
+	AddAgentDeployment(agentDeployment client.ObjectKey, endpoint string)
 	SetAgentOld(agent *instanav1.InstanaAgentRemote)
 	SetAgentSecretConfig(agentSecretConfig client.ObjectKey)
+	SetConfigurationValidation(configurationErrs field.ErrorList)
+	SetInventory(inventory []instanav1.ResourceInventoryEntry)
+	SetReconcilePaused(paused bool)
 	UpdateAgentStatus(ctx context.Context, reconcileErr error) error
 }
 
+// remoteAgentDeployment is the agent Deployment of the backend with the given endpoint
+type remoteAgentDeployment struct {
+	key      client.ObjectKey
+	endpoint string
+}
+
 type instanaAgentRemoteStatusManager struct {
-	instAgentClient   instanaclient.InstanaAgentClient
-	eventRecorder     record.EventRecorder
-	agentOld          *instanav1.InstanaAgentRemote
-	agentDeployments  []client.ObjectKey // New field to store deployments
-	agentSecretConfig client.ObjectKey
+	instAgentClient        instanaclient.InstanaAgentClient
+	eventRecorder          record.EventRecorder
+	agentOld               *instanav1.InstanaAgentRemote
+	agentDeployments       []remoteAgentDeployment
+	agentSecretConfig      client.ObjectKey
+	configurationErrs      field.ErrorList
+	configurationValidated bool
+	inventory              []instanav1.ResourceInventoryEntry
+	reconcilePaused        bool
 }
 
 func NewInstanaAgentRemoteStatusManager(instAgentClient instanaclient.InstanaAgentClient, eventRecorder record.EventRecorder) InstanaAgentRemoteStatusManager {
 	return &instanaAgentRemoteStatusManager{
 		instAgentClient:  instAgentClient,
 		eventRecorder:    eventRecorder,
-		agentDeployments: make([]client.ObjectKey, 0, 1), // Initialize the deployments list
+		agentDeployments: make([]remoteAgentDeployment, 0, 1),
 	}
 }
 
-// AddAgentDeployment adds a deployment to the list of agent deployments
-func (a *instanaAgentRemoteStatusManager) AddAgentDeployment(agentDeployment client.ObjectKey) {
-	if !list.NewContainsElementChecker(a.agentDeployments).Contains(agentDeployment) {
-		a.agentDeployments = append(a.agentDeployments, agentDeployment)
+// AddAgentDeployment records the agent Deployment of a backend, identified by its host:port endpoint
+func (a *instanaAgentRemoteStatusManager) AddAgentDeployment(agentDeployment client.ObjectKey, endpoint string) {
+	for i := range a.agentDeployments {
+		if a.agentDeployments[i].key == agentDeployment {
+			a.agentDeployments[i].endpoint = endpoint
+			return
+		}
 	}
+	a.agentDeployments = append(a.agentDeployments, remoteAgentDeployment{key: agentDeployment, endpoint: endpoint})
 }
 
 func (a *instanaAgentRemoteStatusManager) SetAgentOld(agent *instanav1.InstanaAgentRemote) {
@@ -79,6 +97,24 @@ func (a *instanaAgentRemoteStatusManager) SetAgentSecretConfig(agentSecretConfig
 	a.agentSecretConfig = agentSecretConfig
 }
 
+// SetConfigurationValidation records the result of validating the spec, to be reported as the ConfigurationValid
+// condition
+func (a *instanaAgentRemoteStatusManager) SetConfigurationValidation(configurationErrs field.ErrorList) {
+	a.configurationErrs = configurationErrs
+	a.configurationValidated = true
+}
+
+// SetInventory records the dependents applied for the CR, to be reported in its status
+func (a *instanaAgentRemoteStatusManager) SetInventory(inventory []instanav1.ResourceInventoryEntry) {
+	a.inventory = inventory
+}
+
+// SetReconcilePaused records whether applying the spec was skipped because reconciliation is paused, to be reported
+// as the ReconcilePaused condition
+func (a *instanaAgentRemoteStatusManager) SetReconcilePaused(paused bool) {
+	a.reconcilePaused = paused
+}
+
 func (a *instanaAgentRemoteStatusManager) UpdateAgentStatus(ctx context.Context, reconcileErr error) (finalErr error) {
 	defer recovery.Catch(&finalErr)
 
@@ -93,6 +129,10 @@ func (a *instanaAgentRemoteStatusManager) UpdateAgentStatus(ctx context.Context,
 			OnFailure(errBuilder.AddSingle).
 			Get()
 
+	if !statusChanged(a.agentOld.Status, agentNew.Status) {
+		return errBuilder.Build()
+	}
+
 	if err := a.instAgentClient.Status().Patch(
 		ctx,
 		agentNew,
@@ -105,14 +145,52 @@ func (a *instanaAgentRemoteStatusManager) UpdateAgentStatus(ctx context.Context,
 	return errBuilder.Build()
 }
 
-func (a *instanaAgentRemoteStatusManager) getDeployment(ctx context.Context) result.Result[instanav1.ResourceInfo] {
-	if len(a.agentDeployments) != 1 {
-		return result.OfSuccess(instanav1.ResourceInfo{})
+// getAgentDeployments fetches the agent Deployment of every backend, in the order of the backends
+func (a *instanaAgentRemoteStatusManager) getAgentDeployments(ctx context.Context) result.Result[[]appsv1.Deployment] {
+	deployments := make([]appsv1.Deployment, 0, len(a.agentDeployments))
+
+	for _, agentDeployment := range a.agentDeployments {
+		var deployment appsv1.Deployment
+		if res := a.instAgentClient.GetAsResult(ctx, agentDeployment.key, &deployment); res.IsFailure() {
+			_, err := res.Get()
+			return result.OfFailure[[]appsv1.Deployment](
+				fmt.Errorf(
+					"failed to retrieve status of Instana Agent Remote Deployment: %s due to error: %w",
+					agentDeployment.key.Name,
+					err,
+				),
+			)
+		}
+		deployments = append(deployments, deployment)
 	}
 
-	deployment := a.instAgentClient.GetAsResult(ctx, a.agentDeployments[0], &appsv1.Deployment{})
+	return result.OfSuccess(deployments)
+}
 
-	return result.Map(deployment, toResourceInfo)
+// setDeployments reports the agent Deployment of every backend and how many of them are available
+func (a *instanaAgentRemoteStatusManager) setDeployments(
+	agentNew *instanav1.InstanaAgentRemote,
+	deployments []appsv1.Deployment,
+) {
+	agentNew.Status.Deployments = make([]instanav1.RemoteAgentDeploymentStatus, 0, len(deployments))
+	available := 0
+	for i, deployment := range deployments {
+		agentNew.Status.Deployments = append(
+			agentNew.Status.Deployments,
+			toRemoteAgentDeploymentStatus(a.agentDeployments[i].endpoint, deployment),
+		)
+		if deploymentHasMinimumAvailability(deploymentConditionsAsMap(deployment.Status.Conditions)) {
+			available++
+		}
+	}
+	agentNew.Status.AvailableBackends = fmt.Sprintf("%d/%d", available, len(deployments))
+
+	switch len(deployments) {
+	case 1:
+		agentNew.Status.Deployment = instanav1.ResourceInfo{Name: deployments[0].Name, UID: string(deployments[0].UID)}
+	default:
+		agentNew.Status.Deployment = instanav1.ResourceInfo{}
+	}
 }
 
 func (a *instanaAgentRemoteStatusManager) getConfigSecret(ctx context.Context) result.Result[instanav1.ResourceInfo] {
@@ -125,9 +203,13 @@ func (a *instanaAgentRemoteStatusManager) getConfigSecret(ctx context.Context) r
 	return result.Map(cm, toResourceInfo)
 }
 
+// setConditionAndFireEvent sets the condition and records an event for it if its status or reason changed
 func (a *instanaAgentRemoteStatusManager) setConditionAndFireEvent(agentNew *instanav1.InstanaAgentRemote, condition metav1.Condition) {
+	changed := conditionChanged(agentNew.Status.Conditions, condition)
 	meta.SetStatusCondition(&agentNew.Status.Conditions, condition)
-	a.eventRecorder.Event(agentNew, eventTypeFromCondition(condition), condition.Reason, condition.Message)
+	if changed {
+		a.eventRecorder.Event(agentNew, eventTypeFromCondition(condition), condition.Reason, condition.Message)
+	}
 }
 
 func (a *instanaAgentRemoteStatusManager) getReconcileSucceededCondition(reconcileErr error) metav1.Condition {
@@ -154,51 +236,64 @@ func (a *instanaAgentRemoteStatusManager) getReconcileSucceededCondition(reconci
 	return res
 }
 
-func (a *instanaAgentRemoteStatusManager) getAllAgentsAvailableCondition(ctx context.Context) result.Result[metav1.Condition] {
+// getAgentAvailableCondition reports whether the agent Deployments of all backends are available and rolled out
+func (a *instanaAgentRemoteStatusManager) getAgentAvailableCondition(
+	deployments []appsv1.Deployment,
+	deploymentsErr error,
+) metav1.Condition {
 	condition := metav1.Condition{
-		Type:               ConditionTypeAllAgentsAvailable,
+		Type:               ConditionTypeAgentAvailable,
 		Status:             "",
 		ObservedGeneration: a.agentOld.GetGeneration(),
 		Reason:             "",
 		Message:            "",
 	}
 
-	deployments := make([]appsv1.Deployment, 0, len(a.agentDeployments))
+	if deploymentsErr != nil {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "AgentDeploymentInfoUnavailable"
+		condition.Message = truncateMessage(deploymentsErr.Error())
+		return condition
+	}
 
-	for _, key := range a.agentDeployments {
-		var deploy appsv1.Deployment
-		switch res := a.instAgentClient.GetAsResult(ctx, key, &deploy); {
-		case res.IsSuccess():
-			deployments = append(deployments, deploy)
-		case res.IsFailure():
-			_, err := res.Get()
+	if len(deployments) == 0 {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "AgentDeploymentNotConfigured"
+		condition.Message = "Instana Agent Remote deployment has not been configured for this agent"
+		return condition
+	}
 
-			condition.Status = metav1.ConditionUnknown
-			condition.Reason = "AgentDeploymentInfoUnavailable"
-			msg := fmt.Sprintf(
-				"failed to retrieve status of Instana Agent Remote Deployment: %s due to error: %s",
-				key.Name,
-				err.Error(),
-			)
-			truncatedMsg := truncateMessage(msg)
-			condition.Message = truncatedMsg
+	unavailableEndpoints := make([]string, 0, len(deployments))
+	rollingOutEndpoints := make([]string, 0, len(deployments))
 
-			return result.Of(condition, err)
+	for i, deployment := range deployments {
+		conditions := deploymentConditionsAsMap(deployment.Status.Conditions)
+		switch {
+		case !deploymentHasMinimumAvailability(conditions), deploymentHasReplicaFailures(conditions):
+			unavailableEndpoints = append(unavailableEndpoints, a.agentDeployments[i].endpoint)
+		case !deploymentIsAvailableAndComplete(deployment):
+			rollingOutEndpoints = append(rollingOutEndpoints, a.agentDeployments[i].endpoint)
 		}
 	}
 
-	// Evaluate deployment availability (based on status conditions)
-	if list.NewConditions(deployments).All(deploymentIsAvailableAndComplete) && len(deployments) > 0 {
-		condition.Status = metav1.ConditionTrue
-		condition.Reason = "AllDesiredAgentsAvailable"
-		condition.Message = "All desired Instana Agent Remote are available and using up-to-date configuration"
-	} else {
+	switch {
+	case len(unavailableEndpoints) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "AgentUnavailable"
+		condition.Message = "Instana Agent Remote is not available for backends: " +
+			strings.Join(unavailableEndpoints, ", ")
+	case len(rollingOutEndpoints) > 0:
 		condition.Status = metav1.ConditionFalse
-		condition.Reason = "NotAllDesiredAgentsAvailable"
-		condition.Message = "Not all desired Instana Agent Remote are available or some Agents are not using up-to-date configuration"
+		condition.Reason = "AgentRollingOut"
+		condition.Message = "Instana Agent Remote is rolling out an updated configuration for backends: " +
+			strings.Join(rollingOutEndpoints, ", ")
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "AgentAvailable"
+		condition.Message = "Instana Agent Remote is available and using up-to-date configuration for all backends"
 	}
 
-	return result.OfSuccess(condition)
+	return condition
 }
 
 func (a *instanaAgentRemoteStatusManager) InstanaAgentRemoteWithUpdatedStatus(
@@ -214,13 +309,21 @@ func (a *instanaAgentRemoteStatusManager) InstanaAgentRemoteWithUpdatedStatus(
 		OnSuccess(setStatusDotConfigSecretRemote(agentNew)).
 		OnFailure(errBuilder.AddSingle)
 
-	a.getDeployment(ctx).
-		OnSuccess(setStatusDotDeployment(agentNew)). // New handler for Deployment status
-		OnFailure(errBuilder.AddSingle)
+	deployments, deploymentsErr := a.getAgentDeployments(ctx).
+		OnSuccess(func(deployments []appsv1.Deployment) { a.setDeployments(agentNew, deployments) }).
+		OnFailure(errBuilder.AddSingle).
+		Get()
 
 	// Handle Conditions
 
-	agentNew.Status.ObservedGeneration = pointer.To(a.agentOld.GetGeneration())
+	if a.inventory != nil {
+		agentNew.Status.Inventory = a.inventory
+	}
+
+	// A generation reconciled while paused has not been applied yet
+	if !a.reconcilePaused {
+		agentNew.Status.ObservedGeneration = pointer.To(a.agentOld.GetGeneration())
+	}
 
 	result.Of(semver.NewVersion(env.GetOperatorVersion())).
 		OnSuccess(setStatusDotOperatorVersionRemote(agentNew)).
@@ -231,11 +334,23 @@ func (a *instanaAgentRemoteStatusManager) InstanaAgentRemoteWithUpdatedStatus(
 	reconcileSucceededCondition := a.getReconcileSucceededCondition(reconcileErr)
 	a.setConditionAndFireEvent(agentNew, reconcileSucceededCondition)
 
-	allAgentsAvailableCondition, _ :=
-		a.getAllAgentsAvailableCondition(ctx).
-			OnFailure(errBuilder.AddSingle).
-			Get()
-	a.setConditionAndFireEvent(agentNew, allAgentsAvailableCondition)
+	if a.configurationValidated {
+		a.setConditionAndFireEvent(
+			agentNew,
+			getConfigurationValidCondition(a.configurationErrs, a.agentOld.GetGeneration()),
+		)
+	}
+
+	switch a.reconcilePaused {
+	case true:
+		a.setConditionAndFireEvent(agentNew, getReconcilePausedCondition(a.agentOld.GetGeneration()))
+	default:
+		meta.RemoveStatusCondition(&agentNew.Status.Conditions, ConditionTypeReconcilePaused)
+	}
+
+	a.setConditionAndFireEvent(agentNew, a.getAgentAvailableCondition(deployments, deploymentsErr))
+	// AgentAvailable replaces the AllAgentsAvailable condition reported by earlier operator versions
+	meta.RemoveStatusCondition(&agentNew.Status.Conditions, ConditionTypeAllAgentsAvailable)
 
 	return result.Of(agentNew, errBuilder.Build())
 }
@@ -10,10 +10,15 @@ import (
 	"github.com/go-errors/errors"
 	instanav1 "github.com/instana/instana-agent-operator/api/v1"
 	"github.com/instana/instana-agent-operator/internal/mocks"
+	"github.com/instana/instana-agent-operator/pkg/pointer"
 
 	"github.com/instana/instana-agent-operator/pkg/result"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -46,3 +51,123 @@ func TestUpdateInstanaAgentRemoteStatusReturnsErrorOnPatchFailure(t *testing.T)
 	err := agentStatusManager.UpdateAgentStatus(ctx, nil)
 	assertions.NotNil(err)
 }
+
+func TestUpdateInstanaAgentRemoteStatusSkipsPatchWhenStatusIsUnchanged(t *testing.T) {
+	assertions := require.New(t)
+	ctx := t.Context()
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	defer instanaAgentClient.AssertExpectations(t)
+
+	recorder := record.NewFakeRecorder(10)
+	agentStatusManager := NewInstanaAgentRemoteStatusManager(instanaAgentClient, recorder).(*instanaAgentRemoteStatusManager)
+	agentStatusManager.SetAgentOld(&instanav1.InstanaAgentRemote{})
+	agentNew, _ := agentStatusManager.InstanaAgentRemoteWithUpdatedStatus(ctx, nil).Get()
+	assertions.Len(recorder.Events, 2)
+	for len(recorder.Events) > 0 {
+		<-recorder.Events
+	}
+
+	agentStatusManager.SetAgentOld(agentNew)
+	assertions.NoError(agentStatusManager.UpdateAgentStatus(ctx, nil))
+	instanaAgentClient.AssertNotCalled(t, "Status")
+	assertions.Empty(recorder.Events)
+}
+
+func TestInstanaAgentRemoteWithUpdatedStatusReportsDeploymentsPerBackend(t *testing.T) {
+	assertions := require.New(t)
+	ctx := t.Context()
+
+	availableConditions := []appsv1.DeploymentCondition{
+		{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+		{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "NewReplicaSetAvailable"},
+	}
+	deployments := map[string]appsv1.Deployment{
+		"instana-agent-r": {
+			ObjectMeta: metav1.ObjectMeta{Name: "instana-agent-r", UID: "uid-0"},
+			Status: appsv1.DeploymentStatus{
+				ReadyReplicas:     1,
+				UpdatedReplicas:   1,
+				AvailableReplicas: 1,
+				Conditions:        availableConditions,
+			},
+		},
+		"instana-agent-r-1": {
+			ObjectMeta: metav1.ObjectMeta{Name: "instana-agent-r-1", UID: "uid-1", Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: pointer.To[int32](2)},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				ReadyReplicas:      2,
+				UpdatedReplicas:    1,
+				AvailableReplicas:  2,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+					{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "ReplicaSetUpdated"},
+				},
+			},
+		},
+	}
+
+	instanaAgentClient := &mocks.MockInstanaAgentClient{}
+	instanaAgentClient.On("GetAsResult", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*appsv1.Deployment) = deployments[args.Get(1).(k8sclient.ObjectKey).Name]
+		}).
+		Return(result.OfSuccess[k8sclient.Object](&appsv1.Deployment{}))
+
+	agentStatusManager := NewInstanaAgentRemoteStatusManager(
+		instanaAgentClient,
+		record.NewFakeRecorder(10),
+	).(*instanaAgentRemoteStatusManager)
+	agentStatusManager.SetAgentOld(
+		&instanav1.InstanaAgentRemote{
+			Status: instanav1.InstanaAgentRemoteStatus{
+				Conditions: []metav1.Condition{{Type: ConditionTypeAllAgentsAvailable, Status: metav1.ConditionTrue}},
+			},
+		},
+	)
+	agentStatusManager.AddAgentDeployment(
+		k8sclient.ObjectKey{Namespace: "instana-agent", Name: "instana-agent-r"},
+		"ingress-red-saas.instana.io:443",
+	)
+	agentStatusManager.AddAgentDeployment(
+		k8sclient.ObjectKey{Namespace: "instana-agent", Name: "instana-agent-r-1"},
+		"ingress-blue-saas.instana.io:443",
+	)
+
+	agentNew, err := agentStatusManager.InstanaAgentRemoteWithUpdatedStatus(ctx, nil).Get()
+	assertions.NoError(err)
+	assertions.Equal(
+		[]instanav1.RemoteAgentDeploymentStatus{
+			{
+				Endpoint:   "ingress-red-saas.instana.io:443",
+				Deployment: instanav1.ResourceInfo{Name: "instana-agent-r", UID: "uid-0"},
+				Desired:    1,
+				Ready:      1,
+				Updated:    1,
+				Available:  1,
+			},
+			{
+				Endpoint:   "ingress-blue-saas.instana.io:443",
+				Deployment: instanav1.ResourceInfo{Name: "instana-agent-r-1", UID: "uid-1"},
+				Desired:    2,
+				Ready:      2,
+				Updated:    1,
+				Available:  2,
+			},
+		},
+		agentNew.Status.Deployments,
+	)
+	assertions.Equal("2/2", agentNew.Status.AvailableBackends)
+	assertions.Empty(agentNew.Status.Deployment)
+
+	condition := meta.FindStatusCondition(agentNew.Status.Conditions, ConditionTypeAgentAvailable)
+	assertions.NotNil(condition)
+	assertions.Equal(metav1.ConditionFalse, condition.Status)
+	assertions.Equal("AgentRollingOut", condition.Reason)
+	assertions.Equal(
+		"Instana Agent Remote is rolling out an updated configuration for backends: ingress-blue-saas.instana.io:443",
+		condition.Message,
+	)
+	assertions.Nil(meta.FindStatusCondition(agentNew.Status.Conditions, ConditionTypeAllAgentsAvailable))
+}
@@ -0,0 +1,510 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	agentoperatorv1 "github.com/instana/instana-agent-operator/api/v1"
+	agentoperatorv2 "github.com/instana/instana-agent-operator/api/v2"
+	"github.com/instana/instana-agent-operator/controllers"
+	"github.com/instana/instana-agent-operator/pkg/certgen"
+	generatedtls "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/agent/generated-tls"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/builder"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/constants"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/helpers"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/namespaces"
+	k8ssensordeployment "github.com/instana/instana-agent-operator/pkg/k8s/object/builders/k8s-sensor/deployment"
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/transformations"
+	"github.com/instana/instana-agent-operator/pkg/k8s/operator/status"
+	"github.com/instana/instana-agent-operator/pkg/pointer"
+)
+
+// renderCommand is the first argument selecting the render mode instead of running the operator
+const renderCommand = "render"
+
+// renderOptions holds the inputs of the render subcommand that the operator would otherwise look up in the cluster
+type renderOptions struct {
+	filename                  string
+	openShift                 *bool
+	zones                     []string
+	keysSecretFile            string
+	secretsFile               string
+	namespacesFile            string
+	etcdTargets               []string
+	etcdCAFound               bool
+	openShiftETCDResources    bool
+	persistHostUniqueIDEnvVar bool
+}
+
+func parseRenderOptions(args []string, stderr io.Writer) (renderOptions, error) {
+	opts := renderOptions{}
+	var openShift bool
+	var zones string
+	var etcdTargets string
+
+	flags := flag.NewFlagSet(renderCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(
+			stderr,
+			"Usage: instana-agent-operator %s -f agent.yaml [flags]\n\n"+
+				"Prints the resources the operator would create for the InstanaAgent or InstanaAgentRemote "+
+				"resources in the given file, without accessing a cluster.\n\n",
+			renderCommand,
+		)
+		flags.PrintDefaults()
+	}
+
+	flags.StringVar(&opts.filename, "f", "", "File containing InstanaAgent or InstanaAgentRemote resources (required).")
+	flags.BoolVar(
+		&openShift, "openshift", false,
+		"Render for OpenShift. Defaults to spec.openshift of the InstanaAgent, which is otherwise detected in the cluster.",
+	)
+	flags.StringVar(&zones, "zones", "", "Comma-separated names of the zones to render, all zones are rendered by default.")
+	flags.StringVar(
+		&opts.keysSecretFile, "keys-secret", "",
+		"File containing the Secret referenced by spec.agent.keysSecret.",
+	)
+	flags.StringVar(
+		&opts.secretsFile, "secrets", "",
+		"File containing the Secrets referenced by the proxy and mirror password secret references.",
+	)
+	flags.StringVar(
+		&opts.namespacesFile, "namespaces", "",
+		"File containing the Namespaces of the cluster, only those labelled with "+
+			namespaces.WorkloadMonitoringLabel+" are provided to the agents.",
+	)
+	flags.StringVar(&etcdTargets, "etcd-targets", "", "Comma-separated ETCD metrics endpoints, as discovered on Kubernetes.")
+	flags.BoolVar(&opts.etcdCAFound, "etcd-ca", false, "Whether the ETCD CA secret was found on Kubernetes.")
+	flags.BoolVar(
+		&opts.openShiftETCDResources, "openshift-etcd", false,
+		"Whether the ETCD CA bundle and client certificate were found on OpenShift.",
+	)
+	flags.BoolVar(
+		&opts.persistHostUniqueIDEnvVar, "persist-host-unique-id", true,
+		"Whether INSTANA_PERSIST_HOST_UNIQUE_ID is set, which is only omitted for DaemonSets created by earlier versions.",
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+
+	if opts.filename == "" {
+		flags.Usage()
+		return opts, errors.New("a file must be given with -f")
+	}
+	if flags.NArg() > 0 {
+		return opts, fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == "openshift" {
+			opts.openShift = &openShift
+		}
+	})
+	opts.zones = splitList(zones)
+	opts.etcdTargets = splitList(etcdTargets)
+
+	return opts, nil
+}
+
+// renderMain runs the render subcommand and returns the exit code of the process
+func renderMain(args []string) int {
+	switch err := runRender(args, os.Stdout, os.Stderr); {
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case err != nil:
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	default:
+		return 0
+	}
+}
+
+// runRender implements the render subcommand. It runs the builders of the reconcilers for the resources given in the
+// file and prints the results as YAML documents.
+func runRender(args []string, stdout io.Writer, stderr io.Writer) error {
+	opts, err := parseRenderOptions(args, stderr)
+	if err != nil {
+		return err
+	}
+
+	objects, err := readObjects(opts.filename)
+	if err != nil {
+		return err
+	}
+
+	var keysSecret *corev1.Secret
+	if opts.keysSecretFile != "" {
+		if keysSecret, err = readKeysSecret(opts.keysSecretFile); err != nil {
+			return err
+		}
+	}
+
+	secrets := map[string]*corev1.Secret{}
+	if opts.secretsFile != "" {
+		if secrets, err = readSecrets(opts.secretsFile); err != nil {
+			return err
+		}
+	}
+
+	namespacesDetails := namespaces.NewNamespacesDetails(nil)
+	if opts.namespacesFile != "" {
+		if namespacesDetails, err = readNamespacesDetails(opts.namespacesFile); err != nil {
+			return err
+		}
+	}
+
+	rendered := 0
+	for _, obj := range objects {
+		var built []client.Object
+
+		switch cr := obj.(type) {
+		case *agentoperatorv2.InstanaAgent:
+			agent := &agentoperatorv1.InstanaAgent{}
+			if err := cr.ConvertTo(agent); err != nil {
+				return fmt.Errorf("failed to convert InstanaAgent %s to instana.io/v1: %w", cr.Name, err)
+			}
+			if built, err = renderAgent(agent, opts, keysSecret, secrets, namespacesDetails, stderr); err != nil {
+				return err
+			}
+		case *agentoperatorv1.InstanaAgent:
+			if built, err = renderAgent(cr, opts, keysSecret, secrets, namespacesDetails, stderr); err != nil {
+				return err
+			}
+		case *agentoperatorv2.InstanaAgentRemote:
+			agent := &agentoperatorv1.InstanaAgentRemote{}
+			if err := cr.ConvertTo(agent); err != nil {
+				return fmt.Errorf("failed to convert InstanaAgentRemote %s to instana.io/v1: %w", cr.Name, err)
+			}
+			built = renderAgentRemote(agent, keysSecret, secrets, stderr)
+		case *agentoperatorv1.InstanaAgentRemote:
+			built = renderAgentRemote(cr, keysSecret, secrets, stderr)
+		default:
+			continue
+		}
+
+		if err := writeObjects(stdout, built); err != nil {
+			return err
+		}
+		rendered++
+	}
+
+	if rendered == 0 {
+		return fmt.Errorf("%s contains no InstanaAgent or InstanaAgentRemote", opts.filename)
+	}
+
+	return nil
+}
+
+func renderAgent(
+	agent *agentoperatorv1.InstanaAgent,
+	opts renderOptions,
+	keysSecret *corev1.Secret,
+	secrets map[string]*corev1.Secret,
+	namespacesDetails namespaces.NamespacesDetails,
+	stderr io.Writer,
+) ([]client.Object, error) {
+	if agent.Namespace == "" {
+		agent.Namespace = defaultOperatorNamespace
+	}
+	agent.Default()
+
+	configurationErrs := agent.Spec.Validate()
+	if keysSecret == nil {
+		keysSecret = &corev1.Secret{}
+	} else {
+		configurationErrs = append(configurationErrs, agent.Spec.Agent.ValidateKeysSecret(keysSecret)...)
+	}
+	if pointer.DerefOrDefault(agent.Spec.UseSecretMounts, true) {
+		configurationErrs = append(configurationErrs, agent.Spec.Agent.ResolveSecretRefs(getSecret(secrets))...)
+	}
+	for _, err := range configurationErrs {
+		fmt.Fprintf(stderr, "warning: InstanaAgent %s: %s\n", agent.Name, err.Error())
+	}
+
+	if len(opts.zones) > 0 {
+		zones := make([]agentoperatorv1.Zone, 0, len(opts.zones))
+		for _, name := range opts.zones {
+			i := slices.IndexFunc(agent.Spec.Zones, func(zone agentoperatorv1.Zone) bool {
+				return zone.Name.Name == name
+			})
+			if i < 0 {
+				return nil, fmt.Errorf("InstanaAgent %s has no zone %s", agent.Name, name)
+			}
+			zones = append(zones, agent.Spec.Zones[i])
+		}
+		agent.Spec.Zones = zones
+	}
+
+	isOpenShift := pointer.DerefOrDefault(agent.Spec.OpenShift, false)
+	if opts.openShift != nil {
+		isOpenShift = *opts.openShift
+	}
+
+	var deploymentContext *k8ssensordeployment.DeploymentContext
+	switch {
+	case isOpenShift:
+		deploymentContext = &k8ssensordeployment.DeploymentContext{
+			OpenShiftETCDResourcesExist: opts.openShiftETCDResources,
+		}
+	case len(opts.etcdTargets) > 0:
+		deploymentContext = &k8ssensordeployment.DeploymentContext{
+			DiscoveredETCDTargets: slices.Sorted(slices.Values(opts.etcdTargets)),
+		}
+		if opts.etcdCAFound {
+			deploymentContext.ETCDCASecretName = constants.ETCDCASecretName
+		}
+	}
+
+	// Without a cluster there is no certificate issued by cert-manager that the agents could be rolled out with
+	if agent.Spec.Agent.TlsSpec.CertManager != nil {
+		return nil, fmt.Errorf("InstanaAgent %s: agent.tls.certManager is not supported by %s", agent.Name, renderCommand)
+	}
+
+	var tlsSecret *corev1.Secret
+	generatedTLS, err := generateRenderTLS(agent, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the TLS certificate of InstanaAgent %s: %w", agent.Name, err)
+	}
+	if generatedTLS != nil {
+		tlsSecret = &corev1.Secret{Data: generatedtls.SecretData(generatedTLS)}
+	}
+
+	builders := controllers.NewAgentBuilders(
+		agent,
+		status.NewAgentStatusManager(nil, nil),
+		controllers.AgentBuilderOptions{
+			IsOpenShift:               isOpenShift,
+			PersistHostUniqueIDEnvVar: opts.persistHostUniqueIDEnvVar,
+			KeysSecret:                keysSecret,
+			TLSSecret:                 tlsSecret,
+			GeneratedTLS:              generatedTLS,
+			K8SensorBackends:          controllers.NewK8SensorBackends(agent),
+			NamespacesDetails:         namespacesDetails,
+			DeploymentContext:         deploymentContext,
+		},
+	)
+
+	return buildObjects(transformations.NewTransformations(agent), builders), nil
+}
+
+// generateRenderTLS generates a new CA and certificate of the agent if spec.agent.tls.autoGenerate is set, like the
+// operator does on the first reconcile. Rendered certificates are not rotated.
+func generateRenderTLS(agent *agentoperatorv1.InstanaAgent, now time.Time) (*certgen.Certificates, error) {
+	if !agent.Spec.Agent.TlsSpec.AutoGenerate {
+		return nil, nil
+	}
+
+	ca, err := certgen.GenerateCA(agent.Name+"."+agent.Namespace+" CA", now)
+	if err != nil {
+		return nil, err
+	}
+	serving, err := certgen.GenerateServing(ca, helpers.NewHelpers(agent).ServiceDNSNames(), now)
+	if err != nil {
+		return nil, err
+	}
+	return &certgen.Certificates{CA: ca, Serving: serving}, nil
+}
+
+func renderAgentRemote(
+	agent *agentoperatorv1.InstanaAgentRemote,
+	keysSecret *corev1.Secret,
+	secrets map[string]*corev1.Secret,
+	stderr io.Writer,
+) []client.Object {
+	if agent.Namespace == "" {
+		agent.Namespace = defaultOperatorNamespace
+	}
+	agent.Default()
+
+	configurationErrs := agent.Spec.Validate()
+	if keysSecret == nil {
+		keysSecret = &corev1.Secret{}
+	} else {
+		configurationErrs = append(configurationErrs, agent.Spec.Agent.ValidateKeysSecret(keysSecret)...)
+	}
+	if pointer.DerefOrDefault(agent.Spec.UseSecretMounts, true) {
+		configurationErrs = append(configurationErrs, agent.Spec.Agent.ResolveSecretRefs(getSecret(secrets))...)
+	}
+	for _, err := range configurationErrs {
+		fmt.Fprintf(stderr, "warning: InstanaAgentRemote %s: %s\n", agent.Name, err.Error())
+	}
+
+	builders := controllers.NewRemoteAgentBuilders(
+		agent,
+		status.NewInstanaAgentRemoteStatusManager(nil, nil),
+		keysSecret,
+		nil,
+		controllers.NewRemoteSensorBackends(agent),
+	)
+
+	return buildObjects(transformations.NewTransformationsRemote(agent), builders)
+}
+
+// buildObjects transforms the built objects the same way as they are before being applied
+func buildObjects(t transformations.Transformations, builders []builder.ObjectBuilder) []client.Object {
+	builderTransformer := builder.NewBuilderTransformer(t)
+
+	objects := make([]client.Object, 0, len(builders))
+	for _, bldr := range builders {
+		if obj := builderTransformer.Apply(bldr); obj.IsPresent() {
+			objects = append(objects, obj.Get())
+		}
+	}
+	return objects
+}
+
+func writeObjects(w io.Writer, objects []client.Object) error {
+	for _, obj := range objects {
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("failed to serialize %s %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readObjects decodes all YAML or JSON documents of a file using the operator scheme
+func readObjects(filename string) ([]k8sruntime.Object, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(f))
+
+	var objects []k8sruntime.Object
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", filename, err)
+		}
+		objects = append(objects, obj)
+	}
+}
+
+func readKeysSecret(filename string) (*corev1.Secret, error) {
+	objects, err := readObjects(filename)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objects {
+		if secret, ok := obj.(*corev1.Secret); ok {
+			return secret, nil
+		}
+	}
+	return nil, fmt.Errorf("%s contains no Secret", filename)
+}
+
+// readSecrets reads the Secrets referenced by the credentials of the agents by name
+func readSecrets(filename string) (map[string]*corev1.Secret, error) {
+	objects, err := readObjects(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make(map[string]*corev1.Secret)
+	for _, obj := range objects {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			continue
+		}
+		// stringData is merged into data by the API server, which does not see these Secrets
+		for key, value := range secret.StringData {
+			if secret.Data == nil {
+				secret.Data = make(map[string][]byte, len(secret.StringData))
+			}
+			secret.Data[key] = []byte(value)
+		}
+		secrets[secret.Name] = secret
+	}
+	return secrets, nil
+}
+
+// getSecret looks up the referenced Secrets among the given ones, Secrets that were not given are reported as not
+// found, so that the references are warned about like missing Secrets in the cluster
+func getSecret(secrets map[string]*corev1.Secret) func(name string) (*corev1.Secret, error) {
+	return func(name string) (*corev1.Secret, error) {
+		if secret, ok := secrets[name]; ok {
+			return secret, nil
+		}
+		return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+	}
+}
+
+func readNamespacesDetails(filename string) (namespaces.NamespacesDetails, error) {
+	objects, err := readObjects(filename)
+	if err != nil {
+		return namespaces.NamespacesDetails{}, err
+	}
+
+	var namespaceList []corev1.Namespace
+	for _, obj := range objects {
+		switch ns := obj.(type) {
+		case *corev1.Namespace:
+			namespaceList = append(namespaceList, *ns)
+		case *corev1.NamespaceList:
+			namespaceList = append(namespaceList, ns.Items...)
+		}
+	}
+	return namespaces.NewNamespacesDetails(namespaceList), nil
+}
+
+func splitList(list string) []string {
+	var items []string
+	for item := range strings.SplitSeq(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
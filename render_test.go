@@ -0,0 +1,346 @@
+/*
+(c) Copyright IBM Corp. 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/instana/instana-agent-operator/pkg/k8s/object/builders/common/constants"
+)
+
+const renderAgentYaml = `
+apiVersion: instana.io/v1
+kind: InstanaAgent
+metadata:
+  name: instana-agent
+spec:
+  cluster:
+    name: my-cluster
+  agent:
+    keysSecret: instana-agent-keys
+    endpointHost: ingress-red-saas.instana.io
+    endpointPort: "443"
+  zones:
+    - name: zone-a
+    - name: zone-b
+`
+
+const renderKeysSecretYaml = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: instana-agent-keys
+  namespace: instana-agent
+data:
+  key: YWdlbnQta2V5
+`
+
+func writeRenderFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// renderedDocuments splits the rendered output at the separator lines, which PEM encoded content does not match
+func renderedDocuments(out string) iter.Seq[string] {
+	return strings.SplitSeq(strings.TrimPrefix(out, "---\n"), "\n---\n")
+}
+
+// renderedKinds returns the kind and name of every rendered document
+func renderedKinds(t *testing.T, out string) map[string][]string {
+	kinds := make(map[string][]string)
+	for doc := range renderedDocuments(out) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		obj := struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}{}
+		require.NoError(t, yaml.Unmarshal([]byte(doc), &obj))
+		kinds[obj.Kind] = append(kinds[obj.Kind], obj.Metadata.Name)
+	}
+	return kinds
+}
+
+func TestRenderPrintsResourcesOfInstanaAgent(t *testing.T) {
+	assertions := require.New(t)
+
+	agentFile := writeRenderFile(t, "agent.yaml", renderAgentYaml)
+	keysSecretFile := writeRenderFile(t, "keys.yaml", renderKeysSecretYaml)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	err := runRender(
+		[]string{"-f", agentFile, "--keys-secret", keysSecretFile, "--zones", "zone-b", "--openshift"},
+		stdout,
+		stderr,
+	)
+	assertions.NoError(err)
+	assertions.Empty(stderr.String())
+
+	kinds := renderedKinds(t, stdout.String())
+	assertions.Equal([]string{"instana-agent-zone-b"}, kinds["DaemonSet"])
+	assertions.Equal([]string{"instana-agent-k8sensor"}, kinds["Deployment"])
+	assertions.NotEmpty(kinds["ClusterRole"])
+	assertions.NotEmpty(kinds["Service"])
+	assertions.NotEmpty(kinds["Secret"])
+
+	// Rendered objects carry the labels and owner references of applied objects
+	for doc := range renderedDocuments(stdout.String()) {
+		if !strings.Contains(doc, "kind: DaemonSet") {
+			continue
+		}
+		ds := &appsv1.DaemonSet{}
+		assertions.NoError(yaml.Unmarshal([]byte(doc), ds))
+		assertions.Equal("instana-agent", ds.Namespace)
+		assertions.Equal("instana-agent-operator", ds.Labels["app.kubernetes.io/managed-by"])
+		assertions.Len(ds.OwnerReferences, 1)
+	}
+}
+
+func TestRenderWarnsAboutInvalidConfiguration(t *testing.T) {
+	assertions := require.New(t)
+
+	agentFile := writeRenderFile(t, "agent.yaml", renderAgentYaml)
+	keysSecretFile := writeRenderFile(t, "keys.yaml", strings.ReplaceAll(renderKeysSecretYaml, "key:", "other:"))
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	assertions.NoError(runRender([]string{"-f", agentFile, "--keys-secret", keysSecretFile}, stdout, stderr))
+
+	assertions.Contains(stderr.String(), "secret does not contain a value for key")
+	assertions.Equal(
+		[]string{"instana-agent-zone-a", "instana-agent-zone-b"},
+		renderedKinds(t, stdout.String())["DaemonSet"],
+	)
+}
+
+func TestRenderPrintsResourcesOfInstanaAgentRemote(t *testing.T) {
+	assertions := require.New(t)
+
+	remoteFile := writeRenderFile(t, "remote.yaml", `
+apiVersion: instana.io/v1
+kind: InstanaAgentRemote
+metadata:
+  name: remote
+  namespace: instana-agent
+spec:
+  zone:
+    name: remote-zone
+  agent:
+    key: agent-key
+    endpointHost: ingress-red-saas.instana.io
+    endpointPort: "443"
+`)
+
+	stdout := &bytes.Buffer{}
+	assertions.NoError(runRender([]string{"-f", remoteFile}, stdout, &bytes.Buffer{}))
+
+	kinds := renderedKinds(t, stdout.String())
+	assertions.Equal([]string{"instana-agent-r-remote"}, kinds["Deployment"])
+	assertions.NotContains(kinds, "DaemonSet")
+}
+
+func TestRenderPrintsResourcesOfV2InstanaAgentRemote(t *testing.T) {
+	assertions := require.New(t)
+
+	remoteFile := writeRenderFile(t, "remote.yaml", `
+apiVersion: instana.io/v2
+kind: InstanaAgentRemote
+metadata:
+  name: remote
+  namespace: instana-agent
+spec:
+  zone:
+    name: remote-zone
+  agent:
+    backends:
+      - endpointHost: ingress-red-saas.instana.io
+        endpointPort: "443"
+        key: agent-key
+`)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	assertions.NoError(runRender([]string{"-f", remoteFile}, stdout, stderr))
+	assertions.Empty(stderr.String())
+
+	kinds := renderedKinds(t, stdout.String())
+	assertions.Equal([]string{"instana-agent-r-remote"}, kinds["Deployment"])
+	assertions.Contains(stdout.String(), "ingress-red-saas.instana.io")
+}
+
+func TestRenderResolvesReferencedCredentials(t *testing.T) {
+	assertions := require.New(t)
+
+	agentFile := writeRenderFile(
+		t,
+		"agent.yaml",
+		strings.Replace(
+			renderAgentYaml,
+			"    endpointPort: \"443\"\n",
+			"    endpointPort: \"443\"\n    proxyHost: proxy\n    proxyUser: user\n"+
+				"    proxyPasswordSecretRef:\n      name: proxy\n      key: password\n",
+			1,
+		),
+	)
+	keysSecretFile := writeRenderFile(t, "keys.yaml", renderKeysSecretYaml)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	assertions.NoError(runRender([]string{"-f", agentFile, "--keys-secret", keysSecretFile}, stdout, stderr))
+	assertions.Contains(stderr.String(), "spec.agent.proxyPasswordSecretRef.name: Not found: \"proxy\"")
+
+	secretsFile := writeRenderFile(t, "secrets.yaml", `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: proxy
+  namespace: instana-agent
+stringData:
+  password: proxy-password
+`)
+
+	stdout.Reset()
+	stderr.Reset()
+	assertions.NoError(
+		runRender(
+			[]string{"-f", agentFile, "--keys-secret", keysSecretFile, "--secrets", secretsFile},
+			stdout,
+			stderr,
+		),
+	)
+	assertions.Empty(stderr.String())
+
+	// the resolved password is rendered into the agent configuration
+	var rendered []byte
+	for doc := range renderedDocuments(stdout.String()) {
+		if !strings.Contains(doc, "\nkind: Secret\n") {
+			continue
+		}
+		secret := &corev1.Secret{}
+		assertions.NoError(yaml.Unmarshal([]byte(doc), secret))
+		for _, value := range secret.Data {
+			rendered = append(rendered, value...)
+		}
+	}
+	assertions.Contains(string(rendered), "proxy-password")
+}
+
+func TestRenderReadsNamespacesFile(t *testing.T) {
+	namespacesFile := writeRenderFile(t, "namespaces.yaml", `
+apiVersion: v1
+kind: NamespaceList
+items:
+  - metadata:
+      name: monitored
+      labels:
+        instana-workload-monitoring: "true"
+  - metadata:
+      name: unlabelled
+`)
+
+	details, err := readNamespacesDetails(namespacesFile)
+	require.NoError(t, err)
+	require.Len(t, details.Namespaces, 1)
+	require.Equal(t, "true", details.Namespaces["monitored"].Labels["instana-workload-monitoring"])
+}
+
+func TestRenderRejectsMissingInput(t *testing.T) {
+	assertions := require.New(t)
+
+	assertions.Error(runRender(nil, &bytes.Buffer{}, &bytes.Buffer{}))
+
+	emptyFile := writeRenderFile(t, "empty.yaml", renderKeysSecretYaml)
+	assertions.ErrorContains(
+		runRender([]string{"-f", emptyFile}, &bytes.Buffer{}, &bytes.Buffer{}),
+		"contains no InstanaAgent or InstanaAgentRemote",
+	)
+
+	agentFile := writeRenderFile(t, "agent.yaml", renderAgentYaml)
+	assertions.ErrorContains(
+		runRender([]string{"-f", agentFile, "--zones", "zone-c"}, &bytes.Buffer{}, &bytes.Buffer{}),
+		"has no zone zone-c",
+	)
+}
+
+func TestRenderGeneratesTLSCertificate(t *testing.T) {
+	assertions := require.New(t)
+
+	agentFile := writeRenderFile(
+		t,
+		"agent.yaml",
+		strings.Replace(
+			renderAgentYaml,
+			"    endpointPort: \"443\"\n",
+			"    endpointPort: \"443\"\n    tls:\n      autoGenerate: true\n",
+			1,
+		),
+	)
+
+	stdout := &bytes.Buffer{}
+	assertions.NoError(runRender([]string{"-f", agentFile}, stdout, &bytes.Buffer{}))
+
+	kinds := renderedKinds(t, stdout.String())
+	assertions.Subset(kinds["Secret"], []string{"instana-agent-tls", "instana-agent-tls-ca"})
+	assertions.Contains(kinds["ConfigMap"], "instana-agent-ca")
+
+	for doc := range renderedDocuments(stdout.String()) {
+		if !strings.Contains(doc, "kind: DaemonSet") {
+			continue
+		}
+		ds := &appsv1.DaemonSet{}
+		assertions.NoError(yaml.Unmarshal([]byte(doc), ds))
+		assertions.True(slices.ContainsFunc(ds.Spec.Template.Spec.Volumes, func(volume corev1.Volume) bool {
+			return volume.Secret != nil && volume.Secret.SecretName == "instana-agent-tls"
+		}))
+		assertions.NotEmpty(ds.Spec.Template.Annotations[constants.AnnotationTLSChecksum])
+	}
+}
+
+func TestRenderRejectsCertManager(t *testing.T) {
+	agentFile := writeRenderFile(
+		t,
+		"agent.yaml",
+		strings.Replace(
+			renderAgentYaml,
+			"    endpointPort: \"443\"\n",
+			"    endpointPort: \"443\"\n    tls:\n      certManager:\n        issuerRef:\n          name: issuer\n",
+			1,
+		),
+	)
+
+	require.ErrorContains(
+		t,
+		runRender([]string{"-f", agentFile}, &bytes.Buffer{}, &bytes.Buffer{}),
+		"agent.tls.certManager is not supported by render",
+	)
+}